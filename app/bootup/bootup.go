@@ -2,12 +2,15 @@ package bootup
 
 import (
 	"context"
+	"math/big"
+	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gammazero/workerpool"
 	"github.com/itzmeanjan/harmony/app/config"
 	"github.com/itzmeanjan/harmony/app/data"
 	"github.com/itzmeanjan/harmony/app/graph"
@@ -40,7 +43,7 @@ func SetGround(ctx context.Context, file string) (*data.Resource, error) {
 		return nil, err
 	}
 
-	client, err := rpc.DialContext(ctx, config.Get("RPCUrl"))
+	upstreams, err := data.NewUpstreams(ctx, config.GetRPCUrls())
 	if err != nil {
 		return nil, err
 	}
@@ -56,6 +59,8 @@ func SetGround(ctx context.Context, file string) (*data.Resource, error) {
 	}
 
 	// Attempt to read current network ID
+	client, _ := upstreams.Active()
+
 	network, err := GetNetwork(ctx, client)
 	if err != nil {
 		return nil, err
@@ -68,52 +73,113 @@ func SetGround(ctx context.Context, file string) (*data.Resource, error) {
 	inPendingPoolChan := make(chan *data.MemPoolTx, 4096)
 	lastSeenBlockChan := make(chan uint64, 16)
 
+	// Dead man's switch, both pools let it know when
+	// new tx gets added, so that it can raise alert if nothing
+	// is seen for configured period of time
+	watchdog := &data.Watchdog{
+		LastSeenAt: time.Now().UTC(),
+		SeenChan:   make(chan time.Time, 1),
+		PubSub:     publisher,
+	}
+
+	// Tx(s) sent from/ to watched addresses, entering pending pool,
+	// to be POST-ed to configured URL, by these workers
+	webhook := &data.Webhook{
+		URL:       config.GetWebhookURL(),
+		Retries:   config.GetWebhookRetries(),
+		QueueSize: config.GetWebhookQueueSize(),
+		Workers:   workerpool.New(config.GetConcurrencyFactor()),
+		Client:    &http.Client{Timeout: 10 * time.Second},
+	}
+
+	// Gas usage of tx(s) entering pending pool to be estimated by
+	// these workers, if enabled, results sent back to pending pool
+	estimatedGasChan := make(chan data.EstimatedGas, 1)
+	estimator := &data.GasEstimator{
+		Enabled:    config.GetGasEstimationChoice(),
+		QueueSize:  config.GetGasEstimationQueueSize(),
+		Upstreams:  upstreams,
+		Workers:    workerpool.New(config.GetConcurrencyFactor()),
+		ResultChan: estimatedGasChan,
+	}
+
 	// initialising pending pool
 	pendingPool := &data.PendingPool{
 		Transactions:             make(map[common.Hash]*data.MemPoolTx),
 		TxsFromAddress:           make(map[common.Address]data.TxList),
 		DroppedTxs:               make(map[common.Hash]time.Time),
 		RemovedTxs:               make(map[common.Hash]time.Time),
-		AscTxsByGasPrice:         make(data.MemPoolTxsAsc, 0, config.GetPendingPoolSize()),
-		DescTxsByGasPrice:        make(data.MemPoolTxsDesc, 0, config.GetPendingPoolSize()),
+		TxsByGasPrice:            data.NewSortedTxs(),
 		Done:                     0,
 		LastSeenBlock:            0,
 		LastSeenAt:               time.Now().UTC(),
+		InFlight:                 big.NewInt(0),
 		AddTxChan:                make(chan data.AddRequest, 1),
 		AddFromQueuedPoolChan:    make(chan data.AddRequest, 1),
 		RemoveTxChan:             make(chan data.RemoveRequest, 1),
 		AlreadyInPendingPoolChan: alreadyInPendingPoolChan,
 		InPendingPoolChan:        inPendingPoolChan,
 		TxExistsChan:             make(chan data.ExistsRequest, 1),
+		TxsExistChan:             make(chan data.ExistsManyRequest, 1),
 		GetTxChan:                make(chan data.GetRequest, 1),
 		CountTxsChan:             make(chan data.CountRequest, 1),
 		ListTxsChan:              make(chan data.ListRequest, 1),
 		TxsFromAChan:             make(chan data.TxsFromARequest, 1),
+		TxsByGasPriceRangeChan:   make(chan data.GasPriceRangeRequest, 1),
+		GasPricePercentileChan:   make(chan data.PercentileRequest, 1),
+		IncludablePercentileChan: make(chan data.PercentilesRequest, 1),
+		DisplacedByChan:          make(chan data.DisplacedByRequest, 1),
+		SimulateCandidateChan:    make(chan data.SimulateCandidateRequest, 1),
+		OnRemoveChan:             make(chan data.OnRemoveRequest, 1),
+		OnAddChan:                make(chan data.OnAddRequest, 1),
+		RestoreChan:              make(chan data.RestoreRequest, 1),
+		MarkMinedChan:            make(chan data.MarkMinedRequest, 1),
+		OldestPerSenderChan:      make(chan chan map[common.Address]*data.MemPoolTx, 1),
 		DoneChan:                 make(chan chan uint64, 1),
 		SetLastSeenBlockChan:     lastSeenBlockChan,
+		SetBaseFeeChan:           make(chan *big.Int, 1),
+		SetClockSkewChan:         make(chan time.Duration, 1),
 		LastSeenBlockChan:        make(chan chan data.LastSeenBlock, 1),
+		SenderDominanceChan:      make(chan chan data.SenderDominance, 1),
+		ClockSkewChan:            make(chan chan time.Duration, 1),
+		BaseFeeChan:              make(chan chan *big.Int, 1),
+		ValueInFlightChan:        make(chan chan *big.Int, 1),
+		InclusionLatencyChan:     make(chan data.InclusionLatencyRequest, 1),
+		GasRecommendationChan:    make(chan chan data.GasRecommendation, 1),
+		HeavilyReplacedChan:      make(chan data.HeavilyReplacedRequest, 1),
+		EstimatedGasChan:         estimatedGasChan,
 		PubSub:                   publisher,
-		RPC:                      client,
+		Upstreams:                upstreams,
+		Watchdog:                 watchdog,
+		Webhook:                  webhook,
+		Estimator:                estimator,
+		GasPriceBuckets:          config.GetGasPriceBuckets(),
 	}
 
 	// initialising queued pool
 	queuedPool := &data.QueuedPool{
-		Transactions:      make(map[common.Hash]*data.MemPoolTx),
-		TxsFromAddress:    make(map[common.Address]data.TxList),
-		DroppedTxs:        make(map[common.Hash]time.Time),
-		RemovedTxs:        make(map[common.Hash]time.Time),
-		AscTxsByGasPrice:  make(data.MemPoolTxsAsc, 0, config.GetQueuedPoolSize()),
-		DescTxsByGasPrice: make(data.MemPoolTxsDesc, 0, config.GetQueuedPoolSize()),
-		AddTxChan:         make(chan data.AddRequest, 1),
-		RemoveTxChan:      make(chan data.RemovedUnstuckTx, 1),
-		TxExistsChan:      make(chan data.ExistsRequest, 1),
-		GetTxChan:         make(chan data.GetRequest, 1),
-		CountTxsChan:      make(chan data.CountRequest, 1),
-		ListTxsChan:       make(chan data.ListRequest, 1),
-		TxsFromAChan:      make(chan data.TxsFromARequest, 1),
-		PubSub:            publisher,
-		RPC:               client,
-		PendingPool:       pendingPool,
+		Transactions:           make(map[common.Hash]*data.MemPoolTx),
+		TxsFromAddress:         make(map[common.Address]data.TxList),
+		DroppedTxs:             make(map[common.Hash]time.Time),
+		RemovedTxs:             make(map[common.Hash]time.Time),
+		TxsByGasPrice:          data.NewSortedTxs(),
+		AddTxChan:              make(chan data.AddRequest, 1),
+		RemoveTxChan:           make(chan data.RemovedUnstuckTx, 1),
+		TxExistsChan:           make(chan data.ExistsRequest, 1),
+		GetTxChan:              make(chan data.GetRequest, 1),
+		CountTxsChan:           make(chan data.CountRequest, 1),
+		ListTxsChan:            make(chan data.ListRequest, 1),
+		TxsFromAChan:           make(chan data.TxsFromARequest, 1),
+		TxsByGasPriceRangeChan: make(chan data.GasPriceRangeRequest, 1),
+		RestoreChan:            make(chan data.RestoreRequest, 1),
+		OnAddChan:              make(chan data.OnAddRequest, 1),
+		OnRemoveChan:           make(chan data.OnRemoveRequest, 1),
+		CancelPruneChan:        make(chan struct{}, 1),
+		SenderDominanceChan:    make(chan chan data.SenderDominance, 1),
+		PubSub:                 publisher,
+		Upstreams:              upstreams,
+		PendingPool:            pendingPool,
+		Watchdog:               watchdog,
 	}
 
 	pool := &data.MemPool{
@@ -127,26 +193,45 @@ func SetGround(ctx context.Context, file string) (*data.Resource, error) {
 	notFoundTxsChan := make(chan listen.CaughtTxs, 16)
 	confirmedTxsChan := make(chan data.ConfirmedTx, 4096)
 
+	// When recorded mempool content is being replayed, pending pool
+	// isn't pruned as blocks get mined on live chain, otherwise it'd no
+	// more be reproducing recorded scenario. Queued pool pruner is
+	// driven by what enters pending pool, so it's kept running.
+	replay := len(config.GetReplayDir()) != 0
+
 	// Starting pool life cycle manager go routine
 	go pool.Pending.Start(ctx)
 	// (a)
 	//
 	// After that this pool will also let (b) know that it can
 	// update state of txs, which have become unstuck
-	go pool.Pending.Prune(ctx, caughtTxsChan, confirmedTxsChan, notFoundTxsChan)
+	if !replay {
+		go pool.Pending.Prune(ctx, caughtTxsChan, confirmedTxsChan, notFoundTxsChan)
+	}
 	go pool.Queued.Start(ctx)
 	// (b)
 	go pool.Queued.Prune(ctx, confirmedTxsChan, alreadyInPendingPoolChan)
+	go watchdog.Start(ctx)
+
+	// Why tx(s) leave mempool to be remembered from very beginning,
+	// so that waiting on one which already left, can be answered
+	if !pool.TrackRemovals(ctx) {
+		return nil, ctx.Err()
+	}
 
 	// This worker will supervise block header listener, so that it can keep
 	// track of their health & if they die due to some abnormal reasons
 	// it'll spawn a new one after a static delay of x time unit ( see below )
 	go func() {
 
+		if replay {
+			return
+		}
+
 		var died bool
 
 		healthChan := make(chan struct{})
-		go listen.SubscribeHead(ctx, wsClient, pool.Pending.GetLastSeenBlock().Number, caughtTxsChan, lastSeenBlockChan, healthChan)
+		go listen.SubscribeHead(ctx, wsClient, upstreams, pool.Pending.GetLastSeenBlock(ctx).Number, caughtTxsChan, lastSeenBlockChan, healthChan)
 
 		for {
 
@@ -155,7 +240,7 @@ func SetGround(ctx context.Context, file string) (*data.Resource, error) {
 				<-time.After(time.Duration(5) * time.Second)
 
 				healthChan = make(chan struct{})
-				go listen.SubscribeHead(ctx, wsClient, pool.Pending.GetLastSeenBlock().Number, caughtTxsChan, lastSeenBlockChan, healthChan)
+				go listen.SubscribeHead(ctx, wsClient, upstreams, pool.Pending.GetLastSeenBlock(ctx).Number, caughtTxsChan, lastSeenBlockChan, healthChan)
 
 				died = false
 			}
@@ -179,7 +264,25 @@ func SetGround(ctx context.Context, file string) (*data.Resource, error) {
 
 	}()
 
-	go data.TrackNotFoundTxs(ctx, inPendingPoolChan, notFoundTxsChan, caughtTxsChan)
+	if !replay {
+		go data.TrackNotFoundTxs(ctx, inPendingPoolChan, notFoundTxsChan, caughtTxsChan)
+	} else {
+
+		// Pending pool still lets know about each tx it admits,
+		// nobody is tracking them, so they're discarded
+		go func() {
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-inPendingPoolChan:
+				}
+			}
+
+		}()
+
+	}
 
 	// Passed this mempool handle to graphql query resolver
 	if err := graph.InitMemPool(pool); err != nil {
@@ -199,7 +302,7 @@ func SetGround(ctx context.Context, file string) (*data.Resource, error) {
 	networking.InitParentContext(ctx)
 
 	return &data.Resource{
-		RPCClient: client,
+		Upstreams: upstreams,
 		WSClient:  wsClient,
 		Pool:      pool,
 		StartedAt: time.Now().UTC(),
@@ -5,6 +5,9 @@ import (
 	"log"
 	"math"
 	"runtime"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/viper"
 )
@@ -56,6 +59,81 @@ func GetMemPoolPollingPeriod() uint64 {
 
 }
 
+// GetRPCUrls - Comma separated list of `txpool` RPC API enabled Ethereum node
+// URLs, where first one is used to start with & others are failed over to
+func GetRPCUrls() []string {
+
+	urls := make([]string, 0)
+
+	for _, v := range strings.Split(Get("RPCUrl"), ",") {
+
+		if v = strings.TrimSpace(v); len(v) != 0 {
+			urls = append(urls, v)
+		}
+
+	}
+
+	return urls
+
+}
+
+// GetRPCHealthCheckPeriod - Failed RPC endpoint(s) to be checked for getting
+// back to healthy state, every these many milliseconds
+//
+// If not provided, by default it'll use 30000ms i.e. 30 seconds
+func GetRPCHealthCheckPeriod() uint64 {
+
+	if period := GetUint("RPCHealthCheckPeriod"); period != 0 {
+		return period
+	}
+
+	return 30000
+
+}
+
+// GetQueuedPruneInterval - Milliseconds between scheduled sweeps of queued
+// pool, looking for tx(s) which got unstuck, but were missed by event driven
+// pruning
+//
+// If not provided, by default it'll sweep every 60 seconds, 0 disables it
+func GetQueuedPruneInterval() uint64 {
+
+	if !viper.IsSet("QueuedPruneInterval") {
+		return 60000
+	}
+
+	return GetUint("QueuedPruneInterval")
+
+}
+
+// GetPollMaxRetries - #-of times failed `txpool_content` RPC call to be
+// retried, before giving up on polling
+//
+// If not provided, by default it'll retry 3 times
+func GetPollMaxRetries() uint64 {
+
+	if !viper.IsSet("PollMaxRetries") {
+		return 3
+	}
+
+	return GetUint("PollMaxRetries")
+
+}
+
+// GetPollRetryBaseDelay - Milliseconds to wait before first retry of failed
+// `txpool_content` RPC call, doubled for each subsequent retry
+//
+// If not provided, by default it'll use 500ms
+func GetPollRetryBaseDelay() uint64 {
+
+	if delay := GetUint("PollRetryBaseDelay"); delay != 0 {
+		return delay
+	}
+
+	return 500
+
+}
+
 // GetPendingPoolSize - Max #-of pending pool txs can be living in memory
 func GetPendingPoolSize() uint64 {
 
@@ -104,6 +182,80 @@ func GetPendingTxExitPublishTopic() string {
 
 }
 
+// GetPendingTxDroppedPublishTopic - Read provided topic name from `.env` file
+// where tx dropped from pending pool without being mined, to be published
+func GetPendingTxDroppedPublishTopic() string {
+
+	if v := Get("PendingTxDroppedTopic"); len(v) != 0 {
+		return v
+	}
+
+	log.Printf("[❗️] Failed to get topic for publishing tx dropped from pending pool, using `pending_pool_dropped`\n")
+	return "pending_pool_dropped"
+
+}
+
+// GetPendingTxEvictedPublishTopic - Read provided topic name from `.env` file
+// where tx evicted from pending pool, to be published
+func GetPendingTxEvictedPublishTopic() string {
+
+	if v := Get("PendingTxEvictedTopic"); len(v) != 0 {
+		return v
+	}
+
+	log.Printf("[❗️] Failed to get topic for publishing tx evicted from pending pool, using `pending_pool_evicted`\n")
+	return "pending_pool_evicted"
+
+}
+
+// GetPendingTxReplacementPublishTopic - Read provided topic name from `.env` file
+// where tx replacing some other pending tx i.e. same sender & nonce, to be published
+func GetPendingTxReplacementPublishTopic() string {
+
+	if v := Get("PendingTxReplacementTopic"); len(v) != 0 {
+		return v
+	}
+
+	log.Printf("[❗️] Failed to get topic for publishing pending tx replacement, using `pending_pool_replacement`\n")
+	return "pending_pool_replacement"
+
+}
+
+// GetGasPriceBuckets - Comma separated boundaries ( in Gwei ) of coarse gas price
+// buckets, tx joining pending pool to be also published on topic of bucket its
+// gas price falls in, so that subscribers can pick only ones they care about
+//
+// If not provided, by default it'll use `10,50` i.e. buckets of 0-10, 10-50 &
+// 50+ Gwei. Setting it empty disables bucket topics
+func GetGasPriceBuckets() []float64 {
+
+	if !viper.IsSet("GasPriceBuckets") {
+		return []float64{10, 50}
+	}
+
+	buckets := make([]float64, 0)
+
+	for _, v := range strings.Split(Get("GasPriceBuckets"), ",") {
+
+		if v = strings.TrimSpace(v); len(v) == 0 {
+			continue
+		}
+
+		boundary, err := strconv.ParseFloat(v, 64)
+		if err != nil || boundary <= 0 {
+			log.Printf("[❗️] Bad gas price bucket boundary `%s`, skipping\n", v)
+			continue
+		}
+
+		buckets = append(buckets, boundary)
+
+	}
+
+	sort.Float64s(buckets)
+	return buckets
+
+}
+
 // GetQueuedTxEntryPublishTopic - Read provided topic name from `.env` file
 // where newly added queued pool tx(s) to be published
 func GetQueuedTxEntryPublishTopic() string {
@@ -130,6 +282,483 @@ func GetQueuedTxExitPublishTopic() string {
 
 }
 
+// GetQueuedTxEvictedPublishTopic - Read provided topic name from `.env` file
+// where tx evicted from queued pool, to be published
+func GetQueuedTxEvictedPublishTopic() string {
+
+	if v := Get("QueuedTxEvictedTopic"); len(v) != 0 {
+		return v
+	}
+
+	log.Printf("[❗️] Failed to get topic for publishing tx evicted from queued pool, using `queued_pool_evicted`\n")
+	return "queued_pool_evicted"
+
+}
+
+// GetCompactRemovalChoice - Whether tx(s) leaving pending/ queued pool to be
+// published on exit topics in compact form i.e. only hash, reason & timestamp,
+// while full tx goes to opt-in topic, or not
+//
+// If not provided, by default it's disabled
+func GetCompactRemovalChoice() bool {
+
+	return GetBool("CompactRemovalEnabled")
+
+}
+
+// GetPendingTxExitFullPublishTopic - Read provided topic name from `.env` file
+// where full tx leaving pending pool to be published, when compact removal
+// events are enabled
+func GetPendingTxExitFullPublishTopic() string {
+
+	if v := Get("PendingTxExitFullTopic"); len(v) != 0 {
+		return v
+	}
+
+	log.Printf("[❗️] Failed to get topic for publishing full tx removed from pending pool, using `pending_pool_exit_full`\n")
+	return "pending_pool_exit_full"
+
+}
+
+// GetQueuedTxExitFullPublishTopic - Read provided topic name from `.env` file
+// where full tx leaving queued pool to be published, when compact removal
+// events are enabled
+func GetQueuedTxExitFullPublishTopic() string {
+
+	if v := Get("QueuedTxExitFullTopic"); len(v) != 0 {
+		return v
+	}
+
+	log.Printf("[❗️] Failed to get topic for publishing full tx removed from queued pool, using `queued_pool_exit_full`\n")
+	return "queued_pool_exit_full"
+
+}
+
+// GetGlobalPublishTopic - Optional topic, where all tx(s) joining/ leaving
+// any of pending/ queued pool to be published, in addition to pool specific
+// topics. If not provided, nothing is published on global topic
+func GetGlobalPublishTopic() string {
+
+	return Get("GlobalTxTopic")
+
+}
+
+// GetCategoryPublishTopic - Optional topic, where tx(s) of given category i.e.
+// `transfer`/ `contract-call`/ `deploy`, joining/ leaving any pool to be published,
+// in addition to pool specific topics. If not provided, nothing is published on
+// category topic
+func GetCategoryPublishTopic(category string) string {
+
+	switch category {
+	case "transfer":
+		return Get("TransferTxTopic")
+	case "contract-call":
+		return Get("ContractCallTxTopic")
+	case "deploy":
+		return Get("DeployTxTopic")
+	default:
+		return ""
+	}
+
+}
+
+// GetPendingEventSchemaVersion - Schema version, tx(s) joining/ leaving pending
+// pool to be published in, so that subscribers can be kept on older version
+//
+// If not provided/ unknown, latest version is used
+func GetPendingEventSchemaVersion() uint64 {
+
+	return GetUint("PendingEventSchemaVersion")
+
+}
+
+// GetQueuedEventSchemaVersion - Schema version, tx(s) joining/ leaving queued
+// pool to be published in, so that subscribers can be kept on older version
+//
+// If not provided/ unknown, latest version is used
+func GetQueuedEventSchemaVersion() uint64 {
+
+	return GetUint("QueuedEventSchemaVersion")
+
+}
+
+// GetPublishDedupWindow - If same tx is seen joining/ leaving same pool again
+// within this many milliseconds, it's not re-published, so that tx(s) flickering
+// between pools across polls don't flood subscribers
+//
+// If not provided, deduplication is disabled
+func GetPublishDedupWindow() uint64 {
+
+	return GetUint("PublishDedupWindow")
+
+}
+
+// GetReplacementCoalesceInterval - Tx replacing some pending tx, with same
+// (sender, nonce), to be published at max once every this many milliseconds,
+// carrying latest gas price, so that gas wars don't flood subscribers
+//
+// If not provided, by default it'll use 1000ms. Setting it to 0 disables coalescing
+func GetReplacementCoalesceInterval() uint64 {
+
+	if !viper.IsSet("ReplacementCoalesceInterval") {
+		return 1000
+	}
+
+	return GetUint("ReplacementCoalesceInterval")
+
+}
+
+// GetWebhookURL - Tx(s) sent from/ to watched addresses, entering pending
+// pool, to be POST-ed as JSON to this URL
+//
+// If not provided, webhook is disabled
+func GetWebhookURL() string {
+
+	return Get("WebhookURL")
+
+}
+
+// GetWebhookRetries - #-of times failed webhook delivery to be retried,
+// before giving up on it
+//
+// If not provided, by default it'll retry 3 times
+func GetWebhookRetries() uint64 {
+
+	if !viper.IsSet("WebhookRetries") {
+		return 3
+	}
+
+	return GetUint("WebhookRetries")
+
+}
+
+// GetWebhookQueueSize - #-of webhook deliveries which can be waiting/ in
+// progress at a time, beyond which new ones are dropped
+//
+// If not provided, by default it'll keep 1024 of them
+func GetWebhookQueueSize() uint64 {
+
+	if !viper.IsSet("WebhookQueueSize") {
+		return 1024
+	}
+
+	return GetUint("WebhookQueueSize")
+
+}
+
+// GetGasEstimationChoice - Whether gas usage of tx(s) entering pending pool
+// to be estimated using `eth_estimateGas` or not, costing one RPC call per tx
+//
+// If not provided, by default it's disabled
+func GetGasEstimationChoice() bool {
+
+	return GetBool("GasEstimationEnabled")
+
+}
+
+// GetGasEstimationQueueSize - #-of gas estimations which can be waiting/ in
+// progress at a time, beyond which new ones are dropped
+//
+// If not provided, by default it'll keep 1024 of them
+func GetGasEstimationQueueSize() uint64 {
+
+	if !viper.IsSet("GasEstimationQueueSize") {
+		return 1024
+	}
+
+	return GetUint("GasEstimationQueueSize")
+
+}
+
+// GetLogRateLimit - At max these many log lines sharing same format, emitted
+// from per tx paths i.e. publishing, admission, to be logged in every 10s
+// window, rest are summarised as count, once window ends
+//
+// If not provided, by default 10 lines per window are logged, 0 disables limiting
+func GetLogRateLimit() uint64 {
+
+	if !viper.IsSet("LogRateLimit") {
+		return 10
+	}
+
+	return GetUint("LogRateLimit")
+
+}
+
+// GetReplayDir - Directory holding recorded `txpool_content` responses, as JSON
+// files, to be fed into pools one on every poll, in lexical order of file names,
+// instead of polling RPC node
+//
+// If not provided, replaying is disabled
+func GetReplayDir() string {
+
+	return Get("ReplayDir")
+
+}
+
+// MaxBackfillDepth - Backfilling happens before start up completes, one
+// block at a time, so it's never done for more than these many blocks
+const MaxBackfillDepth = 256
+
+// GetBackfillDepth - During start up, tx(s) of these many latest blocks to be
+// replayed into pending pool as mined, so that stale `txpool_content` results
+// don't bring them in
+//
+// If not provided, backfilling is disabled. If more than `MaxBackfillDepth`,
+// it's capped at that.
+func GetBackfillDepth() uint64 {
+
+	depth := GetUint("BackfillDepth")
+	if depth > MaxBackfillDepth {
+
+		log.Printf("[❗️] Backfill depth %d too large, using %d\n", depth, MaxBackfillDepth)
+		return MaxBackfillDepth
+
+	}
+
+	return depth
+
+}
+
+// GetMaxTxsPerSender - At max these many tx(s) from single sender to be kept
+// in each of pending/ queued pool, so that spamming sender can't balloon
+// memory usage
+//
+// If not provided, there's no per sender cap
+func GetMaxTxsPerSender() uint64 {
+
+	return GetUint("MaxTxsPerSender")
+
+}
+
+// GetMaxSenders - At max these many distinct senders to be tracked in sender
+// index of each of pending/ queued pool, so that spam from many addresses
+// can't balloon memory usage. When full, tx(s) from new senders are rejected
+//
+// If not provided, there's no cap
+func GetMaxSenders() uint64 {
+
+	return GetUint("MaxSenders")
+
+}
+
+// GetDeadMansSwitchPeriod - If mempool doesn't see any new tx getting added
+// into any of pending/ queued pool for this many milliseconds, alert to be raised
+//
+// It's possible polling `txpool_content` keeps succeeding with empty results,
+// while there's actually some problem with node/ connectivity
+//
+// If not provided, by default it'll use 60000ms i.e. 1 minute
+func GetDeadMansSwitchPeriod() uint64 {
+
+	if period := GetUint("DeadMansSwitchPeriod"); period != 0 {
+		return period
+	}
+
+	return 60000
+
+}
+
+// GetClockSkewThreshold - If local clock is found to be off from latest block's
+// timestamp by more than this many milliseconds, warning to be logged
+//
+// If not provided, by default it'll use 30000ms i.e. 30 seconds
+func GetClockSkewThreshold() uint64 {
+
+	if threshold := GetUint("ClockSkewThreshold"); threshold != 0 {
+		return threshold
+	}
+
+	return 30000
+
+}
+
+// GetDeadMansSwitchPublishTopic - Read provided topic name from `.env` file
+// where dead man's switch alert ( & its clearance ) to be published
+func GetDeadMansSwitchPublishTopic() string {
+
+	if v := Get("DeadMansSwitchTopic"); len(v) != 0 {
+		return v
+	}
+
+	log.Printf("[❗️] Failed to get topic for publishing dead man's switch alert, using `dead_mans_switch`\n")
+	return "dead_mans_switch"
+
+}
+
+// GetStatsPublishPeriod - Aggregate mempool stats to be published
+// every `X` milliseconds
+//
+// If not provided, by default it'll use 5000ms i.e. 5 seconds
+func GetStatsPublishPeriod() uint64 {
+
+	if period := GetUint("StatsPublishPeriod"); period != 0 {
+		return period
+	}
+
+	return 5000
+
+}
+
+// GetStatsPublishTopic - Read provided topic name from `.env` file
+// where aggregate mempool stats to be published
+func GetStatsPublishTopic() string {
+
+	if v := Get("StatsTopic"); len(v) != 0 {
+		return v
+	}
+
+	log.Printf("[❗️] Failed to get topic for publishing mempool stats, using `pool_stats`\n")
+	return "pool_stats"
+
+}
+
+// GetAPIKeys - Comma separated list of API keys, allowed to access HTTP
+// endpoints. If none provided, authentication is disabled
+func GetAPIKeys() []string {
+
+	keys := make([]string, 0)
+
+	for _, v := range strings.Split(Get("APIKeys"), ",") {
+
+		if v = strings.TrimSpace(v); len(v) != 0 {
+			keys = append(keys, v)
+		}
+
+	}
+
+	return keys
+
+}
+
+// GetAPIRateLimit - #-of requests per second, each API key
+// is allowed to make
+//
+// If not provided, by default it'll use 10 req/s
+func GetAPIRateLimit() float64 {
+
+	if limit := GetFloat("APIRateLimit"); limit > 0 {
+		return limit
+	}
+
+	return 10
+
+}
+
+// GetAPIRateBurst - #-of requests, each API key is allowed to make
+// in a burst, beyond its rate limit
+//
+// If not provided, by default it'll use 20
+func GetAPIRateBurst() int {
+
+	if burst := GetUint("APIRateBurst"); burst != 0 {
+		return int(burst)
+	}
+
+	return 20
+
+}
+
+// GetSenderRecoveryChoice - Whether sender address of tx(s), missing `from`
+// field in RPC response, to be recovered from signature or not
+//
+// If not provided, by default it's enabled
+func GetSenderRecoveryChoice() bool {
+
+	if !viper.IsSet("SenderRecoveryEnabled") {
+		return true
+	}
+
+	return GetBool("SenderRecoveryEnabled")
+
+}
+
+// GetPollDedupChoice - Whether tx(s) reported in both pending & queued section
+// of same `txpool_content` response, to be considered only as pending or not
+//
+// If not provided, by default it's enabled
+func GetPollDedupChoice() bool {
+
+	if !viper.IsSet("PollDedupEnabled") {
+		return true
+	}
+
+	return GetBool("PollDedupEnabled")
+
+}
+
+// getPublishChoice - Whether events to be published on topic, toggled using
+// given key, or not
+//
+// If not provided, by default it's enabled
+func getPublishChoice(key string) bool {
+
+	if !viper.IsSet(key) {
+		return true
+	}
+
+	return GetBool(key)
+
+}
+
+// GetPendingTxEntryPublishChoice - Whether tx(s) entering pending pool to be
+// published or not, gas price bucket topics also follow it
+func GetPendingTxEntryPublishChoice() bool {
+	return getPublishChoice("PendingTxEntryEnabled")
+}
+
+// GetPendingTxExitPublishChoice - Whether tx(s) leaving pending pool to be
+// published or not
+func GetPendingTxExitPublishChoice() bool {
+	return getPublishChoice("PendingTxExitEnabled")
+}
+
+// GetPendingTxDroppedPublishChoice - Whether tx(s) dropped from pending pool,
+// without being mined, to be also published on dedicated topic or not
+func GetPendingTxDroppedPublishChoice() bool {
+	return getPublishChoice("PendingTxDroppedEnabled")
+}
+
+// GetPendingTxEvictedPublishChoice - Whether tx(s) evicted from pending
+// pool to be published or not
+func GetPendingTxEvictedPublishChoice() bool {
+	return getPublishChoice("PendingTxEvictedEnabled")
+}
+
+// GetPendingTxReplacementPublishChoice - Whether tx(s) replacing pending ones
+// to be published or not
+func GetPendingTxReplacementPublishChoice() bool {
+	return getPublishChoice("PendingTxReplacementEnabled")
+}
+
+// GetQueuedTxEntryPublishChoice - Whether tx(s) entering queued pool to be
+// published or not
+func GetQueuedTxEntryPublishChoice() bool {
+	return getPublishChoice("QueuedTxEntryEnabled")
+}
+
+// GetQueuedTxExitPublishChoice - Whether tx(s) leaving queued pool to be
+// published or not
+func GetQueuedTxExitPublishChoice() bool {
+	return getPublishChoice("QueuedTxExitEnabled")
+}
+
+// GetQueuedTxEvictedPublishChoice - Whether tx(s) evicted from queued
+// pool to be published or not
+func GetQueuedTxEvictedPublishChoice() bool {
+	return getPublishChoice("QueuedTxEvictedEnabled")
+}
+
+// GetSnapshotFile - Path to file, where pool snapshot to be written during
+// graceful shut down & read back from during start up
+//
+// If not provided, snapshotting is disabled
+func GetSnapshotFile() string {
+
+	return Get("SnapshotFile")
+
+}
+
 // GetConcurrencyFactor - Size of worker pool, is dictated by rule below
 //
 // @note You can set floating point value for `ConcurrencyFactor` ( > 0 )
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestGetBackfillDepth(t *testing.T) {
+
+	t.Cleanup(func() { viper.Set("BackfillDepth", 0) })
+
+	for given, expected := range map[uint64]uint64{
+		0:                    0,
+		16:                   16,
+		MaxBackfillDepth:     MaxBackfillDepth,
+		MaxBackfillDepth + 1: MaxBackfillDepth,
+		1 << 40:              MaxBackfillDepth,
+	} {
+
+		viper.Set("BackfillDepth", given)
+
+		if depth := GetBackfillDepth(); depth != expected {
+			t.Fatalf("given %d : expected %d, got %d", given, expected, depth)
+		}
+
+	}
+
+}
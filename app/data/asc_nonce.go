@@ -1,5 +1,7 @@
 package data
 
+import "bytes"
+
 // TxsFromAddressAsc - List of txs, sent from same address
 // sorted by their nonce
 type TxsFromAddressAsc []*MemPoolTx
@@ -19,6 +21,19 @@ func (t TxsFromAddressAsc) get() []*MemPoolTx {
 	return t
 }
 
+// placedBefore - Whether `a` is to be placed before `b`, as per nonce,
+// where tx(s) with same nonce i.e. replacements, are ordered by hash, so
+// that order doesn't depend on when they were seen
+func placedBefore(a *MemPoolTx, b *MemPoolTx) bool {
+
+	if a.Nonce != b.Nonce {
+		return a.Nonce < b.Nonce
+	}
+
+	return bytes.Compare(a.Hash.Bytes(), b.Hash.Bytes()) < 0
+
+}
+
 // findInsertionPoint - When attempting to insert new tx into this slice,
 // find index where to insert, so that it stays sorted ( ascending ), as per
 // nonce field of tx
@@ -30,7 +45,7 @@ func (t TxsFromAddressAsc) findInsertionPoint(low int, high int, tx *MemPoolTx)
 
 	if low == high {
 
-		if t[low].Nonce > tx.Nonce {
+		if placedBefore(tx, t[low]) {
 			return low
 		}
 
@@ -39,7 +54,7 @@ func (t TxsFromAddressAsc) findInsertionPoint(low int, high int, tx *MemPoolTx)
 	}
 
 	mid := (low + high) / 2
-	if t[mid].Nonce > tx.Nonce {
+	if placedBefore(tx, t[mid]) {
 
 		return t.findInsertionPoint(low, mid, tx)
 
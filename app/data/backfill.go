@@ -0,0 +1,79 @@
+package data
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// MarkMined - Lets pending pool know given tx(s) are already mined, those
+// living in pool are removed as confirmed, while others are remembered as
+// removed, returning #-of tx(s) marked
+func (p *PendingPool) MarkMined(ctx context.Context, hashes []common.Hash) int {
+
+	p.owner.enter()
+
+	respChan := make(chan int, 1)
+
+	select {
+	case <-ctx.Done():
+		return 0
+	case p.MarkMinedChan <- MarkMinedRequest{Txs: hashes, ResponseChan: respChan}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return 0
+	case v := <-respChan:
+		return v
+	}
+
+}
+
+// Backfill - Replays tx(s) of latest `depth` blocks into pending pool as
+// mined, so that removed tx cache is warm during start up & stale
+// `txpool_content` results don't bring them back in, returning #-of tx(s)
+// marked mined
+//
+// @note Latest block is also let known to pending pool, which warms up
+// base fee & clock skew, as if it was seen by header listener
+func (m *MemPool) Backfill(ctx context.Context, depth uint64) (int, error) {
+
+	var head hexutil.Uint64
+
+	client, _ := m.Pending.Upstreams.Active()
+
+	if err := client.CallContext(ctx, &head, "eth_blockNumber"); err != nil {
+		return 0, err
+	}
+
+	from := uint64(0)
+	if uint64(head)+1 > depth {
+		from = uint64(head) + 1 - depth
+	}
+
+	var count int
+
+	for number := from; number <= uint64(head); number++ {
+
+		var block struct {
+			Transactions []common.Hash `json:"transactions"`
+		}
+
+		if err := client.CallContext(ctx, &block, "eth_getBlockByNumber", hexutil.EncodeUint64(number), false); err != nil {
+			return count, err
+		}
+
+		count += m.Pending.MarkMined(ctx, block.Transactions)
+
+	}
+
+	select {
+	case <-ctx.Done():
+	case m.Pending.SetLastSeenBlockChan <- uint64(head):
+	}
+
+	return count, nil
+
+}
@@ -0,0 +1,111 @@
+package data
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// bloomBitsPerTx & bloomHashes - Keeps false positive rate
+// of pool bloom filter around 1%
+const (
+	bloomBitsPerTx = 10
+	bloomHashes    = 7
+)
+
+// PoolBloom - Bloom filter built once from `txpool_content` response,
+// so that common "definitely not present" case can be answered without
+// going over whole response
+type PoolBloom struct {
+	bits []uint64
+	size uint64
+}
+
+// NewPoolBloom - Builds bloom filter from all tx hashes present in
+// pending/ queued section of `txpool_content` response
+func NewPoolBloom(txs map[string]map[string]*MemPoolTx) *PoolBloom {
+
+	var count uint64
+	for _, v := range txs {
+		count += uint64(len(v))
+	}
+
+	size := count * bloomBitsPerTx
+	if size < 64 {
+		size = 64
+	}
+
+	b := &PoolBloom{
+		bits: make([]uint64, (size+63)/64),
+		size: size,
+	}
+
+	for _, v := range txs {
+		for _, tx := range v {
+			b.add(tx.Hash)
+		}
+	}
+
+	return b
+
+}
+
+// index - Tx hash is already uniformly distributed, so `i`-th
+// 4 byte chunk of it is used as `i`-th hash function
+func (b *PoolBloom) index(hash common.Hash, i int) uint64 {
+
+	return uint64(binary.BigEndian.Uint32(hash[i*4:])) % b.size
+
+}
+
+func (b *PoolBloom) add(hash common.Hash) {
+
+	for i := 0; i < bloomHashes; i++ {
+		idx := b.index(hash, i)
+		b.bits[idx/64] |= 1 << (idx % 64)
+	}
+
+}
+
+// MayContain - False means tx is definitely absent from response,
+// true means it may be present, which needs to be confirmed
+func (b *PoolBloom) MayContain(hash common.Hash) bool {
+
+	for i := 0; i < bloomHashes; i++ {
+		idx := b.index(hash, i)
+		if b.bits[idx/64]&(1<<(idx%64)) == 0 {
+			return false
+		}
+	}
+
+	return true
+
+}
+
+// IsPresent - Checks presence of tx in response this filter was built
+// from, falling back to exact lookup only when filter says it may be there
+//
+// Exact lookup goes over tx(s) of sender of tx, which response is keyed by,
+// only when sender isn't found that way, whole response is looked into
+func (b *PoolBloom) IsPresent(txs map[string]map[string]*MemPoolTx, tx *MemPoolTx) bool {
+
+	if !b.MayContain(tx.Hash) {
+		return false
+	}
+
+	fromA, ok := txs[tx.From.Hex()]
+	if !ok {
+		return IsPresentInCurrentPool(txs, tx.Hash)
+	}
+
+	for _, v := range fromA {
+
+		if v.Hash == tx.Hash {
+			return true
+		}
+
+	}
+
+	return false
+
+}
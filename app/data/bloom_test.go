@@ -0,0 +1,145 @@
+package data
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// uniformHashOf - Deterministic hash of `i`-th tx, uniformly distributed
+// same as real tx hash, which bloom filter relies on
+func uniformHashOf(i int) common.Hash {
+
+	return crypto.Keccak256Hash(hashOf(i).Bytes())
+
+}
+
+// pollResultOf - Given tx(s) grouped same way as `txpool_content`
+// response i.e. keyed by sender, then nonce
+func pollResultOf(txs ...*MemPoolTx) map[string]map[string]*MemPoolTx {
+
+	result := make(map[string]map[string]*MemPoolTx)
+
+	for _, tx := range txs {
+
+		from := tx.From.Hex()
+		if _, ok := result[from]; !ok {
+			result[from] = make(map[string]*MemPoolTx)
+		}
+
+		result[from][strconv.FormatUint(uint64(tx.Nonce), 10)] = tx
+
+	}
+
+	return result
+
+}
+
+func TestPoolBloom(t *testing.T) {
+
+	txs := make([]*MemPoolTx, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		txs = append(txs, legacyTx(uniformHashOf(i), addrOf(i%100), uint64(i/100), 1))
+	}
+
+	result := pollResultOf(txs...)
+	bloom := NewPoolBloom(result)
+
+	for _, tx := range txs {
+
+		if !bloom.IsPresent(result, tx) {
+			t.Fatalf("tx %s present in response, reported absent", tx.Hash.Hex())
+		}
+
+	}
+
+	for i := 1000; i < 2000; i++ {
+
+		if bloom.IsPresent(result, legacyTx(uniformHashOf(i), addrOf(i%100), 0, 1)) {
+			t.Fatalf("tx %s absent from response, reported present", uniformHashOf(i).Hex())
+		}
+
+	}
+
+	// Response not keyed by checksummed sender address,
+	// must still be found
+	lower := map[string]map[string]*MemPoolTx{"sender": {"0": txs[0]}}
+
+	if !NewPoolBloom(lower).IsPresent(lower, txs[0]) {
+		t.Fatal("tx present in response, keyed differently, reported absent")
+	}
+
+}
+
+func TestPruneStale(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	m := &MemPool{Pending: pools.pending, Queued: pools.queued}
+
+	live := legacyTx(hashOf(0), addrOf(0), 0, 1)
+	stale := legacyTx(hashOf(1), addrOf(1), 0, 1)
+	queued := legacyTx(hashOf(2), addrOf(2), 5, 1)
+
+	for _, tx := range []*MemPoolTx{live, stale} {
+
+		if !pools.pending.Add(ctx, tx) {
+			t.Fatalf("failed to add tx %s", tx.Hash.Hex())
+		}
+
+	}
+
+	if !pools.queued.Add(ctx, queued) {
+		t.Fatal("failed to add queued tx")
+	}
+
+	if n := m.PruneStale(ctx, pollResultOf(live), pollResultOf()); n != 2 {
+		t.Fatalf("expected 2 stale tx(s) to be pruned, pruned %d", n)
+	}
+
+	if !pools.pending.Exists(ctx, live.Hash) {
+		t.Fatal("tx present in response pruned")
+	}
+
+	if pools.pending.Exists(ctx, stale.Hash) || pools.queued.Exists(ctx, queued.Hash) {
+		t.Fatal("tx absent from response not pruned")
+	}
+
+}
+
+// BenchmarkPoolBloom - Checking absence of tx(s) from response of 100k tx(s),
+// by scanning whole response vs. asking bloom filter first
+func BenchmarkPoolBloom(b *testing.B) {
+
+	txs := make([]*MemPoolTx, 0, 100_000)
+	for i := 0; i < 100_000; i++ {
+		txs = append(txs, legacyTx(uniformHashOf(i), addrOf(i%10_000), uint64(i/10_000), 1))
+	}
+
+	result := pollResultOf(txs...)
+	absent := legacyTx(uniformHashOf(100_000), addrOf(0), 0, 1)
+
+	b.Run("scan", func(b *testing.B) {
+
+		for i := 0; i < b.N; i++ {
+			IsPresentInCurrentPool(result, absent.Hash)
+		}
+
+	})
+
+	b.Run("bloom", func(b *testing.B) {
+
+		bloom := NewPoolBloom(result)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			bloom.IsPresent(result, absent)
+		}
+
+	})
+
+}
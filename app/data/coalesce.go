@@ -0,0 +1,142 @@
+package data
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// replacementSlot - Tx(s) replacing each other share same sender & nonce
+type replacementSlot struct {
+	from  common.Address
+	nonce hexutil.Uint64
+}
+
+// replacementChains - Groups tx(s) by slot, keeping only slots where some tx
+// got replaced, each one ordered as per when tx(s) were seen, ties broken by
+// hash, so that result doesn't depend on order of `txs`
+func replacementChains(txs []*MemPoolTx) [][]*MemPoolTx {
+
+	bySlot := make(map[replacementSlot][]*MemPoolTx)
+
+	for _, tx := range txs {
+
+		slot := replacementSlot{from: tx.From, nonce: tx.Nonce}
+		bySlot[slot] = append(bySlot[slot], tx)
+
+	}
+
+	chains := make([][]*MemPoolTx, 0)
+
+	for _, chain := range bySlot {
+
+		if len(chain) < 2 {
+			continue
+		}
+
+		sort.Slice(chain, func(i, j int) bool {
+
+			if !chain[i].PendingFrom.Equal(chain[j].PendingFrom) {
+				return chain[i].PendingFrom.Before(chain[j].PendingFrom)
+			}
+
+			return bytes.Compare(chain[i].Hash.Bytes(), chain[j].Hash.Bytes()) < 0
+
+		})
+
+		chains = append(chains, chain)
+
+	}
+
+	return chains
+
+}
+
+// coalescedReplacement - Latest replacement seen for slot, while it was
+// being held back, waiting for interval to end
+type coalescedReplacement struct {
+	latest *MemPoolTx
+}
+
+// replacementCoalescer - Coalesces rapid replacements of tx with same
+// (sender, nonce), so that at max one replacement event is emitted per
+// slot per interval, during aggressive gas wars
+//
+// First replacement of slot is emitted right away, ones following within
+// interval are held back & only latest of them i.e. carrying latest gas
+// price, is emitted when interval ends
+//
+// @note Zero value is ready to use
+type replacementCoalescer struct {
+	lock    sync.Mutex
+	entries map[replacementSlot]*coalescedReplacement
+}
+
+// offer - Lets coalescer know of new replacement tx, which is either emitted
+// right away or held back till end of current interval of its slot
+//
+// @note Zero `interval` disables coalescing
+func (r *replacementCoalescer) offer(tx *MemPoolTx, interval time.Duration, emit func(*MemPoolTx)) {
+
+	if interval == 0 {
+		emit(tx)
+		return
+	}
+
+	slot := replacementSlot{from: tx.From, nonce: tx.Nonce}
+
+	r.lock.Lock()
+
+	if r.entries == nil {
+		r.entries = make(map[replacementSlot]*coalescedReplacement)
+	}
+
+	if entry, ok := r.entries[slot]; ok {
+
+		// Within interval, only latest one is kept
+		entry.latest = tx
+		r.lock.Unlock()
+		return
+
+	}
+
+	r.entries[slot] = &coalescedReplacement{}
+	time.AfterFunc(interval, func() { r.flush(slot, interval, emit) })
+
+	r.lock.Unlock()
+
+	emit(tx)
+
+}
+
+// flush - Invoked when interval of slot ends, emitting latest held back
+// replacement, if any, which starts next interval. Otherwise slot is forgotten
+func (r *replacementCoalescer) flush(slot replacementSlot, interval time.Duration, emit func(*MemPoolTx)) {
+
+	r.lock.Lock()
+
+	entry, ok := r.entries[slot]
+	if !ok {
+		r.lock.Unlock()
+		return
+	}
+
+	if entry.latest == nil {
+		delete(r.entries, slot)
+		r.lock.Unlock()
+		return
+	}
+
+	tx := entry.latest
+	entry.latest = nil
+	time.AfterFunc(interval, func() { r.flush(slot, interval, emit) })
+
+	r.lock.Unlock()
+
+	emit(tx)
+
+}
@@ -0,0 +1,143 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestReplacementCoalescer(t *testing.T) {
+
+	const interval = 50 * time.Millisecond
+
+	var coalescer replacementCoalescer
+
+	emitted := make(chan *MemPoolTx, 16)
+	emit := func(tx *MemPoolTx) { emitted <- tx }
+
+	expect := func(hash common.Hash) {
+
+		t.Helper()
+
+		select {
+		case tx := <-emitted:
+			if tx.Hash != hash {
+				t.Fatalf("expected %s to be emitted, got %s", hash.Hex(), tx.Hash.Hex())
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s not emitted", hash.Hex())
+		}
+
+	}
+
+	expectNothing := func() {
+
+		t.Helper()
+
+		select {
+		case tx := <-emitted:
+			t.Fatalf("expected nothing to be emitted, got %s", tx.Hash.Hex())
+		default:
+		}
+
+	}
+
+	// Gas war on same slot, first one goes out right away
+	for i := 0; i < 5; i++ {
+		coalescer.offer(legacyTx(hashOf(i), addrOf(0), 0, int64(10+i)), interval, emit)
+	}
+
+	expect(hashOf(0))
+
+	// Other slots aren't held back
+	coalescer.offer(legacyTx(hashOf(10), addrOf(0), 1, 10), interval, emit)
+	coalescer.offer(legacyTx(hashOf(11), addrOf(1), 0, 10), interval, emit)
+
+	expect(hashOf(10))
+	expect(hashOf(11))
+	expectNothing()
+
+	// Only latest one of held back ones, when interval ends
+	expect(hashOf(4))
+
+	// Once slot stays quiet for whole interval, it's forgotten
+	time.Sleep(3 * interval)
+	expectNothing()
+
+	coalescer.offer(legacyTx(hashOf(5), addrOf(0), 0, 20), interval, emit)
+	expect(hashOf(5))
+
+	// Disabled, each one is emitted
+	var disabled replacementCoalescer
+
+	for i := 20; i < 23; i++ {
+		disabled.offer(legacyTx(hashOf(i), addrOf(2), 0, int64(i)), 0, emit)
+		expect(hashOf(i))
+	}
+
+}
+
+func TestReplacementChains(t *testing.T) {
+
+	at := time.Now().UTC()
+
+	first := legacyTx(hashOf(0), addrOf(0), 0, 10)
+	first.PendingFrom = at
+
+	second := legacyTx(hashOf(1), addrOf(0), 0, 11)
+	second.PendingFrom = at.Add(time.Second)
+
+	// Seen at same time as second one, ordered after it by hash
+	third := legacyTx(hashOf(2), addrOf(0), 0, 12)
+	third.PendingFrom = at.Add(time.Second)
+
+	// Never replaced
+	alone := legacyTx(hashOf(3), addrOf(0), 1, 10)
+	other := legacyTx(hashOf(4), addrOf(1), 0, 10)
+
+	chains := replacementChains([]*MemPoolTx{third, alone, first, other, second})
+	if len(chains) != 1 {
+		t.Fatalf("expected 1 replacement chain, got %d", len(chains))
+	}
+
+	chain := chains[0]
+	if len(chain) != 3 || chain[0] != first || chain[1] != second || chain[2] != third {
+		t.Fatalf("unexpected replacement chain %v", chain)
+	}
+
+}
+
+func TestInvalidReplacements(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	for _, tx := range []*MemPoolTx{
+		legacyTx(hashOf(0), addrOf(0), 0, 100),
+		// Bumped by less than 10%
+		legacyTx(hashOf(1), addrOf(0), 0, 105),
+		legacyTx(hashOf(2), addrOf(0), 0, 120),
+	} {
+
+		if !pools.pending.Add(ctx, tx) {
+			t.Fatalf("failed to add tx %s", tx.Hash.Hex())
+		}
+
+		// Entry time orders replacements
+		time.Sleep(time.Millisecond)
+
+	}
+
+	invalid := pools.pending.InvalidReplacements(ctx)
+	if len(invalid) != 1 || invalid[0].Hash != hashOf(1) {
+		t.Fatalf("expected only underpriced replacement, got %v", invalid)
+	}
+
+	bumps := pools.pending.FeeBumps(ctx)
+	if len(bumps) != 2 || bumps[0].New.Hash != hashOf(1) || bumps[1].New.Hash != hashOf(2) {
+		t.Fatalf("unexpected fee bumps %v", bumps)
+	}
+
+}
@@ -1,11 +1,15 @@
 package data
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"runtime"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -90,6 +94,51 @@ func IsPresentInCurrentPool(txs map[string]map[string]*MemPoolTx, txHash common.
 
 }
 
+// DiffSnapshots - Given two `txpool_content` sections, seen in consecutive
+// polls, finds out which tx(s) entered & which left in between
+//
+// @note Both lists are sorted by hash, so that delta is deterministic
+func DiffSnapshots(prev map[string]map[string]*MemPoolTx, next map[string]map[string]*MemPoolTx) ([]common.Hash, []common.Hash) {
+
+	hashesOf := func(txs map[string]map[string]*MemPoolTx) map[common.Hash]struct{} {
+
+		hashes := make(map[common.Hash]struct{})
+
+		for _, v := range txs {
+			for _, tx := range v {
+				hashes[tx.Hash] = struct{}{}
+			}
+		}
+
+		return hashes
+
+	}
+
+	// Hashes present in `a`, but not in `b`
+	missingFrom := func(a map[common.Hash]struct{}, b map[common.Hash]struct{}) []common.Hash {
+
+		result := make([]common.Hash, 0)
+
+		for hash := range a {
+			if _, ok := b[hash]; !ok {
+				result = append(result, hash)
+			}
+		}
+
+		sort.Slice(result, func(i, j int) bool {
+			return bytes.Compare(result[i].Bytes(), result[j].Bytes()) < 0
+		})
+
+		return result
+
+	}
+
+	before, after := hashesOf(prev), hashesOf(next)
+
+	return missingFrom(after, before), missingFrom(before, after)
+
+}
+
 // Removes prepended `0{x, X}` from hex string
 func remove0x(num string) string {
 	return strings.Replace(strings.Replace(num, "0x", "", -1), "0X", "", -1)
@@ -109,10 +158,7 @@ func HexToDecimal(num hexutil.Uint64) string {
 // decimal big integer
 func BigHexToBigDecimal(num *hexutil.Big) *big.Int {
 
-	_num := big.NewInt(0)
-	_num.SetString(remove0x(num.String()), 16)
-
-	return _num
+	return big.NewInt(0).Set(num.ToInt())
 
 }
 
@@ -175,3 +221,175 @@ func NumericGasPriceGwei(num *hexutil.Big) float64 {
 	gp, _ := _res.Float64()
 	return gp
 }
+
+// WeiToGwei - Converts amount in Wei to Gwei, rounded to `precision` digits
+// after decimal point, for consumers not dealing with big integers
+//
+// @note Nil amount is considered to be zero, negative precision keeps
+// all digits representable in double precision
+func WeiToGwei(num *big.Int, precision int) float64 {
+
+	if num == nil {
+		return 0.0
+	}
+
+	_res := big.NewFloat(0).SetInt(num)
+	_res.Quo(_res, big.NewFloat(1_000_000_000))
+
+	gwei, _ := _res.Float64()
+	if precision < 0 {
+		return gwei
+	}
+
+	scale := math.Pow10(precision)
+	return math.Round(gwei*scale) / scale
+
+}
+
+// GweiToWei - Converts amount in Gwei to Wei, keeping nil as is, so that
+// it can still denote unbounded side of range
+func GweiToWei(num *big.Int) *big.Int {
+
+	if num == nil {
+		return nil
+	}
+
+	return new(big.Int).Mul(num, big.NewInt(1_000_000_000))
+
+}
+
+// dominanceOf - Computes largest single sender share of pool, given tx(s)
+// living in pool, grouped by sender
+func dominanceOf(txsFromAddress map[common.Address]TxList) SenderDominance {
+
+	var (
+		totalTxs, totalGas uint64
+		maxTxs, maxGas     uint64
+	)
+
+	for _, txs := range txsFromAddress {
+
+		if txs == nil {
+			continue
+		}
+
+		var gas uint64
+		for _, tx := range txs.get() {
+			gas += uint64(tx.Gas)
+		}
+
+		count := uint64(txs.len())
+
+		totalTxs += count
+		totalGas += gas
+
+		if count > maxTxs {
+			maxTxs = count
+		}
+
+		if gas > maxGas {
+			maxGas = gas
+		}
+
+	}
+
+	var dominance SenderDominance
+
+	if totalTxs != 0 {
+		dominance.TxShare = float64(maxTxs) / float64(totalTxs)
+	}
+
+	if totalGas != 0 {
+		dominance.GasShare = float64(maxGas) / float64(totalGas)
+	}
+
+	return dominance
+
+}
+
+// selectKth - Partially reorders durations in-place, so that k-th smallest one
+// ( 0-indexed ) is placed at index `k`, with all smaller/ equal ones before it,
+// in expected linear time i.e. quickselect
+func selectKth(v []time.Duration, k int) time.Duration {
+
+	lo, hi := 0, len(v)-1
+
+	for lo < hi {
+
+		// Median of three pivot, to avoid degrading on
+		// already sorted input
+		mid := lo + (hi-lo)/2
+		if v[mid] < v[lo] {
+			v[mid], v[lo] = v[lo], v[mid]
+		}
+		if v[hi] < v[lo] {
+			v[hi], v[lo] = v[lo], v[hi]
+		}
+		if v[hi] < v[mid] {
+			v[hi], v[mid] = v[mid], v[hi]
+		}
+
+		pivot := v[mid]
+		i, j := lo, hi
+
+		for i <= j {
+
+			for v[i] < pivot {
+				i++
+			}
+
+			for v[j] > pivot {
+				j--
+			}
+
+			if i <= j {
+				v[i], v[j] = v[j], v[i]
+				i++
+				j--
+			}
+
+		}
+
+		switch {
+		case k <= j:
+			hi = j
+		case k >= i:
+			lo = i
+		default:
+			return v[k]
+		}
+
+	}
+
+	return v[k]
+
+}
+
+// MedianDuration - Median of given durations, found using selection rather
+// than sorting, where for even count mean of two middle ones is considered
+//
+// @note Given slice gets reordered & zero is returned, if it's empty
+func MedianDuration(v []time.Duration) time.Duration {
+
+	n := len(v)
+	if n == 0 {
+		return 0
+	}
+
+	upper := selectKth(v, n/2)
+	if n%2 == 1 {
+		return upper
+	}
+
+	// After selection, all before n/2 are smaller/ equal, so
+	// largest among them is lower middle one
+	lower := v[0]
+	for _, d := range v[1 : n/2] {
+		if d > lower {
+			lower = d
+		}
+	}
+
+	return (lower + upper) / 2
+
+}
@@ -0,0 +1,160 @@
+package data
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestSenderDominance(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	// First sender holds most tx(s), second one holds most gas
+	txs := make([]*MemPoolTx, 0, 8)
+
+	for i := 0; i < 6; i++ {
+		txs = append(txs, legacyTx(hashOf(i), addrOf(0), uint64(i), 1_000_000_000))
+	}
+
+	heavy := legacyTx(hashOf(6), addrOf(1), 0, 1_000_000_000)
+	heavy.Gas = 210000
+
+	txs = append(txs, heavy, legacyTx(hashOf(7), addrOf(2), 0, 1_000_000_000))
+
+	if v := pools.pending.SenderDominance(ctx); v != (SenderDominance{}) {
+		t.Fatalf("expected zero dominance of empty pool, got %v", v)
+	}
+
+	for _, tx := range txs {
+
+		if !pools.pending.Add(ctx, tx) {
+			t.Fatalf("failed to add tx %s", tx.Hash.Hex())
+		}
+
+	}
+
+	expected := SenderDominance{TxShare: 6. / 8, GasShare: 210000. / (6*21000 + 210000 + 21000)}
+
+	if v := pools.pending.SenderDominance(ctx); v != expected {
+		t.Fatalf("expected %v, got %v", expected, v)
+	}
+
+	if v := dominanceOf(map[common.Address]TxList{addrOf(0): nil}); v != (SenderDominance{}) {
+		t.Fatalf("expected zero dominance without any tx, got %v", v)
+	}
+
+}
+
+func TestGweiConversions(t *testing.T) {
+
+	huge, _ := big.NewInt(0).SetString("1000000000000000000000000000000", 10)
+
+	for _, c := range []struct {
+		wei       *big.Int
+		precision int
+		expected  float64
+	}{
+		{nil, 2, 0},
+		{big.NewInt(1), 9, 1e-9},
+		{big.NewInt(1), 2, 0},
+		{big.NewInt(1_234_567_890), 2, 1.23},
+		{big.NewInt(1_235_567_890), 0, 1},
+		{big.NewInt(1_234_567_890), 3, 1.235},
+		{big.NewInt(1_234_567_890), -1, 1.23456789},
+		{huge, -1, 1e21},
+	} {
+
+		if gwei := WeiToGwei(c.wei, c.precision); gwei != c.expected {
+			t.Fatalf("%v Wei, precision %d : expected %v Gwei, got %v", c.wei, c.precision, c.expected, gwei)
+		}
+
+	}
+
+	stats := PoolStats{MinGasPrice: big.NewInt(1_500_000_000), MaxGasPrice: big.NewInt(99_999_999_999), MedianGasPrice: nil}
+
+	expected := PoolStatsGwei{MinGasPrice: 1.5, MaxGasPrice: 100, MedianGasPrice: 0}
+	if gwei := stats.InGwei(1); gwei != expected {
+		t.Fatalf("expected %v, got %v", expected, gwei)
+	}
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	if gwei := pools.pending.GasPricePercentileGwei(ctx, 50, 2); gwei != 0 {
+		t.Fatalf("expected zero for empty pool, got %v", gwei)
+	}
+
+	for i, price := range []int64{1_111_111_111, 2_222_222_222, 3_333_333_333} {
+
+		if !pools.pending.Add(ctx, legacyTx(hashOf(i), addrOf(i), 0, price)) {
+			t.Fatalf("failed to add tx %d", i)
+		}
+
+	}
+
+	if gwei := pools.pending.GasPricePercentileGwei(ctx, 50, 2); gwei != 2.22 {
+		t.Fatalf("expected median of 2.22 Gwei, got %v", gwei)
+	}
+
+	if gwei := pools.pending.GasPricePercentileGwei(ctx, 100, -1); gwei != 3.333333333 {
+		t.Fatalf("expected max of 3.333333333 Gwei, got %v", gwei)
+	}
+
+}
+
+func TestDiffSnapshots(t *testing.T) {
+
+	a, b := addrOf(0).Hex(), addrOf(1).Hex()
+
+	prev := map[string]map[string]*MemPoolTx{
+		a: {
+			"0": legacyTx(hashOf(0), addrOf(0), 0, 1),
+			"1": legacyTx(hashOf(1), addrOf(0), 1, 1),
+		},
+		b: {
+			"4": legacyTx(hashOf(2), addrOf(1), 4, 1),
+		},
+	}
+
+	next := map[string]map[string]*MemPoolTx{
+		a: {
+			// Nonce 0 got mined, nonce 1 replaced
+			"1": legacyTx(hashOf(4), addrOf(0), 1, 2),
+			"2": legacyTx(hashOf(3), addrOf(0), 2, 1),
+		},
+		b: {
+			"4": legacyTx(hashOf(2), addrOf(1), 4, 1),
+		},
+		addrOf(2).Hex(): {
+			"0": legacyTx(hashOf(5), addrOf(2), 0, 1),
+		},
+	}
+
+	for name, c := range map[string]struct {
+		prev, next     map[string]map[string]*MemPoolTx
+		added, removed []common.Hash
+	}{
+		"delta":     {prev, next, []common.Hash{hashOf(3), hashOf(4), hashOf(5)}, []common.Hash{hashOf(0), hashOf(1)}},
+		"unchanged": {prev, prev, []common.Hash{}, []common.Hash{}},
+		"first":     {nil, prev, []common.Hash{hashOf(0), hashOf(1), hashOf(2)}, []common.Hash{}},
+		"emptied":   {prev, map[string]map[string]*MemPoolTx{a: {}}, []common.Hash{}, []common.Hash{hashOf(0), hashOf(1), hashOf(2)}},
+	} {
+
+		added, removed := DiffSnapshots(c.prev, c.next)
+
+		if !reflect.DeepEqual(added, c.added) {
+			t.Fatalf("%s : expected added %v, got %v", name, c.added, added)
+		}
+
+		if !reflect.DeepEqual(removed, c.removed) {
+			t.Fatalf("%s : expected removed %v, got %v", name, c.removed, removed)
+		}
+
+	}
+
+}
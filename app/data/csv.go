@@ -0,0 +1,41 @@
+package data
+
+import (
+	"strconv"
+	"time"
+)
+
+// CSVHeader - Columns of tx(s) exported as CSV, in order
+var CSVHeader = []string{"hash", "from", "to", "nonce", "gasPrice", "gasLimit", "value", "pendingSince"}
+
+// ToCSVRecord - Flattens tx into CSV record, columns being in same order
+// as `CSVHeader`
+//
+// Big integers are written as decimal strings, while `pendingSince` is
+// RFC3339 formatted time, since when tx is living in its current pool
+//
+// @note Contract creation tx(s) have empty `to`
+func (m *MemPoolTx) ToCSVRecord() []string {
+
+	var to string
+	if m.To != nil {
+		to = m.To.Hex()
+	}
+
+	since := m.PendingFrom
+	if m.Pool == "queued" {
+		since = m.QueuedAt
+	}
+
+	return []string{
+		m.Hash.Hex(),
+		m.From.Hex(),
+		to,
+		strconv.FormatUint(uint64(m.Nonce), 10),
+		bigOrZero(m.GasPrice).String(),
+		strconv.FormatUint(uint64(m.Gas), 10),
+		bigOrZero(m.Value).String(),
+		since.Format(time.RFC3339),
+	}
+
+}
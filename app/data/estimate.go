@@ -0,0 +1,74 @@
+package data
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/gammazero/workerpool"
+)
+
+// GasEstimator - Annotates tx(s) entering pending pool with gas usage
+// estimated by node, so that it can be compared with declared gas limit
+//
+// Estimations are done by bounded worker pool & results are sent back to
+// pending pool, which owns tx state, so that slow node never blocks pool.
+// At max `QueueSize` of them can be waiting/ in progress, rest are dropped.
+//
+// @note Estimation finishes after tx's entry is published, so `EstimatedGas`
+// is only carried by later events of tx i.e. its exit
+//
+// @note Disabled by default, because it costs one RPC call per tx
+type GasEstimator struct {
+	Enabled    bool
+	QueueSize  uint64
+	Upstreams  *Upstreams
+	Workers    *workerpool.WorkerPool
+	ResultChan chan<- EstimatedGas
+	queued     uint64
+}
+
+// Fire - Submits gas estimation of tx, to be invoked from pending
+// pool's add path
+//
+// @note This is non-blocking call, estimation happens in worker pool
+func (g *GasEstimator) Fire(ctx context.Context, tx *MemPoolTx) {
+
+	if !g.Enabled {
+		return
+	}
+
+	// Copying what's needed, tx itself is owned by pool
+	call := &MemPoolTx{Hash: tx.Hash, From: tx.From, To: tx.To, Value: tx.Value, Input: tx.Input}
+
+	// Node isn't keeping up, rather than piling up estimations
+	// without bound during spam, newer ones are dropped
+	if atomic.AddUint64(&g.queued, 1) > g.QueueSize {
+
+		atomic.AddUint64(&g.queued, ^uint64(0))
+		limitedLog.Printf("[❗️] Dropped gas estimation for tx %s : queue full\n", call.Hash.Hex())
+
+		return
+
+	}
+
+	g.Workers.Submit(func() {
+
+		defer atomic.AddUint64(&g.queued, ^uint64(0))
+
+		client, _ := g.Upstreams.Active()
+
+		gas, err := call.EstimateGas(ctx, client)
+		if err != nil {
+			// Tx stays in pool, only without estimation
+			limitedLog.Printf("[❗️] Failed to estimate gas for tx %s : %s\n", call.Hash.Hex(), err.Error())
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+		case g.ResultChan <- EstimatedGas{Hash: call.Hash, Gas: gas}:
+		}
+
+	})
+
+}
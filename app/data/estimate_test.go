@@ -0,0 +1,74 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gammazero/workerpool"
+)
+
+func TestGasEstimatorQueueBound(t *testing.T) {
+
+	release := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+
+		body, _ := io.ReadAll(r.Body)
+
+		// Node is stuck estimating
+		if strings.Contains(string(body), "eth_estimateGas") {
+			<-release
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(rw, `{"jsonrpc":"2.0","id":1,"result":"0x5208"}`)
+
+	}))
+	t.Cleanup(srv.Close)
+
+	upstreams, err := NewUpstreams(context.Background(), []string{srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(upstreams.Close)
+
+	results := make(chan EstimatedGas, 16)
+
+	g := &GasEstimator{
+		Enabled:    true,
+		QueueSize:  2,
+		Upstreams:  upstreams,
+		Workers:    workerpool.New(1),
+		ResultChan: results,
+	}
+
+	for i := 0; i < 10; i++ {
+		g.Fire(context.Background(), legacyTx(hashOf(i), addrOf(0), uint64(i), 1))
+	}
+
+	if n := atomic.LoadUint64(&g.queued); n != 2 {
+		t.Fatalf("expected 2 queued estimations, found %d", n)
+	}
+
+	close(release)
+	g.Workers.StopWait()
+
+	if n := len(results); n != 2 {
+		t.Fatalf("expected 2 estimations, found %d", n)
+	}
+
+	if v := <-results; v.Gas != 21000 {
+		t.Fatalf("expected 21000 gas, got %d", v.Gas)
+	}
+
+	if n := atomic.LoadUint64(&g.queued); n != 0 {
+		t.Fatalf("expected queue to be drained, found %d", n)
+	}
+
+}
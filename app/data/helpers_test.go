@@ -0,0 +1,353 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/itzmeanjan/pub0sub/hub"
+	"github.com/itzmeanjan/pub0sub/ops"
+	"github.com/itzmeanjan/pub0sub/publisher"
+	"github.com/itzmeanjan/pub0sub/subscriber"
+	"github.com/spf13/viper"
+)
+
+// makeChans - Allocates every nil channel field of pool, with buffer of 1,
+// same as bootup does, so that tests don't need to keep up with each new one
+func makeChans(pool interface{}) {
+
+	v := reflect.ValueOf(pool).Elem()
+
+	for i := 0; i < v.NumField(); i++ {
+
+		f := v.Field(i)
+		if f.Kind() != reflect.Chan || !f.IsNil() || !f.CanSet() {
+			continue
+		}
+
+		// Directional ones are backed by bidirectional channel
+		ch := reflect.MakeChan(reflect.ChanOf(reflect.BothDir, f.Type().Elem()), 1)
+		f.Set(ch.Convert(f.Type()))
+
+	}
+
+}
+
+// newTestEndpoint - RPC endpoint answering every call with given JSON
+// encoded result, each call of batch gets same answer
+func newTestEndpoint(t testing.TB, result string) string {
+
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+
+		rw.Header().Set("Content-Type", "application/json")
+
+		var batch []struct {
+			ID json.RawMessage `json:"id"`
+		}
+
+		// Not a batch, answered as single call
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			fmt.Fprintf(rw, `{"jsonrpc":"2.0","id":1,"result":%s}`, result)
+			return
+		}
+
+		resps := make([]string, 0, len(batch))
+		for _, req := range batch {
+			resps = append(resps, fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"result":%s}`, req.ID, result))
+		}
+
+		fmt.Fprintf(rw, "[%s]", strings.Join(resps, ","))
+
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv.URL
+
+}
+
+// testPools - Pending & queued pool, whose go routines are running until
+// `stop` is invoked, after which their state can be inspected directly
+type testPools struct {
+	pending *PendingPool
+	queued  *QueuedPool
+	stop    func()
+}
+
+// startPools - Creates & starts both pools, with nothing to talk to i.e.
+// no pubsub, RPC node reporting zero for everything asked
+func startPools(t testing.TB) *testPools {
+
+	t.Helper()
+
+	return startPoolsWith(t, `"0x0"`)
+
+}
+
+// startPoolsWith - Same as `startPools`, but RPC node answers every call
+// with given JSON encoded result
+func startPoolsWith(t testing.TB, rpcResult string) *testPools {
+
+	t.Helper()
+
+	return startPoolsPublishing(t, rpcResult, nil)
+
+}
+
+// startPoolsPublishing - Same as `startPoolsWith`, but both pools publish
+// using given publisher, once respective choices are enabled back
+func startPoolsPublishing(t testing.TB, rpcResult string, pub *publisher.Publisher) *testPools {
+
+	t.Helper()
+
+	upstreams, err := NewUpstreams(context.Background(), []string{newTestEndpoint(t, rpcResult)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(upstreams.Close)
+
+	// Nothing to publish to
+	for _, key := range []string{
+		"PendingTxEntryEnabled", "PendingTxExitEnabled", "PendingTxDroppedEnabled",
+		"PendingTxEvictedEnabled", "PendingTxReplacementEnabled",
+		"QueuedTxEntryEnabled", "QueuedTxExitEnabled", "QueuedTxEvictedEnabled",
+	} {
+		setConfig(t, key, false)
+	}
+
+	watchdog := &Watchdog{}
+	makeChans(watchdog)
+
+	// Pending pool lets queued side know about each added tx, which
+	// none of its consumers are running to hear, so they're discarded
+	alreadyInPending := make(chan *MemPoolTx, 1)
+	inPending := make(chan *MemPoolTx, 1)
+
+	pending := &PendingPool{
+		Transactions:             make(map[common.Hash]*MemPoolTx),
+		TxsFromAddress:           make(map[common.Address]TxList),
+		DroppedTxs:               make(map[common.Hash]time.Time),
+		RemovedTxs:               make(map[common.Hash]time.Time),
+		TxsByGasPrice:            NewSortedTxs(),
+		LastSeenAt:               time.Now().UTC(),
+		InFlight:                 big.NewInt(0),
+		AlreadyInPendingPoolChan: alreadyInPending,
+		InPendingPoolChan:        inPending,
+		Upstreams:                upstreams,
+		PubSub:                   pub,
+		Watchdog:                 watchdog,
+		Webhook:                  &Webhook{},
+		Estimator:                &GasEstimator{},
+	}
+	makeChans(pending)
+
+	queued := &QueuedPool{
+		Transactions:   make(map[common.Hash]*MemPoolTx),
+		TxsFromAddress: make(map[common.Address]TxList),
+		DroppedTxs:     make(map[common.Hash]time.Time),
+		RemovedTxs:     make(map[common.Hash]time.Time),
+		TxsByGasPrice:  NewSortedTxs(),
+		Upstreams:      upstreams,
+		PubSub:         pub,
+		PendingPool:    pending,
+		Watchdog:       watchdog,
+	}
+	makeChans(queued)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	pendingDone, queuedDone := make(chan struct{}), make(chan struct{})
+
+	go func() {
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-alreadyInPending:
+			case <-inPending:
+			}
+		}
+
+	}()
+
+	go func() {
+		defer close(pendingDone)
+		pending.Start(ctx)
+	}()
+
+	go func() {
+		defer close(queuedDone)
+		queued.Start(ctx)
+	}()
+
+	var stopped bool
+
+	stop := func() {
+
+		if stopped {
+			return
+		}
+
+		stopped = true
+
+		cancel()
+		<-pendingDone
+		<-queuedDone
+
+	}
+
+	t.Cleanup(stop)
+
+	// Both have claimed their state, once they've served a request
+	pending.Count(ctx)
+	queued.Count(ctx)
+
+	return &testPools{pending: pending, queued: queued, stop: stop}
+
+}
+
+// setConfig - Overrides config value for duration of test
+func setConfig(t testing.TB, key string, value interface{}) {
+
+	t.Helper()
+
+	prev, ok := viper.Get(key), viper.IsSet(key)
+	viper.Set(key, value)
+
+	t.Cleanup(func() {
+
+		if ok {
+			viper.Set(key, prev)
+			return
+		}
+
+		viper.Set(key, nil)
+
+	})
+
+}
+
+// testHub - Pubsub hub, along with one publisher & one subscriber, listening
+// on every topic of interest
+type testHub struct {
+	pub *publisher.Publisher
+	sub *subscriber.Subscriber
+}
+
+// hubMarker - Topic, published on last by `received`, so that it knows
+// everything published before it has been delivered
+const hubMarker = "marker"
+
+// startHub - Starts pubsub hub on random local port, subscribing to given
+// topics, which lives till end of test
+func startHub(t testing.TB, topics ...string) *testHub {
+
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	h, err := hub.New(ctx, "127.0.0.1:0", 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sub, err := subscriber.New(ctx, "tcp", h.Addr(), 64, append(topics, hubMarker)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pub, err := publisher.New(ctx, "tcp", h.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testHub{pub: pub, sub: sub}
+
+}
+
+// received - Everything published since last invocation, keyed by topic,
+// relying on hub to deliver in order messages published over same connection
+func (h *testHub) received(t testing.TB) map[string][][]byte {
+
+	t.Helper()
+
+	if _, err := h.pub.Publish(&ops.Msg{Topics: []string{hubMarker}}); err != nil {
+		t.Fatal(err)
+	}
+
+	result := make(map[string][][]byte)
+	timeout := time.After(time.Second)
+
+	for {
+
+		for msg := h.sub.Next(); msg != nil; msg = h.sub.Next() {
+
+			if msg.Topic == hubMarker {
+				return result
+			}
+
+			result[msg.Topic] = append(result[msg.Topic], msg.Data)
+
+		}
+
+		select {
+		case <-h.sub.Watch():
+		case <-time.After(10 * time.Millisecond):
+		case <-timeout:
+			t.Fatal("published messages not delivered")
+		}
+
+	}
+
+}
+
+// addrOf - Deterministic address, for `i`-th sender
+func addrOf(i int) common.Address {
+
+	return common.BigToAddress(big.NewInt(int64(i + 1)))
+
+}
+
+// hashOf - Deterministic hash, for `i`-th tx
+func hashOf(i int) common.Hash {
+
+	return common.BigToHash(big.NewInt(int64(i + 1)))
+
+}
+
+// legacyTx - Legacy tx paying given gas price ( in Wei )
+func legacyTx(hash common.Hash, from common.Address, nonce uint64, gasPrice int64) *MemPoolTx {
+
+	return &MemPoolTx{
+		Hash:     hash,
+		From:     from,
+		Nonce:    hexutil.Uint64(nonce),
+		Gas:      hexutil.Uint64(21000),
+		GasPrice: (*hexutil.Big)(big.NewInt(gasPrice)),
+		Value:    (*hexutil.Big)(big.NewInt(0)),
+	}
+
+}
+
+// dynamicTx - EIP-1559 tx with given fee cap & priority fee ( in Wei )
+func dynamicTx(hash common.Hash, from common.Address, nonce uint64, feeCap int64, tip int64) *MemPoolTx {
+
+	tx := legacyTx(hash, from, nonce, feeCap)
+	tx.MaxFeePerGas = (*hexutil.Big)(big.NewInt(feeCap))
+	tx.MaxPriorityFeePerGas = (*hexutil.Big)(big.NewInt(tip))
+
+	return tx
+
+}
@@ -1,6 +1,7 @@
 package data
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -12,10 +13,15 @@ const (
 	DESC
 )
 
+// PruneStatus - Immediate response to async request for pruning pending/
+// queued pool, kept as its own type, so that it's never mistaken for count
+// of tx(s) pruned
+type PruneStatus int
+
 // When submitting async request for pruning pending/ queued
 // pool, immediate response to be sent to client in any of these form(s)
 const (
-	EMPTY = iota
+	EMPTY PruneStatus = iota
 	PRUNING
 	SCHEDULED
 )
@@ -61,20 +67,35 @@ type ExistsRequest struct {
 	ResponseChan chan bool
 }
 
+// ExistsManyRequest - Checking whether each of given txs is present in pool
+// or not, in single go
+type ExistsManyRequest struct {
+	Txs          []common.Hash
+	ResponseChan chan map[common.Hash]bool
+}
+
 // GetRequest - Obtaining reference to existing tx in pool
 type GetRequest struct {
 	Tx           common.Hash
 	ResponseChan chan *MemPoolTx
 }
 
-// CountRequest - Getting #-of txs present in pool
+// CountRequest - Getting #-of txs present in pool, satisfying `Filter`
+//
+// @note Nil/ empty filter counts all of them
 type CountRequest struct {
+	Filter       *TxFilter
 	ResponseChan chan uint64
 }
 
 // ListRequest - Listing all txs in pool
+//
+// If `Limit` is non-zero, only window of txs starting at `Offset`
+// & spanning at max `Limit` entries to be copied & returned
 type ListRequest struct {
 	Order        int
+	Offset       uint64
+	Limit        uint64
 	ResponseChan chan []*MemPoolTx
 }
 
@@ -85,6 +106,104 @@ type TxsFromARequest struct {
 	ResponseChan chan []*MemPoolTx
 }
 
+// GasPriceRangeRequest - When requesting for txs living in pool, paying
+// gas price within given range ( inclusive ), use this construct
+//
+// @note nil `Min`/ `Max` denotes range is unbounded on that side
+type GasPriceRangeRequest struct {
+	Min          *big.Int
+	Max          *big.Int
+	ResponseChan chan []*MemPoolTx
+}
+
+// PercentileRequest - When requesting for `P`-th percentile of effective
+// gas price paid by tx(s) living in pool, use this construct
+type PercentileRequest struct {
+	P            float64
+	ResponseChan chan *big.Int
+}
+
+// PercentilesRequest - When requesting for multiple percentiles of effective
+// gas price paid by tx(s) living in pool, in single go, use this construct
+type PercentilesRequest struct {
+	Ps           []float64
+	ResponseChan chan []*big.Int
+}
+
+// DisplacedByRequest - When checking which tx would be dropped from full pool,
+// if candidate tx paying `GasPrice` was added, use this construct
+type DisplacedByRequest struct {
+	GasPrice     *big.Int
+	ResponseChan chan *MemPoolTx
+}
+
+// SimulateCandidateRequest - When checking where hypothetical tx, paying
+// `GasPrice` & consuming `Gas`, would be ranked in pool & whether it'd make
+// it to next block of `BlockGasLimit`, use this construct
+type SimulateCandidateRequest struct {
+	GasPrice      *big.Int
+	Gas           uint64
+	BlockGasLimit uint64
+	ResponseChan  chan CandidateSimulation
+}
+
+// CandidateSimulation - Where hypothetical tx would be ranked ( 1-based ) in
+// pool & whether it'd be included in next block
+type CandidateSimulation struct {
+	Rank     int
+	Included bool
+}
+
+// OnRemoveRequest - When registering callback to be invoked, for every tx
+// leaving pending/ queued pool, along with reason i.e. `confirmed`/ `dropped`
+// for pending pool & `unstuck`/ `dropped` for queued pool
+type OnRemoveRequest struct {
+	Callback     func(*MemPoolTx, string)
+	ResponseChan chan bool
+}
+
+// OnAddRequest - When registering callback to be invoked, for every tx
+// joining pending/ queued pool
+type OnAddRequest struct {
+	Callback     func(*MemPoolTx)
+	ResponseChan chan bool
+}
+
+// RestoreRequest - When restoring tx(s) read from snapshot, into pool, keeping
+// their timing metadata as is, use this construct
+type RestoreRequest struct {
+	Txs          []*MemPoolTx
+	ResponseChan chan int
+}
+
+// InclusionLatencyRequest - When asking for average time taken by tx(s)
+// confirmed within last `Window`, from first seen to mined, use this construct
+type InclusionLatencyRequest struct {
+	Window       time.Duration
+	ResponseChan chan time.Duration
+}
+
+// HeavilyReplacedRequest - When looking for tx(s) whose (sender, nonce) slot
+// has been replaced at least `Min` times, use this construct
+type HeavilyReplacedRequest struct {
+	Min          int
+	ResponseChan chan []*MemPoolTx
+}
+
+// EstimatedGas - Gas usage estimation of tx, sent back to pending pool
+// by gas estimator workers, to be recorded on tx, if it's still there
+type EstimatedGas struct {
+	Hash common.Hash
+	Gas  uint64
+}
+
+// MarkMinedRequest - When letting pending pool know these tx(s) are already
+// mined, so that they're never picked up again, use this construct
+type MarkMinedRequest struct {
+	Txs          []common.Hash
+	ResponseChan chan int
+}
+
 // NewSeenBlock - When new block is seen by header listener, concurrent-safe updation
 // is sent to pending pool worker
 type NewSeenBlock struct {
@@ -94,7 +213,11 @@ type NewSeenBlock struct {
 
 // LastSeenBlock - Which block number was last seen by header subscriber
 // along with time
+//
+// `Interval` is moving average of time between consecutive blocks, as seen
+// by header subscriber, zero until it has seen at least two of them
 type LastSeenBlock struct {
-	Number uint64
-	At     time.Time
+	Number   uint64
+	At       time.Time
+	Interval time.Duration
 }
@@ -0,0 +1,130 @@
+package data
+
+import (
+	"container/heap"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// MinTxGas - Lowest amount of gas any tx can consume, if remaining
+// block space is lower than this, nothing else fits
+const MinTxGas = 21000
+
+// senderHeads - Next includable tx of each sender, kept as max heap
+// as per effective gas price paid, under given base fee
+type senderHeads struct {
+	txs     []*MemPoolTx
+	baseFee *big.Int
+}
+
+func (s *senderHeads) Len() int {
+	return len(s.txs)
+}
+
+func (s *senderHeads) Less(i, j int) bool {
+	return compareTxs(s.txs[i], s.txs[j], s.baseFee) > 0
+}
+
+func (s *senderHeads) Swap(i, j int) {
+	s.txs[i], s.txs[j] = s.txs[j], s.txs[i]
+}
+
+func (s *senderHeads) Push(x interface{}) {
+	s.txs = append(s.txs, x.(*MemPoolTx))
+}
+
+func (s *senderHeads) Pop() interface{} {
+
+	n := len(s.txs)
+
+	tx := s.txs[n-1]
+	s.txs[n-1] = nil
+	s.txs = s.txs[:n-1]
+
+	return tx
+
+}
+
+// simulateBlock - Greedily packs tx(s) into block of given gas limit, always picking
+// highest gas price paying tx, among next includable one of each sender, while
+// respecting per sender nonce order
+//
+// Tx(s) are compared as per effective gas price, under `baseFee`, so that
+// legacy & EIP-1559 tx(s) are ordered correctly with respect to each other
+//
+// If some sender's next tx doesn't fit in remaining space, rest of tx(s) from that
+// sender are skipped, because they can't be included before it. Tx(s) paying
+// less than `baseFee` are never included
+func simulateBlock(txs []*MemPoolTx, gasLimit uint64, baseFee *big.Int) []*MemPoolTx {
+
+	fromA := make(map[common.Address][]*MemPoolTx)
+	for _, tx := range txs {
+		fromA[tx.From] = append(fromA[tx.From], tx)
+	}
+
+	heads := &senderHeads{txs: make([]*MemPoolTx, 0, len(fromA)), baseFee: baseFee}
+	for _, v := range fromA {
+
+		sort.Slice(v, func(i, j int) bool {
+			return v[i].Nonce < v[j].Nonce
+		})
+		heads.txs = append(heads.txs, v[0])
+
+	}
+
+	heap.Init(heads)
+
+	included := make([]*MemPoolTx, 0)
+	remaining := gasLimit
+
+	for heads.Len() != 0 && remaining >= MinTxGas {
+
+		tx := heap.Pop(heads).(*MemPoolTx)
+
+		// Best one is not paying enough, none else will
+		if baseFee != nil && tx.EffectiveGasPrice(baseFee).Cmp(baseFee) < 0 {
+			break
+		}
+
+		if uint64(tx.Gas) > remaining {
+			continue
+		}
+
+		included = append(included, tx)
+		remaining -= uint64(tx.Gas)
+
+		// Only consecutive nonce can be next one from
+		// this sender
+		rest := fromA[tx.From][1:]
+		fromA[tx.From] = rest
+
+		if len(rest) != 0 && rest[0].Nonce == tx.Nonce+1 {
+			heap.Push(heads, rest[0])
+		}
+
+	}
+
+	return included
+
+}
+
+// effectiveTip - Per unit gas, what block producer earns from tx,
+// after base fee is burnt
+//
+// @note If base fee is not provided, whole gas price is considered tip
+func effectiveTip(tx *MemPoolTx, baseFee *big.Int) *big.Int {
+
+	tip := tx.EffectiveGasPrice(baseFee)
+	if baseFee != nil {
+		tip.Sub(tip, baseFee)
+	}
+
+	if tip.Sign() < 0 {
+		return big.NewInt(0)
+	}
+
+	return tip
+
+}
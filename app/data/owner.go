@@ -0,0 +1,17 @@
+//go:build !harmonydebug
+// +build !harmonydebug
+
+package data
+
+// ownerGuard - Single writer invariant checker, which is no-op in regular
+// build. Build with `harmonydebug` tag for enabling it
+type ownerGuard struct{}
+
+// claim - Marks calling go routine as owner of pool state
+func (g *ownerGuard) claim() {}
+
+// check - Asserts pool state is being touched by its owner go routine
+func (g *ownerGuard) check() {}
+
+// enter - Asserts request is not being made from owner go routine
+func (g *ownerGuard) enter() {}
@@ -0,0 +1,82 @@
+//go:build harmonydebug
+// +build harmonydebug
+
+package data
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+)
+
+// ownerGuard - Single writer invariant checker, remembers which go routine
+// owns pool state & panics when it's touched from any other go routine, or
+// when owner makes request to its own pool
+//
+// @note Only enabled when built with `harmonydebug` tag, because finding out
+// go routine ID is expensive
+type ownerGuard struct {
+	id uint64
+}
+
+// goroutineID - ID of calling go routine, parsed from first line
+// of its stack trace i.e. `goroutine <id> [running]:`
+func goroutineID() uint64 {
+
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+
+	fields := bytes.Fields(buf)
+	if len(fields) < 2 {
+		return 0
+	}
+
+	id, err := strconv.ParseUint(string(fields[1]), 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return id
+
+}
+
+// claim - Marks calling go routine as owner of pool state
+func (g *ownerGuard) claim() {
+
+	atomic.StoreUint64(&g.id, goroutineID())
+
+}
+
+// check - Asserts pool state is being touched by its owner go routine,
+// panics otherwise
+func (g *ownerGuard) check() {
+
+	owner := atomic.LoadUint64(&g.id)
+	if owner == 0 {
+		return
+	}
+
+	if caller := goroutineID(); caller != owner {
+		panic(fmt.Sprintf("pool state touched from go routine %d, owned by %d", caller, owner))
+	}
+
+}
+
+// enter - Asserts request is not being made from owner go routine, panics
+// otherwise. Owner can't serve its own request, so it'd either deadlock or
+// end up touching pool state while in middle of some other request, e.g.
+// when add/ remove callback calls back into pool
+func (g *ownerGuard) enter() {
+
+	owner := atomic.LoadUint64(&g.id)
+	if owner == 0 {
+		return
+	}
+
+	if goroutineID() == owner {
+		panic(fmt.Sprintf("pool request made from its owner go routine %d", owner))
+	}
+
+}
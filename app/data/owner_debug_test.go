@@ -0,0 +1,88 @@
+//go:build harmonydebug
+// +build harmonydebug
+
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestOwnerGuardRequestFromOwner(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	recovered := make(chan interface{}, 1)
+
+	// Callback runs on pool's own go routine, calling back into
+	// pool from there must be caught, instead of deadlocking
+	pools.pending.OnAdd(ctx, func(tx *MemPoolTx) {
+
+		defer func() {
+			recovered <- recover()
+		}()
+
+		pools.pending.Get(ctx, tx.Hash)
+
+	})
+
+	if !pools.pending.Add(ctx, legacyTx(hashOf(0), addrOf(0), 0, 1_000_000_000)) {
+		t.Fatal("failed to add tx")
+	}
+
+	select {
+	case v := <-recovered:
+		if v == nil {
+			t.Fatal("request from owner go routine didn't panic")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("callback wasn't invoked")
+	}
+
+}
+
+func TestOwnerGuardStateTouchedOutsideOwner(t *testing.T) {
+
+	pools := startPools(t)
+
+	for name, touch := range map[string]func(){
+		"pending": func() { pools.pending.allocateFor(addrOf(0)) },
+		"queued":  func() { pools.queued.allocateFor(addrOf(0)) },
+	} {
+
+		func() {
+
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%s : touching state outside owner go routine didn't panic", name)
+				}
+			}()
+
+			touch()
+
+		}()
+
+	}
+
+}
+
+func TestOwnerGuardRequestsFromOthers(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	if !pools.pending.Add(ctx, legacyTx(hashOf(0), addrOf(0), 0, 1_000_000_000)) {
+		t.Fatal("failed to add tx to pending pool")
+	}
+
+	if !pools.queued.Add(ctx, legacyTx(hashOf(1), addrOf(1), 5, 1_000_000_000)) {
+		t.Fatal("failed to add tx to queued pool")
+	}
+
+	if pools.pending.Get(ctx, hashOf(0)) == nil || pools.queued.Get(ctx, hashOf(1)) == nil {
+		t.Fatal("failed to look up added tx(s)")
+	}
+
+}
@@ -0,0 +1,80 @@
+package data
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// parquetTx - Columnar representation of tx, as written to parquet file
+//
+// Big integer fields are kept as decimal strings, so that no precision is
+// lost, while timestamps are in milliseconds since epoch
+type parquetTx struct {
+	Hash        string  `parquet:"name=hash, type=BYTE_ARRAY, convertedtype=UTF8"`
+	From        string  `parquet:"name=from, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	To          *string `parquet:"name=to, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY, repetitiontype=OPTIONAL"`
+	Nonce       int64   `parquet:"name=nonce, type=INT64"`
+	Gas         int64   `parquet:"name=gas, type=INT64"`
+	GasPrice    string  `parquet:"name=gas_price, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Value       string  `parquet:"name=value, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Type        int32   `parquet:"name=type, type=INT32"`
+	Category    string  `parquet:"name=category, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	InputSize   int64   `parquet:"name=input_size, type=INT64"`
+	PendingFrom int64   `parquet:"name=pending_from, type=INT64, convertedtype=TIMESTAMP_MILLIS"`
+}
+
+// toParquet - Flattens tx into row to be written into parquet file
+func (m *MemPoolTx) toParquet() *parquetTx {
+
+	row := &parquetTx{
+		Hash:        m.Hash.Hex(),
+		From:        m.From.Hex(),
+		Nonce:       int64(m.Nonce),
+		Gas:         int64(m.Gas),
+		GasPrice:    bigOrZero(m.GasPrice).String(),
+		Value:       bigOrZero(m.Value).String(),
+		Type:        int32(m.Type),
+		Category:    m.Category(),
+		InputSize:   int64(len(m.Input)),
+		PendingFrom: m.PendingFrom.UnixNano() / int64(time.Millisecond),
+	}
+
+	// Contract creation tx(s) don't have recipient
+	if m.To != nil {
+		to := m.To.Hex()
+		row.To = &to
+	}
+
+	return row
+
+}
+
+// WriteParquet - Writes all tx(s) living in pending pool to `w`, as parquet
+// file, one row per tx, for ingestion into analytics tools
+func (p *PendingPool) WriteParquet(ctx context.Context, w io.Writer) error {
+
+	pw, err := writer.NewParquetWriterFromWriter(w, new(parquetTx), 1)
+	if err != nil {
+		return err
+	}
+
+	pw.CompressionType = parquet.CompressionCodec_SNAPPY
+
+	txs := p.AscListTxs(ctx)
+	defer CleanSlice(txs)
+
+	for _, tx := range txs {
+
+		if err := pw.Write(tx.toParquet()); err != nil {
+			return err
+		}
+
+	}
+
+	return pw.WriteStop()
+
+}
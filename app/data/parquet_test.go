@@ -0,0 +1,77 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func TestWriteParquet(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	to := addrOf(9)
+
+	transfer := legacyTx(hashOf(0), addrOf(0), 0, 2_000_000_000)
+	transfer.To = &to
+
+	// Paying more than int64 can hold
+	deploy := legacyTx(hashOf(1), addrOf(1), 7, 1)
+	deploy.GasPrice.ToInt().Lsh(deploy.GasPrice.ToInt(), 80)
+
+	for _, tx := range []*MemPoolTx{transfer, deploy} {
+
+		if !pools.pending.Add(ctx, tx) {
+			t.Fatalf("failed to add tx %s", tx.Hash.Hex())
+		}
+
+	}
+
+	var buf bytes.Buffer
+
+	if err := pools.pending.WriteParquet(ctx, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := buffer.NewBufferFile(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pr, err := reader.NewParquetReader(file, new(parquetTx), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pr.ReadStop()
+
+	if n := pr.GetNumRows(); n != 2 {
+		t.Fatalf("expected 2 rows, found %d", n)
+	}
+
+	rows := make([]parquetTx, 2)
+	if err := pr.Read(&rows); err != nil {
+		t.Fatal(err)
+	}
+
+	// Ordered by gas price, low to high
+	if rows[0].Hash != transfer.Hash.Hex() || rows[0].To == nil || *rows[0].To != to.Hex() {
+		t.Fatalf("unexpected transfer row %+v", rows[0])
+	}
+
+	if rows[0].GasPrice != "2000000000" || rows[0].Category != TRANSFER {
+		t.Fatalf("unexpected transfer row %+v", rows[0])
+	}
+
+	if rows[1].Hash != deploy.Hash.Hex() || rows[1].To != nil || rows[1].Nonce != 7 {
+		t.Fatalf("unexpected deploy row %+v", rows[1])
+	}
+
+	if rows[1].GasPrice != "1208925819614629174706176" || rows[1].Category != DEPLOY {
+		t.Fatalf("unexpected deploy row %+v", rows[1])
+	}
+
+}
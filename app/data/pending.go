@@ -1,9 +1,12 @@
 package data
 
 import (
+	"bytes"
 	"context"
 	"log"
+	"math/big"
 	"runtime"
+	"sort"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -12,6 +15,7 @@ import (
 	"github.com/gammazero/workerpool"
 	"github.com/itzmeanjan/harmony/app/config"
 	"github.com/itzmeanjan/harmony/app/listen"
+	"github.com/itzmeanjan/harmony/app/metrics"
 	"github.com/itzmeanjan/pub0sub/ops"
 	"github.com/itzmeanjan/pub0sub/publisher"
 )
@@ -23,26 +27,64 @@ type PendingPool struct {
 	TxsFromAddress           map[common.Address]TxList
 	DroppedTxs               map[common.Hash]time.Time
 	RemovedTxs               map[common.Hash]time.Time
-	AscTxsByGasPrice         TxList
-	DescTxsByGasPrice        TxList
+	TxsByGasPrice            *SortedTxs
 	Done                     uint64
 	LastSeenBlock            uint64
 	LastSeenAt               time.Time
+	BlockInterval            time.Duration
+	Skew                     time.Duration
+	InFlight                 *big.Int
 	AddTxChan                chan AddRequest
 	AddFromQueuedPoolChan    chan AddRequest
 	RemoveTxChan             chan RemoveRequest
 	AlreadyInPendingPoolChan chan *MemPoolTx
 	InPendingPoolChan        chan<- *MemPoolTx
 	TxExistsChan             chan ExistsRequest
+	TxsExistChan             chan ExistsManyRequest
 	GetTxChan                chan GetRequest
 	CountTxsChan             chan CountRequest
 	ListTxsChan              chan ListRequest
 	TxsFromAChan             chan TxsFromARequest
+	TxsByGasPriceRangeChan   chan GasPriceRangeRequest
+	GasPricePercentileChan   chan PercentileRequest
+	IncludablePercentileChan chan PercentilesRequest
+	DisplacedByChan          chan DisplacedByRequest
+	SimulateCandidateChan    chan SimulateCandidateRequest
+	OnRemoveChan             chan OnRemoveRequest
+	OnAddChan                chan OnAddRequest
+	RestoreChan              chan RestoreRequest
+	MarkMinedChan            chan MarkMinedRequest
+	OldestPerSenderChan      chan chan map[common.Address]*MemPoolTx
 	DoneChan                 chan chan uint64
 	SetLastSeenBlockChan     chan uint64
+	SetBaseFeeChan           chan *big.Int
+	SetClockSkewChan         chan time.Duration
 	LastSeenBlockChan        chan chan LastSeenBlock
+	SenderDominanceChan      chan chan SenderDominance
+	ClockSkewChan            chan chan time.Duration
+	BaseFeeChan              chan chan *big.Int
+	ValueInFlightChan        chan chan *big.Int
+	InclusionLatencyChan     chan InclusionLatencyRequest
+	GasRecommendationChan    chan chan GasRecommendation
+	HeavilyReplacedChan      chan HeavilyReplacedRequest
+	EstimatedGasChan         chan EstimatedGas
 	PubSub                   *publisher.Publisher
-	RPC                      *rpc.Client
+	Upstreams                *Upstreams
+	Watchdog                 *Watchdog
+	Webhook                  *Webhook
+	Estimator                *GasEstimator
+	GasPriceBuckets          []float64
+	owner                    ownerGuard
+	published                publishedEvents
+	replacements             replacementCoalescer
+	inclusions               []inclusion
+}
+
+// inclusion - When tx was confirmed & how long it took to get there,
+// since it was first seen
+type inclusion struct {
+	at      time.Time
+	latency time.Duration
 }
 
 // HasBeenAllocatedFor - Given an address from which tx was sent
@@ -52,6 +94,8 @@ type PendingPool struct {
 // @note This function is supposed to be invoked when lock is already held
 func (p *PendingPool) hasBeenAllocatedFor(addr common.Address) bool {
 
+	p.owner.check()
+
 	_, ok := p.TxsFromAddress[addr]
 	return ok
 
@@ -67,6 +111,8 @@ func (p *PendingPool) hasBeenAllocatedFor(addr common.Address) bool {
 // @note This function is supposed to be invoked when lock is already held
 func (p *PendingPool) allocateFor(addr common.Address) TxList {
 
+	p.owner.check()
+
 	if p.hasBeenAllocatedFor(addr) {
 		return p.TxsFromAddress[addr]
 	}
@@ -80,6 +126,10 @@ func (p *PendingPool) allocateFor(addr common.Address) TxList {
 // go routine, maintaining pending pool state, through out its life time
 func (p *PendingPool) Start(ctx context.Context) {
 
+	// This go routine owns pool state, mutations from anywhere
+	// else are caught in debug build
+	p.owner.claim()
+
 	// Closure for checking whether adding new tx triggers
 	// condition for dropping some other tx
 	//
@@ -94,11 +144,11 @@ func (p *PendingPool) Start(ctx context.Context) {
 	//
 	// @note Don't accept tx which are already dropped
 	needToDropTxs := func() bool {
-		return uint64(p.AscTxsByGasPrice.len())+1 > config.GetPendingPoolSize()
+		return uint64(p.TxsByGasPrice.len())+1 > config.GetPendingPoolSize()
 	}
 
 	pickTxWithLowestGasPrice := func() *MemPoolTx {
-		return p.AscTxsByGasPrice.get()[0]
+		return p.TxsByGasPrice.first()
 	}
 
 	// Plain simple safe tx adding into pool, logic, invoke it from other section
@@ -106,10 +156,36 @@ func (p *PendingPool) Start(ctx context.Context) {
 	// Don't rewrite this logic again
 	addTx := func(tx *MemPoolTx) {
 
-		p.AscTxsByGasPrice = Insert(p.AscTxsByGasPrice, tx)
-		p.DescTxsByGasPrice = Insert(p.DescTxsByGasPrice, tx)
+		p.TxsByGasPrice.insert(tx)
 		p.TxsFromAddress[tx.From] = Insert(p.allocateFor(tx.From), tx)
 		p.Transactions[tx.Hash] = tx
+		p.InFlight.Add(p.InFlight, tx.MaxCost())
+		metrics.PendingTxs.Set(float64(len(p.Transactions)))
+
+	}
+
+	// How many times each (sender, nonce) slot has been replaced, while
+	// some tx of that slot was living in pool
+	replacedTimes := make(map[replacementSlot]int)
+
+	// Tx(s) living in pool, sent by same sender, with same nonce
+	txsInSlot := func(from common.Address, nonce hexutil.Uint64) []*MemPoolTx {
+
+		txs, ok := p.TxsFromAddress[from]
+		if !ok {
+			return nil
+		}
+
+		result := make([]*MemPoolTx, 0, 1)
+		for _, v := range txs.get() {
+
+			if v.Nonce == nonce {
+				result = append(result, v)
+			}
+
+		}
+
+		return result
 
 	}
 
@@ -117,10 +193,47 @@ func (p *PendingPool) Start(ctx context.Context) {
 	removeTx := func(tx *MemPoolTx) {
 
 		// Remove from sorted tx list, keep it sorted
-		p.AscTxsByGasPrice = Remove(p.AscTxsByGasPrice, tx)
-		p.DescTxsByGasPrice = Remove(p.DescTxsByGasPrice, tx)
+		p.TxsByGasPrice.remove(tx)
 		p.TxsFromAddress[tx.From] = Remove(p.TxsFromAddress[tx.From], tx)
+		// Sender index entry to be released as soon as last tx from
+		// that address leaves pending pool, so that it doesn't keep
+		// growing with every address ever seen
+		if txs := p.TxsFromAddress[tx.From]; txs == nil || txs.len() == 0 {
+			delete(p.TxsFromAddress, tx.From)
+		}
 		delete(p.Transactions, tx.Hash)
+		p.InFlight.Sub(p.InFlight, tx.MaxCost())
+		metrics.PendingTxs.Set(float64(len(p.Transactions)))
+
+		// Replacement history is forgotten, once slot
+		// has nothing left in pool
+		if len(txsInSlot(tx.From, tx.Nonce)) == 0 {
+			delete(replacedTimes, replacementSlot{from: tx.From, nonce: tx.Nonce})
+		}
+
+	}
+
+	// Registered removal callbacks, invoked from this go routine
+	// in order of removal
+	onRemove := make([]func(*MemPoolTx, string), 0)
+
+	notifyRemoved := func(tx *MemPoolTx, reason string) {
+
+		for _, cb := range onRemove {
+			cb(tx, reason)
+		}
+
+	}
+
+	// Registered addition callbacks, invoked from this go routine
+	// in order of addition
+	onAdd := make([]func(*MemPoolTx), 0)
+
+	notifyAdded := func(tx *MemPoolTx) {
+
+		for _, cb := range onAdd {
+			cb(tx)
+		}
 
 	}
 
@@ -130,6 +243,7 @@ func (p *PendingPool) Start(ctx context.Context) {
 	dropTx := func(tx *MemPoolTx) {
 
 		removeTx(tx)
+		notifyRemoved(tx, "dropped")
 		// 👇 op not being done while holding lock
 		// is due to the fact, no other competing
 		// worker attempting to read from/ write to
@@ -138,6 +252,120 @@ func (p *PendingPool) Start(ctx context.Context) {
 
 	}
 
+	// Tx is evicted, when pool is full/ its sender is at cap, for paying
+	// lowest effective gas price, letting subscribers know it didn't leave
+	// mempool on its own
+	evictTx := func(tx *MemPoolTx) {
+
+		if tx == nil {
+			return
+		}
+
+		tx.Pool = "dropped"
+		tx.DroppedAt = time.Now().UTC()
+
+		dropTx(tx)
+		p.PublishEvicted(ctx, tx)
+
+	}
+
+	// Gas price with which this tx's (sender, nonce) slot was first seen
+	// in pool, so that fee escalations done using replacement tx(s)
+	// can be found out
+	initialGasPriceOf := func(tx *MemPoolTx) *hexutil.Big {
+
+		initial := tx.GasPrice
+
+		txs, ok := p.TxsFromAddress[tx.From]
+		if !ok {
+			return initial
+		}
+
+		for _, v := range txs.get() {
+
+			if v.Nonce != tx.Nonce || v.InitialGasPrice == nil {
+				continue
+			}
+
+			if bigOrZero(v.InitialGasPrice).Cmp(bigOrZero(initial)) < 0 {
+				initial = v.InitialGasPrice
+			}
+
+		}
+
+		return initial
+
+	}
+
+	// Checking whether some other tx with same (sender, nonce) is
+	// already living in pool, which this one is replacing
+	isReplacement := func(tx *MemPoolTx) bool {
+
+		txs, ok := p.TxsFromAddress[tx.From]
+		if !ok {
+			return false
+		}
+
+		for _, v := range txs.get() {
+
+			if v.Nonce == tx.Nonce && v.Hash != tx.Hash {
+				return true
+			}
+
+		}
+
+		return false
+
+	}
+
+	// Single sender can't hold more than configured #-of tx(s) in pool,
+	// if it's already at cap, its lowest gas price paying tx is evicted,
+	// given new one pays more, otherwise new one is rejected
+	makeRoomForSender := func(tx *MemPoolTx) bool {
+
+		// Sender index is bounded, once full, new senders are
+		// not let in, until some existing one leaves
+		if _, ok := p.TxsFromAddress[tx.From]; !ok {
+
+			if max := config.GetMaxSenders(); max != 0 && uint64(len(p.TxsFromAddress)) >= max {
+
+				// Not remembered as dropped, so that it's let in on some
+				// later poll, once any sender's last tx leaves pool
+				limitedLog.Printf("[❗️] Pending pool at cap of %d senders, rejected %s\n", max, tx.Hash.Hex())
+				return false
+
+			}
+
+		}
+
+		limit := config.GetMaxTxsPerSender()
+		if limit == 0 {
+			return true
+		}
+
+		txs, ok := p.TxsFromAddress[tx.From]
+		if !ok || uint64(txs.len()) < limit {
+			return true
+		}
+
+		lowest := p.TxsByGasPrice.lowestOf(txs.get())
+		if lowest == nil || !p.TxsByGasPrice.outbids(tx, lowest) {
+
+			limitedLog.Printf("[❗️] Sender %s at cap of %d pending tx(s), rejected %s\n", tx.From.Hex(), limit, tx.Hash.Hex())
+
+			// Not to be considered again, every time it's seen in poll
+			p.DroppedTxs[tx.Hash] = time.Now().UTC()
+			return false
+
+		}
+
+		evictTx(lowest)
+		limitedLog.Printf("[➖] Sender %s at cap of %d pending tx(s), evicted %s\n", tx.From.Hex(), limit, lowest.Hash.Hex())
+
+		return true
+
+	}
+
 	// Closure for safely adding new tx into pool
 	txAdder := func(tx *MemPoolTx) bool {
 
@@ -155,17 +383,49 @@ func (p *PendingPool) Start(ctx context.Context) {
 			return false
 		}
 
+		// When pool is full, tx not paying more than cheapest one
+		// can't take its place, so it's rejected, instead of making
+		// room for it. It's checked before sender cap, so that
+		// sender's own tx isn't evicted for a tx which is rejected.
+		if needToDropTxs() {
+
+			lowest := pickTxWithLowestGasPrice()
+			if lowest == nil || !p.TxsByGasPrice.outbids(tx, lowest) {
+
+				limitedLog.Printf("[❗️] Pending pool full, rejected %s paying no more than cheapest tx\n", tx.Hash.Hex())
+				return false
+
+			}
+
+		}
+
+		if !makeRoomForSender(tx) {
+			return false
+		}
+
 		if needToDropTxs() {
-			dropTx(pickTxWithLowestGasPrice())
+			evictTx(pickTxWithLowestGasPrice())
 		}
 
 		// Marking we found this tx in mempool now
 		tx.PendingFrom = time.Now().UTC()
 		tx.Pool = "pending"
+		tx.InitialGasPrice = initialGasPriceOf(tx)
+		replacing := isReplacement(tx)
 
 		addTx(tx)
+		notifyAdded(tx)
 		p.PublishAdded(ctx, tx)
 
+		if replacing {
+			replacedTimes[replacementSlot{from: tx.From, nonce: tx.Nonce}]++
+			p.PublishReplaced(ctx, tx)
+		}
+
+		p.Webhook.Fire(ctx, tx)
+		p.Estimator.Fire(ctx, tx)
+		p.Watchdog.Seen()
+
 		return true
 
 	}
@@ -191,15 +451,32 @@ func (p *PendingPool) Start(ctx context.Context) {
 		if txStat.Status == CONFIRMED {
 			tx.Pool = "confirmed"
 			tx.ConfirmedAt = time.Now().UTC()
+
+			p.inclusions = append(p.inclusions, inclusion{at: tx.ConfirmedAt, latency: tx.ConfirmedAt.Sub(tx.firstSeenAt())})
 		}
 
 		removeTx(tx)
+		notifyRemoved(tx, tx.Pool)
 		p.PublishRemoved(ctx, tx)
 
 		return true
 
 	}
 
+	// Letting queued pool side know about tx, which just joined pending
+	// pool, where it may have stopped consuming during shut down, so
+	// this loop must not get stuck waiting for it
+	forward := func(ch chan<- *MemPoolTx, tx *MemPoolTx) bool {
+
+		select {
+		case <-ctx.Done():
+			return false
+		case ch <- tx:
+			return true
+		}
+
+	}
+
 	for {
 
 		select {
@@ -217,14 +494,41 @@ func (p *PendingPool) Start(ctx context.Context) {
 				// Letting queued pool know, this tx is already added
 				// in pending pool, so it can be removed from queued pool
 				// if it's living there too
-				p.AlreadyInPendingPoolChan <- req.Tx
-				p.InPendingPoolChan <- req.Tx
+				if !forward(p.AlreadyInPendingPoolChan, req.Tx) || !forward(p.InPendingPoolChan, req.Tx) {
+					return
+				}
 			}
 
 		case req := <-p.AddFromQueuedPoolChan:
 
 			req.ResponseChan <- txAdder(req.Tx)
-			p.InPendingPoolChan <- req.Tx
+
+			if !forward(p.InPendingPoolChan, req.Tx) {
+				return
+			}
+
+		case req := <-p.RestoreChan:
+
+			// Tx(s) read from snapshot are put back as they were, without
+			// publishing, they've already been seen by subscribers
+			var count int
+
+			for _, tx := range req.Txs {
+
+				if _, ok := p.Transactions[tx.Hash]; ok {
+					continue
+				}
+
+				if needToDropTxs() {
+					break
+				}
+
+				addTx(tx)
+				count++
+
+			}
+
+			req.ResponseChan <- count
 
 		case req := <-p.RemoveTxChan:
 
@@ -238,11 +542,49 @@ func (p *PendingPool) Start(ctx context.Context) {
 				p.Done++
 			}
 
+		case v := <-p.EstimatedGasChan:
+
+			// Tx may have left pool, while being estimated
+			if tx, ok := p.Transactions[v.Hash]; ok {
+				tx.EstimatedGas = hexutil.Uint64(v.Gas)
+			}
+
+		case req := <-p.MarkMinedChan:
+
+			// Tx(s) living in pool are removed as confirmed, while
+			// others are only remembered as removed, so that stale
+			// poll results don't bring them in
+			var count int
+
+			for _, hash := range req.Txs {
+
+				if txRemover(&TxStatus{Hash: hash, Status: CONFIRMED}) {
+					p.Done++
+				}
+
+				p.RemovedTxs[hash] = time.Now().UTC()
+				count++
+
+			}
+
+			req.ResponseChan <- count
+
 		case req := <-p.TxExistsChan:
 
 			_, ok := p.Transactions[req.Tx]
 			req.ResponseChan <- ok
 
+		case req := <-p.TxsExistChan:
+
+			exists := make(map[common.Hash]bool, len(req.Txs))
+
+			for _, hash := range req.Txs {
+				_, ok := p.Transactions[hash]
+				exists[hash] = ok
+			}
+
+			req.ResponseChan <- exists
+
 		case req := <-p.GetTxChan:
 
 			if tx, ok := p.Transactions[req.Tx]; ok {
@@ -252,42 +594,169 @@ func (p *PendingPool) Start(ctx context.Context) {
 
 			req.ResponseChan <- nil
 
+		case req := <-p.SenderDominanceChan:
+
+			req <- dominanceOf(p.TxsFromAddress)
+
 		case req := <-p.CountTxsChan:
 
-			req.ResponseChan <- uint64(p.AscTxsByGasPrice.len())
+			if req.Filter == nil || req.Filter.IsEmpty() {
+				req.ResponseChan <- uint64(p.TxsByGasPrice.len())
+				break
+			}
+
+			// Counted in place, without collecting them
+			var count uint64
+			for _, tx := range p.Transactions {
+
+				if req.Filter.Matches(tx) {
+					count++
+				}
+
+			}
+
+			req.ResponseChan <- count
 
 		case req := <-p.ListTxsChan:
 
-			if req.Order == ASC {
+			// If empty/ requested window is out of range,
+			// nil to be returned
+			req.ResponseChan <- p.TxsByGasPrice.window(req.Order, req.Offset, req.Limit)
 
-				// If empty, just return nil
-				if p.AscTxsByGasPrice.len() == 0 {
-					req.ResponseChan <- nil
-					break
+		case req := <-p.GasPricePercentileChan:
+
+			// Picked straight out of gas price ordered list
+			req.ResponseChan <- p.TxsByGasPrice.percentile(req.P, 0)
+
+		case req := <-p.IncludablePercentileChan:
+
+			// Tx(s) whose fee cap is below base fee pay effective gas price
+			// lower than base fee, while includable ones pay at least base fee,
+			// so they're all placed after non-includable ones
+			offset := 0
+			if p.TxsByGasPrice.baseFee != nil {
+				offset = p.TxsByGasPrice.rankOf(p.TxsByGasPrice.baseFee)
+			}
+
+			if offset == p.TxsByGasPrice.len() {
+				req.ResponseChan <- nil
+				break
+			}
+
+			prices := make([]*big.Int, 0, len(req.Ps))
+			for _, pct := range req.Ps {
+				prices = append(prices, p.TxsByGasPrice.percentile(pct, offset))
+			}
+
+			req.ResponseChan <- prices
+
+		case req := <-p.HeavilyReplacedChan:
+
+			result := make([]*MemPoolTx, 0)
+
+			for slot, times := range replacedTimes {
+
+				if times < req.Min {
+					continue
 				}
 
-				copied := make([]*MemPoolTx, p.AscTxsByGasPrice.len())
-				copy(copied, p.AscTxsByGasPrice.get())
+				// Latest one seen is considered to be
+				// current tx of slot
+				var current *MemPoolTx
+				for _, v := range txsInSlot(slot.from, slot.nonce) {
 
-				req.ResponseChan <- copied
+					if current == nil || v.PendingFrom.After(current.PendingFrom) {
+						current = v
+					}
+
+				}
+
+				if current != nil {
+					result = append(result, current)
+				}
+
+			}
+
+			req.ResponseChan <- result
+
+		case req := <-p.GasRecommendationChan:
+
+			// Same as percentiles of includable tx(s), they're placed
+			// after ones not paying base fee
+			offset := 0
+			if p.TxsByGasPrice.baseFee != nil {
+				offset = p.TxsByGasPrice.rankOf(p.TxsByGasPrice.baseFee)
+			}
+
+			if p.TxsByGasPrice.len()-offset < MinTxsForRecommendation {
+
+				req <- GasRecommendation{
+					Slow:             big.NewInt(0),
+					Standard:         big.NewInt(0),
+					Fast:             big.NewInt(0),
+					InsufficientData: true,
+				}
 				break
 
 			}
 
-			if req.Order == DESC {
+			req <- GasRecommendation{
+				Slow:     p.TxsByGasPrice.percentile(SlowPercentile, offset),
+				Standard: p.TxsByGasPrice.percentile(StandardPercentile, offset),
+				Fast:     p.TxsByGasPrice.percentile(FastPercentile, offset),
+			}
+
+		case req := <-p.DisplacedByChan:
 
-				// If empty, just return nil
-				if p.DescTxsByGasPrice.len() == 0 {
-					req.ResponseChan <- nil
+			// Pool not full yet, nothing to be dropped
+			if !needToDropTxs() {
+				req.ResponseChan <- nil
+				break
+			}
+
+			// Candidate must outbid cheapest tx in pool, otherwise
+			// it's not worth displacing anything
+			lowest := pickTxWithLowestGasPrice()
+			if lowest == nil || lowest.EffectiveGasPrice(p.TxsByGasPrice.baseFee).Cmp(req.GasPrice) >= 0 {
+				req.ResponseChan <- nil
+				break
+			}
+
+			req.ResponseChan <- lowest
+
+		case req := <-p.SimulateCandidateChan:
+
+			// Ranked as per same effective gas price, pool is ordered by,
+			// placed after tx(s) paying same
+			rank := p.TxsByGasPrice.len() - p.TxsByGasPrice.rankOf(req.GasPrice) + 1
+
+			candidate := &MemPoolTx{
+				GasPrice: (*hexutil.Big)(req.GasPrice),
+				Gas:      hexutil.Uint64(req.Gas),
+			}
+
+			txs := p.TxsByGasPrice.window(DESC, 0, 0)
+
+			var included bool
+
+			for _, tx := range simulateBlock(append(txs, candidate), req.BlockGasLimit, p.TxsByGasPrice.baseFee) {
+
+				if tx == candidate {
+					included = true
 					break
 				}
 
-				copied := make([]*MemPoolTx, p.DescTxsByGasPrice.len())
-				copy(copied, p.DescTxsByGasPrice.get())
+			}
 
-				req.ResponseChan <- copied
+			CleanSlice(txs)
+			req.ResponseChan <- CandidateSimulation{Rank: rank, Included: included}
 
-			}
+		case req := <-p.TxsByGasPriceRangeChan:
+			// Only those txs which are paying gas price within
+			// requested range, found by seeking to lower bound in
+			// gas price ordered list
+
+			req.ResponseChan <- p.TxsByGasPrice.rangeByGasPrice(req.Min, req.Max)
 
 		case req := <-p.TxsFromAChan:
 			// Return only those txs, which were sent by specific address `A`
@@ -309,6 +778,36 @@ func (p *PendingPool) Start(ctx context.Context) {
 
 			req.ResponseChan <- nil
 
+		case req := <-p.OldestPerSenderChan:
+
+			// Single pass over sender index, picking tx which
+			// has been pending for longest, for each sender
+			oldest := make(map[common.Address]*MemPoolTx, len(p.TxsFromAddress))
+
+			for addr, txs := range p.TxsFromAddress {
+
+				for _, tx := range txs.get() {
+
+					if cur, ok := oldest[addr]; !ok || tx.PendingFrom.Before(cur.PendingFrom) {
+						oldest[addr] = tx
+					}
+
+				}
+
+			}
+
+			req <- oldest
+
+		case req := <-p.OnRemoveChan:
+
+			onRemove = append(onRemove, req.Callback)
+			req.ResponseChan <- true
+
+		case req := <-p.OnAddChan:
+
+			onAdd = append(onAdd, req.Callback)
+			req.ResponseChan <- true
+
 		case req := <-p.DoneChan:
 
 			// How many tx(s) are seen to be
@@ -326,36 +825,115 @@ func (p *PendingPool) Start(ctx context.Context) {
 				break
 			}
 
+			// Moving average of time between blocks, for estimating
+			// how long it'll take for some #-of blocks to be mined,
+			// where skipped blocks share elapsed time equally
+			now := time.Now().UTC()
+			if p.LastSeenBlock != 0 && num > p.LastSeenBlock {
+
+				sample := now.Sub(p.LastSeenAt) / time.Duration(num-p.LastSeenBlock)
+
+				if p.BlockInterval == 0 {
+					p.BlockInterval = sample
+				} else {
+					p.BlockInterval += (sample - p.BlockInterval) / 8
+				}
+
+			}
+
 			p.LastSeenBlock = num
-			p.LastSeenAt = time.Now().UTC()
+			p.LastSeenAt = now
 
-		case req := <-p.LastSeenBlockChan:
+			// Base fee of this block decides ordering of EIP-1559 tx(s),
+			// while its timestamp tells how far off our clock is, both
+			// fetched without blocking pool
+			go p.inspectBlock(ctx, num)
 
-			req <- LastSeenBlock{Number: p.LastSeenBlock, At: p.LastSeenAt}
+		case baseFee := <-p.SetBaseFeeChan:
 
-		case <-time.After(time.Duration(1) * time.Millisecond):
-			// After 1 hour of keeping entries which were previously removed
-			// are now being deleted from memory, so that memory usage for keeping track of
-			// which were removed in past doesn't become a problem for us.
-			//
-			// 1 hour is just a random time period, it can be possibly improved
-			//
-			// Just hoping after 1 hour of last time this tx was seen to be added
-			// into this pool, it has been either dropped/ confirmed, so it won't
-			// be attempted to be added here again
+			// Effective gas price of EIP-1559 tx(s) changes with
+			// base fee, so they need to be reordered
+			p.TxsByGasPrice.rebase(baseFee)
 
-			for k := range p.DroppedTxs {
+		case skew := <-p.SetClockSkewChan:
 
-				if time.Now().UTC().Sub(p.DroppedTxs[k]) > time.Duration(1)*time.Hour {
-					delete(p.DroppedTxs, k)
-				}
+			p.Skew = skew
 
+			if skew < 0 {
+				skew = -skew
 			}
 
-		case <-time.After(time.Duration(1) * time.Millisecond):
-			// Read 👆 comment
+			if skew > time.Duration(config.GetClockSkewThreshold())*time.Millisecond {
+				log.Printf("[❗️] Local clock is off from chain by %s, tx age(s) may be inaccurate\n", p.Skew)
+			}
 
-			for k := range p.RemovedTxs {
+		case req := <-p.LastSeenBlockChan:
+
+			req <- LastSeenBlock{Number: p.LastSeenBlock, At: p.LastSeenAt, Interval: p.BlockInterval}
+
+		case req := <-p.ClockSkewChan:
+
+			req <- p.Skew
+
+		case req := <-p.BaseFeeChan:
+
+			// Copied, so that caller can't mutate what
+			// gas price ordered list relies on
+			if p.TxsByGasPrice.baseFee == nil {
+				req <- nil
+				break
+			}
+
+			req <- big.NewInt(0).Set(p.TxsByGasPrice.baseFee)
+
+		case req := <-p.ValueInFlightChan:
+
+			req <- big.NewInt(0).Set(p.InFlight)
+
+		case req := <-p.InclusionLatencyChan:
+
+			var total time.Duration
+			var count int64
+
+			// Entries are in order of confirmation, so walking
+			// backwards until one falls out of window
+			since := time.Now().UTC().Add(-req.Window)
+
+			for i := len(p.inclusions) - 1; i >= 0 && !p.inclusions[i].at.Before(since); i-- {
+				total += p.inclusions[i].latency
+				count++
+			}
+
+			if count == 0 {
+				req.ResponseChan <- 0
+				break
+			}
+
+			req.ResponseChan <- time.Duration(int64(total) / count)
+
+		case <-time.After(time.Duration(1) * time.Millisecond):
+			// After 1 hour of keeping entries which were previously removed
+			// are now being deleted from memory, so that memory usage for keeping track of
+			// which were removed in past doesn't become a problem for us.
+			//
+			// 1 hour is just a random time period, it can be possibly improved
+			//
+			// Just hoping after 1 hour of last time this tx was seen to be added
+			// into this pool, it has been either dropped/ confirmed, so it won't
+			// be attempted to be added here again
+
+			for k := range p.DroppedTxs {
+
+				if time.Now().UTC().Sub(p.DroppedTxs[k]) > time.Duration(1)*time.Hour {
+					delete(p.DroppedTxs, k)
+				}
+
+			}
+
+		case <-time.After(time.Duration(1) * time.Millisecond):
+			// Read 👆 comment
+
+			for k := range p.RemovedTxs {
 
 				if time.Now().UTC().Sub(p.RemovedTxs[k]) > time.Duration(1)*time.Hour {
 					delete(p.RemovedTxs, k)
@@ -363,12 +941,63 @@ func (p *PendingPool) Start(ctx context.Context) {
 
 			}
 
+			// Confirmations are forgotten same way, oldest ones
+			// being at front
+			var expired int
+			for expired < len(p.inclusions) && time.Now().UTC().Sub(p.inclusions[expired].at) > time.Duration(1)*time.Hour {
+				expired++
+			}
+
+			if expired != 0 {
+				p.inclusions = append(p.inclusions[:0], p.inclusions[expired:]...)
+			}
+
 		}
 
 	}
 
 }
 
+// inspectBlock - Fetches base fee & timestamp of given block & lets pool know
+// about those, so that tx(s) can be ordered as per effective gas price & local
+// clock's skew from chain can be tracked
+//
+// @note Pre-London blocks don't carry base fee, those are ignored
+func (p *PendingPool) inspectBlock(ctx context.Context, number uint64) {
+
+	var block struct {
+		BaseFee   *hexutil.Big   `json:"baseFeePerGas"`
+		Timestamp hexutil.Uint64 `json:"timestamp"`
+	}
+
+	client, _ := p.Upstreams.Active()
+
+	if err := client.CallContext(ctx, &block, "eth_getBlockByNumber", hexutil.EncodeUint64(number), false); err != nil {
+		log.Printf("[❗️] Failed to fetch block %d : %s\n", number, err.Error())
+		return
+	}
+
+	// Block propagation delay is also included, so it's
+	// never exactly zero, even with perfectly synced clocks
+	skew := time.Now().UTC().Sub(time.Unix(int64(block.Timestamp), 0))
+
+	select {
+	case <-ctx.Done():
+		return
+	case p.SetClockSkewChan <- skew:
+	}
+
+	if block.BaseFee == nil {
+		return
+	}
+
+	select {
+	case <-ctx.Done():
+	case p.SetBaseFeeChan <- BigHexToBigDecimal(block.BaseFee):
+	}
+
+}
+
 // Prune - Remove confirmed/ dropped txs from pending pool
 //
 // @note This method is supposed to be run as independent go routine
@@ -391,6 +1020,7 @@ func (p *PendingPool) Prune(ctx context.Context, caughtTxsChan <-chan listen.Cau
 
 		case txs := <-caughtTxsChan:
 
+			start := time.Now().UTC()
 			var prunables []*MemPoolTx = make([]*MemPoolTx, 0, len(txs))
 			var notFoundTxs []*listen.CaughtTx = make([]*listen.CaughtTx, 0, len(txs))
 
@@ -413,7 +1043,7 @@ func (p *PendingPool) Prune(ctx context.Context, caughtTxsChan <-chan listen.Cau
 
 			for i := 0; i < len(txs); i++ {
 
-				tx := p.Get(txs[i].Hash)
+				tx := p.Get(ctx, txs[i].Hash)
 				if tx == nil {
 					// well, couldn't find tx in pool, keeping track of
 					// it in another worker, which will let us know about it
@@ -442,7 +1072,7 @@ func (p *PendingPool) Prune(ctx context.Context, caughtTxsChan <-chan listen.Cau
 
 				}
 
-				_prunableLocal := p.Prunables(tx)
+				_prunableLocal := p.Prunables(ctx, tx)
 				_prubableLocalC := len(_prunableLocal)
 				_metadata := metadata{nonce: tx.Nonce, from: len(prunables), count: _prubableLocalC}
 
@@ -478,7 +1108,27 @@ func (p *PendingPool) Prune(ctx context.Context, caughtTxsChan <-chan listen.Cau
 
 						// Tx got confirmed/ dropped, to be used when computing
 						// how long it spent in pending pool
-						dropped, _ := tx.IsDropped(ctx, p.RPC)
+						client, _ := p.Upstreams.Active()
+
+						dropped, err := tx.IsDropped(ctx, client)
+						if err != nil && ctx.Err() == nil && p.Upstreams.Failover(client) {
+
+							// One more attempt against next healthy endpoint
+							client, _ = p.Upstreams.Active()
+							dropped, err = tx.IsDropped(ctx, client)
+
+						}
+
+						// Without receipt lookup, it's not known whether it got
+						// mined or dropped, so it's left in pool, to be pruned
+						// when some later tx from same sender gets mined
+						if err != nil {
+
+							limitedLog.Printf("[❗️] Failed to look up receipt of %s, left in pending pool : %s\n", tx.Hash.Hex(), err.Error())
+							return
+
+						}
+
 						if dropped {
 
 							internalChan <- &TxStatus{Hash: tx.Hash, Status: DROPPED}
@@ -495,6 +1145,7 @@ func (p *PendingPool) Prune(ctx context.Context, caughtTxsChan <-chan listen.Cau
 			}
 
 			CleanSlice(prunables)
+			metrics.PruneDuration.Observe(time.Now().UTC().Sub(start).Seconds())
 
 		case tx := <-internalChan:
 
@@ -520,36 +1171,141 @@ func (p *PendingPool) Prune(ctx context.Context, caughtTxsChan <-chan listen.Cau
 
 // Get - Given tx hash, attempts to find out tx in pending pool, if any
 //
-// Returns nil, if found nothing
-func (p *PendingPool) Get(hash common.Hash) *MemPoolTx {
+// Returns nil, if found nothing.
+//
+// @note All pool operations taking `ctx` give up & return zero value, as soon as
+// `ctx` is done, so that callers don't hang when pool worker is unresponsive
+func (p *PendingPool) Get(ctx context.Context, hash common.Hash) *MemPoolTx {
+
+	p.owner.enter()
 
-	respChan := make(chan *MemPoolTx)
+	respChan := make(chan *MemPoolTx, 1)
 
-	p.GetTxChan <- GetRequest{Tx: hash, ResponseChan: respChan}
+	// Pool may have stopped serving requests, so don't
+	// wait beyond what caller is willing to
+	select {
+	case <-ctx.Done():
+		return nil
+	case p.GetTxChan <- GetRequest{Tx: hash, ResponseChan: respChan}:
+	}
 
-	return <-respChan
+	select {
+	case <-ctx.Done():
+		return nil
+	case v := <-respChan:
+		return v
+	}
 
 }
 
 // Exists - Checks whether tx of given hash exists on pending pool or not
-func (p *PendingPool) Exists(hash common.Hash) bool {
+func (p *PendingPool) Exists(ctx context.Context, hash common.Hash) bool {
+
+	p.owner.enter()
+
+	respChan := make(chan bool, 1)
+
+	select {
+	case <-ctx.Done():
+		return false
+	case p.TxExistsChan <- ExistsRequest{Tx: hash, ResponseChan: respChan}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case v := <-respChan:
+		return v
+	}
+
+}
+
+// ExistsMany - Checks whether each of given tx hashes exists in pending pool
+// or not, using single round trip to pool, rather than one per hash
+func (p *PendingPool) ExistsMany(ctx context.Context, hashes []common.Hash) map[common.Hash]bool {
+
+	respChan := make(chan map[common.Hash]bool, 1)
 
-	respChan := make(chan bool)
+	select {
+	case <-ctx.Done():
+		return nil
+	case p.TxsExistChan <- ExistsManyRequest{Txs: hashes, ResponseChan: respChan}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case v := <-respChan:
+		return v
+	}
+
+}
+
+// SenderDominance - Largest share of pending pool held by single sender, by
+// #-of tx(s) & by gas limit
+func (p *PendingPool) SenderDominance(ctx context.Context) SenderDominance {
+
+	respChan := make(chan SenderDominance, 1)
+
+	select {
+	case <-ctx.Done():
+		return SenderDominance{}
+	case p.SenderDominanceChan <- respChan:
+	}
+
+	select {
+	case <-ctx.Done():
+		return SenderDominance{}
+	case v := <-respChan:
+		return v
+	}
+
+}
+
+// CountMatching - #-of pending tx(s) satisfying given filter, counted in
+// single pass over pool, by pool itself, without collecting them
+//
+// @note Empty filter is same as `Count`
+func (p *PendingPool) CountMatching(ctx context.Context, filter TxFilter) uint64 {
+
+	p.owner.enter()
 
-	p.TxExistsChan <- ExistsRequest{Tx: hash, ResponseChan: respChan}
+	respChan := make(chan uint64, 1)
+
+	select {
+	case <-ctx.Done():
+		return 0
+	case p.CountTxsChan <- CountRequest{Filter: &filter, ResponseChan: respChan}:
+	}
 
-	return <-respChan
+	select {
+	case <-ctx.Done():
+		return 0
+	case v := <-respChan:
+		return v
+	}
 
 }
 
 // Count - How many tx(s) currently present in pending pool
-func (p *PendingPool) Count() uint64 {
+func (p *PendingPool) Count(ctx context.Context) uint64 {
+
+	p.owner.enter()
 
-	respChan := make(chan uint64)
+	respChan := make(chan uint64, 1)
 
-	p.CountTxsChan <- CountRequest{ResponseChan: respChan}
+	select {
+	case <-ctx.Done():
+		return 0
+	case p.CountTxsChan <- CountRequest{ResponseChan: respChan}:
+	}
 
-	return <-respChan
+	select {
+	case <-ctx.Done():
+		return 0
+	case v := <-respChan:
+		return v
+	}
 
 }
 
@@ -557,29 +1313,137 @@ func (p *PendingPool) Count() uint64 {
 // as seen by this `harmony` instance during its life time
 //
 // This is nothing but count of `dropped` & `confirmed` tx(s)
-func (p *PendingPool) Processed() uint64 {
-	respChan := make(chan uint64)
+func (p *PendingPool) Processed(ctx context.Context) uint64 {
+	respChan := make(chan uint64, 1)
 
-	p.DoneChan <- respChan
+	select {
+	case <-ctx.Done():
+		return 0
+	case p.DoneChan <- respChan:
+	}
 
-	return <-respChan
+	select {
+	case <-ctx.Done():
+		return 0
+	case v := <-respChan:
+		return v
+	}
 }
 
 // GetLastSeenBlock - Get last seen block & time, as reported
 // by block header listener
-func (p *PendingPool) GetLastSeenBlock() LastSeenBlock {
-	respChan := make(chan LastSeenBlock)
+func (p *PendingPool) GetLastSeenBlock(ctx context.Context) LastSeenBlock {
+	respChan := make(chan LastSeenBlock, 1)
+
+	select {
+	case <-ctx.Done():
+		return LastSeenBlock{}
+	case p.LastSeenBlockChan <- respChan:
+	}
+
+	select {
+	case <-ctx.Done():
+		return LastSeenBlock{}
+	case v := <-respChan:
+		return v
+	}
+}
+
+// ValueInFlight - Total value at risk in pending pool, i.e. sum of value
+// being transferred along with max fee, of all pending tx(s), in wei
+//
+// @note Running total is maintained as tx(s) join/ leave pool, so it's O(1)
+func (p *PendingPool) ValueInFlight(ctx context.Context) *big.Int {
+
+	respChan := make(chan *big.Int, 1)
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case p.ValueInFlightChan <- respChan:
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case v := <-respChan:
+		return v
+	}
+
+}
+
+// AvgInclusionLatency - Average time taken by tx(s), confirmed within last
+// `window`, to get mined since they were first seen, zero if none
+//
+// @note Confirmations are remembered for 1 hour, so wider window doesn't help
+func (p *PendingPool) AvgInclusionLatency(ctx context.Context, window time.Duration) time.Duration {
+
+	respChan := make(chan time.Duration, 1)
+
+	select {
+	case <-ctx.Done():
+		return 0
+	case p.InclusionLatencyChan <- InclusionLatencyRequest{Window: window, ResponseChan: respChan}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return 0
+	case v := <-respChan:
+		return v
+	}
+
+}
+
+// ClockSkew - How far ahead local clock is from timestamp of last seen block,
+// negative if it's behind
+func (p *PendingPool) ClockSkew(ctx context.Context) time.Duration {
+
+	respChan := make(chan time.Duration, 1)
+
+	select {
+	case <-ctx.Done():
+		return 0
+	case p.ClockSkewChan <- respChan:
+	}
+
+	select {
+	case <-ctx.Done():
+		return 0
+	case v := <-respChan:
+		return v
+	}
+
+}
+
+// BaseFee - Base fee, pending pool is currently ordered as per, so that
+// tx(s) listed in gas price order can be compared using same effective
+// gas price, nil if not yet known
+func (p *PendingPool) BaseFee(ctx context.Context) *big.Int {
+
+	respChan := make(chan *big.Int, 1)
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case p.BaseFeeChan <- respChan:
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case v := <-respChan:
+		return v
+	}
 
-	p.LastSeenBlockChan <- respChan
-	return <-respChan
 }
 
 // Prunables - Given tx, we're attempting to find out all txs which are living
 // in pending pool now & having same sender address & same/ lower nonce, so that
 // pruner can update state while removing mined txs from mempool
-func (p *PendingPool) Prunables(targetTx *MemPoolTx) []*MemPoolTx {
+func (p *PendingPool) Prunables(ctx context.Context, targetTx *MemPoolTx) []*MemPoolTx {
 
-	txs := p.TxsFromA(targetTx.From)
+	txs := p.TxsFromA(ctx, targetTx.From)
 	if txs == nil {
 		return nil
 	}
@@ -644,280 +1508,554 @@ func (p *PendingPool) Prunables(targetTx *MemPoolTx) []*MemPoolTx {
 
 }
 
-// DuplicateTxs - Attempting to find duplicate tx(s) for given
-// txHash.
-//
-// @note In duplicate tx list, the tx which was provided as input
-// will not be included
+// SenderSummary - Summarises all tx(s) living in pending pool, sent from
+// given address, in a single pass over them, where gas prices are effective
+// ones, under latest known base fee
 //
-// Considering one tx duplicate of given one, if this tx has same
-// nonce & sender address, as of given ones
-func (p *PendingPool) DuplicateTxs(hash common.Hash) []*MemPoolTx {
-
-	targetTx := p.Get(hash)
-	if targetTx == nil {
-		return nil
-	}
+// @note Returns nil, if no tx from this address is present in pool
+func (p *PendingPool) SenderSummary(ctx context.Context, addr common.Address) *SenderStats {
 
-	txs := p.TxsFromA(targetTx.From)
+	txs := p.TxsFromA(ctx, addr)
 	if txs == nil {
 		return nil
 	}
 
-	txCount := uint64(len(txs))
-	commChan := make(chan *MemPoolTx, txCount)
-	result := make([]*MemPoolTx, 0, txCount)
-
-	var wp *workerpool.WorkerPool
+	baseFee := p.BaseFee(ctx)
 
-	if txCount > uint64(runtime.NumCPU()) {
-		wp = workerpool.New(runtime.NumCPU())
-	} else {
-		wp = workerpool.New(int(txCount))
+	stats := &SenderStats{
+		Address:    addr,
+		TxCount:    uint64(len(txs)),
+		TotalValue: big.NewInt(0),
+		TotalFees:  big.NewInt(0),
+		// Tx(s) are kept sorted by nonce, for each sender
+		LowestNonce:  uint64(txs[0].Nonce),
+		HighestNonce: uint64(txs[len(txs)-1].Nonce),
 	}
 
+	totalGasPrice := big.NewInt(0)
+	now := time.Now().UTC()
+
 	for i := 0; i < len(txs); i++ {
 
-		func(tx *MemPoolTx) {
+		if txs[i].Value != nil {
+			stats.TotalValue.Add(stats.TotalValue, BigHexToBigDecimal(txs[i].Value))
+		}
 
-			wp.Submit(func() {
+		gp := txs[i].EffectiveGasPrice(baseFee)
 
-				if tx.IsDuplicateOf(targetTx) {
-					commChan <- tx
-					return
-				}
+		totalGasPrice.Add(totalGasPrice, gp)
+		stats.TotalFees.Add(stats.TotalFees, big.NewInt(0).Mul(gp, big.NewInt(0).SetUint64(uint64(txs[i].Gas))))
 
-				commChan <- nil
+		if stats.MinGasPrice == nil || gp.Cmp(stats.MinGasPrice) < 0 {
+			stats.MinGasPrice = gp
+		}
 
-			})
+		if stats.MaxGasPrice == nil || gp.Cmp(stats.MaxGasPrice) > 0 {
+			stats.MaxGasPrice = gp
+		}
 
-		}(txs[i])
+		if age := now.Sub(txs[i].PendingFrom); age > stats.OldestTxAge {
+			stats.OldestTxAge = age
+		}
 
 	}
 
-	var received uint64
+	stats.AvgGasPrice = totalGasPrice.Div(totalGasPrice, big.NewInt(0).SetUint64(stats.TxCount))
 
-	for v := range commChan {
+	CleanSlice(txs)
+	return stats
 
-		if v != nil {
-			result = append(result, v)
-		}
+}
 
-		received++
-		if received >= txCount {
-			break
-		}
+// SimulateNextBlock - Picks tx(s) from pending pool, which would be included
+// in next block of given gas limit, if block producer greedily picks highest
+// paying tx(s), while respecting nonce order of each sender
+//
+// @note Gas limit of tx is considered as its gas usage, because actual
+// usage is unknown until execution
+func (p *PendingPool) SimulateNextBlock(ctx context.Context, gasLimit uint64, baseFee *big.Int) []*MemPoolTx {
 
+	txs := p.DescListTxs(ctx)
+	if txs == nil {
+		return []*MemPoolTx{}
 	}
 
-	// This call is irrelevant here, probably, but still being made
-	//
-	// Because all workers have exited, otherwise we could have never
-	// reached this point
-	wp.Stop()
-	CleanSlice(txs)
+	included := simulateBlock(txs, gasLimit, baseFee)
 
-	return result
+	CleanSlice(txs)
+	return included
 
 }
 
-// AscListTxs - Returns all tx(s) present in pending pool, as slice, ascending ordered as per gas price paid
-func (p *PendingPool) AscListTxs() []*MemPoolTx {
+// SimulateCandidate - Read-only check of where hypothetical tx, paying `gasPrice`
+// & consuming `gas`, would be ranked ( 1-based, as per gas price, placed after
+// tx(s) paying same ) in pending pool & whether it'd make it to next block of
+// `blockGasLimit`, if it was submitted now
+//
+// @note Candidate is considered to be from sender having no other tx in pool
+//
+// @note Returns zero rank, if `gasPrice` isn't given or `ctx` is done
+func (p *PendingPool) SimulateCandidate(ctx context.Context, gasPrice *big.Int, gas uint64, blockGasLimit uint64) (int, bool) {
+
+	if gasPrice == nil {
+		return 0, false
+	}
+
+	p.owner.enter()
 
-	respChan := make(chan []*MemPoolTx)
+	respChan := make(chan CandidateSimulation, 1)
 
-	p.ListTxsChan <- ListRequest{ResponseChan: respChan, Order: ASC}
+	select {
+	case <-ctx.Done():
+		return 0, false
+	case p.SimulateCandidateChan <- SimulateCandidateRequest{GasPrice: gasPrice, Gas: gas, BlockGasLimit: blockGasLimit, ResponseChan: respChan}:
+	}
 
-	return <-respChan
+	select {
+	case <-ctx.Done():
+		return 0, false
+	case v := <-respChan:
+		return v.Rank, v.Included
+	}
 
 }
 
-// DescListTxs - Returns all tx(s) present in pending pool, as slice, descending ordered as per gas price paid
-func (p *PendingPool) DescListTxs() []*MemPoolTx {
+// GasPriceForInclusionWithin - Minimum gas price, candidate tx with `txGas` gas
+// limit needs to pay, for getting included within next `k` blocks, each of
+// `blockGasLimit`, given current pending pool. Blocks are simulated one after
+// another, each one packed out of tx(s) left by previous ones.
+//
+// If k-th block has room for candidate, it fits paying just current base fee
+// i.e. zero, when base fee isn't known yet. Otherwise it needs to outbid as
+// many of cheapest tx(s) of k-th block, as required for making room for it.
+//
+// @note Returns nil, if `k` is not positive or candidate can't fit in a block
+func (p *PendingPool) GasPriceForInclusionWithin(ctx context.Context, k int, txGas uint64, blockGasLimit uint64) *big.Int {
 
-	respChan := make(chan []*MemPoolTx)
+	if k < 1 || txGas < MinTxGas || txGas > blockGasLimit {
+		return nil
+	}
 
-	p.ListTxsChan <- ListRequest{ResponseChan: respChan, Order: DESC}
+	baseFee := p.BaseFee(ctx)
 
-	return <-respChan
+	floor := big.NewInt(0)
+	if baseFee != nil {
+		floor.Set(baseFee)
+	}
 
-}
+	txs := p.DescListTxs(ctx)
+	if txs == nil {
+		return floor
+	}
 
-// TxsFromA - Returns a slice of txs, where all of those are sent
-// by address `A`
-func (p *PendingPool) TxsFromA(addr common.Address) []*MemPoolTx {
+	defer CleanSlice(txs)
 
-	respChan := make(chan []*MemPoolTx)
+	left := txs
+	var block []*MemPoolTx
 
-	p.TxsFromAChan <- TxsFromARequest{ResponseChan: respChan, From: addr}
+	for i := 0; i < k; i++ {
 
-	return <-respChan
+		block = simulateBlock(left, blockGasLimit, baseFee)
+		if len(block) == 0 {
+			return floor
+		}
 
-}
+		included := make(map[common.Hash]struct{}, len(block))
+		for _, tx := range block {
+			included[tx.Hash] = struct{}{}
+		}
 
-// TopXWithHighGasPrice - Returns only top `X` tx(s) present in pending mempool,
-// where being top is determined by how much gas price paid by tx sender
-func (p *PendingPool) TopXWithHighGasPrice(x uint64) []*MemPoolTx {
+		rest := make([]*MemPoolTx, 0, len(left)-len(block))
+		for _, tx := range left {
 
-	txs := p.DescListTxs()
-	if uint64(len(txs)) <= x {
-		return txs
-	}
+			if _, ok := included[tx.Hash]; !ok {
+				rest = append(rest, tx)
+			}
 
-	CleanSlice(txs[x:])
-	return txs[:x]
+		}
 
-}
+		left = rest
 
-// TopXWithLowGasPrice - Returns only top `X` tx(s) present in pending mempool,
-// where being top is determined by how low gas price paid by tx sender
-func (p *PendingPool) TopXWithLowGasPrice(x uint64) []*MemPoolTx {
+	}
 
-	txs := p.AscListTxs()
-	if uint64(len(txs)) <= x {
-		return txs
+	var used uint64
+	for _, tx := range block {
+		used += uint64(tx.Gas)
 	}
 
-	CleanSlice(txs[x:])
-	return txs[:x]
+	// Room left in k-th block, candidate fits anyway
+	room := blockGasLimit - used
+	if room >= txGas {
+		return floor
+	}
 
-}
+	// Cheapest ones are pushed out first, until there's
+	// enough room
+	sort.Slice(block, func(i, j int) bool {
+		return block[i].EffectiveGasPrice(baseFee).Cmp(block[j].EffectiveGasPrice(baseFee)) < 0
+	})
 
-// SentFrom - Returns a list of pending tx(s) sent from
-// specified address
-func (p *PendingPool) SentFrom(address common.Address) []*MemPoolTx {
-	return p.TxsFromA(address)
-}
+	var price *big.Int
 
-// SentTo - Returns a list of pending tx(s) sent to
-// specified address
-func (p *PendingPool) SentTo(address common.Address) []*MemPoolTx {
+	for _, tx := range block {
+
+		price = tx.EffectiveGasPrice(baseFee)
+		room += uint64(tx.Gas)
+
+		if room >= txGas {
+			break
+		}
 
-	txs := p.DescListTxs()
-	if txs == nil {
-		return nil
 	}
 
-	txCount := uint64(len(txs))
-	commChan := make(chan *MemPoolTx, txCount)
-	result := make([]*MemPoolTx, 0, txCount)
+	price.Add(price, big.NewInt(1))
+	if price.Cmp(floor) < 0 {
+		return floor
+	}
 
-	wp := workerpool.New(runtime.NumCPU())
+	return price
 
-	for i := 0; i < len(txs); i++ {
+}
 
-		func(tx *MemPoolTx) {
+// NextBlockTipRevenue - Sum of effective tip x gas, for all tx(s) which would
+// be included in next block, as per `SimulateNextBlock`
+func (p *PendingPool) NextBlockTipRevenue(ctx context.Context, gasLimit uint64, baseFee *big.Int) *big.Int {
 
-			wp.Submit(func() {
+	revenue := big.NewInt(0)
 
-				if tx.IsSentTo(address) {
-					commChan <- tx
-					return
-				}
+	for _, tx := range p.SimulateNextBlock(ctx, gasLimit, baseFee) {
+		revenue.Add(revenue, big.NewInt(0).Mul(effectiveTip(tx, baseFee), big.NewInt(0).SetUint64(uint64(tx.Gas))))
+	}
 
-				commChan <- nil
+	return revenue
 
-			})
+}
 
-		}(txs[i])
+// sumFees - Sum of gas price x gas limit, of given tx(s), where
+// fee cap is considered as gas price of EIP-1559 tx(s)
+func sumFees(txs []*MemPoolTx) *big.Int {
 
+	total := big.NewInt(0)
+
+	for _, tx := range txs {
+		total.Add(total, big.NewInt(0).Mul(tx.EffectiveGasPrice(nil), big.NewInt(0).SetUint64(uint64(tx.Gas))))
 	}
 
-	var received uint64
-	mustReceive := txCount
+	return total
 
-	// Waiting for all go routines to finish
-	for v := range commChan {
+}
 
-		if v != nil {
-			result = append(result, v)
+// TotalPendingFees - Sum of gas price x gas limit, over all pending tx(s),
+// rough upper bound on fees extractable from pool
+func (p *PendingPool) TotalPendingFees(ctx context.Context) *big.Int {
+
+	txs := p.DescListTxs(ctx)
+	if txs == nil {
+		return big.NewInt(0)
+	}
+
+	total := sumFees(txs)
+
+	CleanSlice(txs)
+	return total
+
+}
+
+// EstimateBlockFees - Sum of gas price x gas limit, over tx(s) filling up
+// block of given gas limit, picked greedily by descending gas price, while
+// respecting nonce order of each sender, as per `SimulateNextBlock`
+func (p *PendingPool) EstimateBlockFees(ctx context.Context, gasLimit uint64) *big.Int {
+
+	return sumFees(p.SimulateNextBlock(ctx, gasLimit, nil))
+
+}
+
+// FindReplacement - Given txHash, attempts to find out tx living in pending pool,
+// which replaces it i.e. sent from same address, with same nonce, but paying
+// higher gas price, along with how much more ( in Wei ) it's paying
+//
+// @note If multiple such tx(s) are present, one paying highest gas price
+// is returned, because that's the one most likely to get mined
+func (p *PendingPool) FindReplacement(ctx context.Context, hash common.Hash) (*MemPoolTx, *big.Int) {
+
+	targetTx := p.Get(ctx, hash)
+	if targetTx == nil {
+		return nil, nil
+	}
+
+	txs := p.DuplicateTxs(ctx, hash)
+	if txs == nil {
+		return nil, nil
+	}
+
+	baseFee := p.BaseFee(ctx)
+	targetPrice := targetTx.EffectiveGasPrice(baseFee)
+
+	var (
+		replacement      *MemPoolTx
+		replacementPrice *big.Int
+	)
+
+	for _, tx := range txs {
+
+		price := tx.EffectiveGasPrice(baseFee)
+
+		if price.Cmp(targetPrice) <= 0 {
+			continue
 		}
 
-		received++
-		if received >= mustReceive {
-			break
+		if replacement == nil || price.Cmp(replacementPrice) > 0 {
+			replacement, replacementPrice = tx, price
 		}
 
 	}
 
-	// This call is irrelevant here, probably, but still being made
-	//
-	// Because all workers have exited, otherwise we could have never
-	// reached this point
-	wp.Stop()
 	CleanSlice(txs)
 
-	return result
+	if replacement == nil {
+		return nil, nil
+	}
+
+	return replacement, big.NewInt(0).Sub(replacementPrice, targetPrice)
 
 }
 
-// OlderThanX - Returns a list of all pending tx(s), which are
-// living in mempool for more than or equals to `X` time unit
-func (p *PendingPool) OlderThanX(x time.Duration) []*MemPoolTx {
+// TopSendersByCumulativeFee - Groups pending tx(s) by sender & returns top `n`
+// senders, as per cumulative fee offered by all of their tx(s), where ties are
+// broken by tx count
+func (p *PendingPool) TopSendersByCumulativeFee(ctx context.Context, n int) []SenderFee {
+
+	if n <= 0 {
+		return []SenderFee{}
+	}
 
-	txs := p.DescListTxs()
+	txs := p.DescListTxs(ctx)
 	if txs == nil {
-		return nil
+		return []SenderFee{}
 	}
 
-	txCount := uint64(len(txs))
-	commChan := make(chan *MemPoolTx, txCount)
-	result := make([]*MemPoolTx, 0, txCount)
+	baseFee := p.BaseFee(ctx)
+	bySender := make(map[common.Address]*SenderFee)
 
-	wp := workerpool.New(runtime.NumCPU())
+	for _, tx := range txs {
 
-	for i := 0; i < len(txs); i++ {
+		fee, ok := bySender[tx.From]
+		if !ok {
+			fee = &SenderFee{Address: tx.From, TotalFee: big.NewInt(0)}
+			bySender[tx.From] = fee
+		}
 
-		func(tx *MemPoolTx) {
+		fee.TotalFee.Add(fee.TotalFee, big.NewInt(0).Mul(tx.EffectiveGasPrice(baseFee), big.NewInt(0).SetUint64(uint64(tx.Gas))))
+		fee.TxCount++
 
-			wp.Submit(func() {
+	}
 
-				if tx.IsPendingForGTE(x) {
-					commChan <- tx
-					return
-				}
+	CleanSlice(txs)
 
-				commChan <- nil
+	fees := make([]SenderFee, 0, len(bySender))
+	for _, fee := range bySender {
+		fees = append(fees, *fee)
+	}
 
-			})
+	sort.Slice(fees, func(i, j int) bool {
 
-		}(txs[i])
+		if cmp := fees[i].TotalFee.Cmp(fees[j].TotalFee); cmp != 0 {
+			return cmp > 0
+		}
+
+		if fees[i].TxCount != fees[j].TxCount {
+			return fees[i].TxCount > fees[j].TxCount
+		}
+
+		return bytes.Compare(fees[i].Address.Bytes(), fees[j].Address.Bytes()) < 0
 
+	})
+
+	if n < len(fees) {
+		fees = fees[:n]
 	}
 
-	var received uint64
-	mustReceive := txCount
+	return fees
 
-	// Waiting for all go routines to finish
-	for v := range commChan {
+}
+
+// HeavilyReplaced - Current tx of each (sender, nonce) slot, which has been
+// replaced at least `minReplacements` times, while living in pending pool,
+// to catch chronic gas wars
+//
+// @note Replacement history of slot is forgotten, once no tx of it is left in
+// pool, returned tx(s) are in descending order of gas price
+func (p *PendingPool) HeavilyReplaced(ctx context.Context, minReplacements int) []*MemPoolTx {
+
+	respChan := make(chan []*MemPoolTx, 1)
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case p.HeavilyReplacedChan <- HeavilyReplacedRequest{Min: minReplacements, ResponseChan: respChan}:
+	}
+
+	var txs []*MemPoolTx
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case txs = <-respChan:
+	}
+
+	sort.Slice(txs, func(i, j int) bool {
+		return compareTxs(txs[i], txs[j], nil) > 0
+	})
+
+	return txs
+
+}
+
+// ReplacementPriceBump - Minimum gas price bump ( in percentage ), replacement
+// tx needs to pay over tx it replaces, as enforced by nodes by default
+const ReplacementPriceBump = 10
+
+// InvalidReplacements - Finds pending tx(s) which replaced some other pending
+// tx with same sender & nonce, seen earlier, without bumping gas price by at
+// least `ReplacementPriceBump` percent, which nodes don't accept. These are
+// likely artifacts of node, worth logging
+//
+// @note Tx(s) of same (sender, nonce) are ordered as per when they were seen,
+// each one is checked against highest gas price paid by ones seen before it
+func (p *PendingPool) InvalidReplacements(ctx context.Context) []*MemPoolTx {
+
+	txs := p.DescListTxs(ctx)
+	if txs == nil {
+		return []*MemPoolTx{}
+	}
+
+	chains := replacementChains(txs)
+	CleanSlice(txs)
+
+	invalid := make([]*MemPoolTx, 0)
+
+	for _, slot := range chains {
+
+		highest := bigOrZero(slot[0].GasPrice)
+
+		for _, tx := range slot[1:] {
+
+			gasPrice := bigOrZero(tx.GasPrice)
+
+			// Required gas price = highest * ( 100 + bump ) / 100
+			required := big.NewInt(0).Mul(highest, big.NewInt(100+ReplacementPriceBump))
+			required.Div(required, big.NewInt(100))
+
+			if gasPrice.Cmp(required) < 0 {
+				invalid = append(invalid, tx)
+			}
+
+			if gasPrice.Cmp(highest) > 0 {
+				highest = gasPrice
+			}
 
-		if v != nil {
-			result = append(result, v)
 		}
 
-		received++
-		if received >= mustReceive {
-			break
+	}
+
+	return invalid
+
+}
+
+// UnderfundedSenders - Best-effort check for senders whose total committed
+// outflow across pending tx(s) i.e. value along with max fee, exceeds their
+// current balance, returning their pending tx(s), ascending as per nonce
+//
+// Balances are looked up in single batch RPC call, one per sender. Senders
+// whose balance couldn't be looked up are skipped.
+func (p *PendingPool) UnderfundedSenders(ctx context.Context) map[common.Address][]*MemPoolTx {
+
+	result := make(map[common.Address][]*MemPoolTx)
+
+	txs := p.DescListTxs(ctx)
+	if txs == nil {
+		return result
+	}
+
+	outflows := make(map[common.Address]*big.Int)
+	bySender := make(map[common.Address][]*MemPoolTx)
+
+	for _, tx := range txs {
+
+		outflow, ok := outflows[tx.From]
+		if !ok {
+			outflow = big.NewInt(0)
+			outflows[tx.From] = outflow
 		}
 
+		outflow.Add(outflow, tx.MaxCost())
+		bySender[tx.From] = append(bySender[tx.From], tx)
+
 	}
 
-	// This call is irrelevant here, probably, but still being made
-	//
-	// Because all workers have exited, otherwise we could have never
-	// reached this point
-	wp.Stop()
 	CleanSlice(txs)
 
+	senders := make([]common.Address, 0, len(outflows))
+	balances := make([]hexutil.Big, len(outflows))
+	batch := make([]rpc.BatchElem, 0, len(outflows))
+
+	for addr := range outflows {
+
+		batch = append(batch, rpc.BatchElem{
+			Method: "eth_getBalance",
+			Args:   []interface{}{addr.Hex(), "latest"},
+			Result: &balances[len(senders)],
+		})
+		senders = append(senders, addr)
+
+	}
+
+	client, _ := p.Upstreams.Active()
+
+	if err := client.BatchCallContext(ctx, batch); err != nil {
+		log.Printf("[❗️] Failed to look up balance of %d sender(s) : %s\n", len(senders), err.Error())
+		return result
+	}
+
+	for i, addr := range senders {
+
+		if batch[i].Error != nil {
+			continue
+		}
+
+		if outflows[addr].Cmp(balances[i].ToInt()) <= 0 {
+			continue
+		}
+
+		affected := bySender[addr]
+		sort.Slice(affected, func(i, j int) bool {
+			return affected[i].Nonce < affected[j].Nonce
+		})
+
+		result[addr] = affected
+
+	}
+
 	return result
 
 }
 
-// FresherThanX - Returns a list of all pending tx(s), which are
-// living in mempool for less than or equals to `X` time unit
-func (p *PendingPool) FresherThanX(x time.Duration) []*MemPoolTx {
+// DuplicateTxs - Attempting to find duplicate tx(s) for given
+// txHash.
+//
+// @note In duplicate tx list, the tx which was provided as input
+// will not be included
+//
+// Considering one tx duplicate of given one, if this tx has same
+// nonce & sender address, as of given ones
+func (p *PendingPool) DuplicateTxs(ctx context.Context, hash common.Hash) []*MemPoolTx {
+
+	targetTx := p.Get(ctx, hash)
+	if targetTx == nil {
+		return nil
+	}
 
-	txs := p.DescListTxs()
+	txs := p.TxsFromA(ctx, targetTx.From)
 	if txs == nil {
 		return nil
 	}
@@ -926,7 +2064,13 @@ func (p *PendingPool) FresherThanX(x time.Duration) []*MemPoolTx {
 	commChan := make(chan *MemPoolTx, txCount)
 	result := make([]*MemPoolTx, 0, txCount)
 
-	wp := workerpool.New(runtime.NumCPU())
+	var wp *workerpool.WorkerPool
+
+	if txCount > uint64(runtime.NumCPU()) {
+		wp = workerpool.New(runtime.NumCPU())
+	} else {
+		wp = workerpool.New(int(txCount))
+	}
 
 	for i := 0; i < len(txs); i++ {
 
@@ -934,7 +2078,7 @@ func (p *PendingPool) FresherThanX(x time.Duration) []*MemPoolTx {
 
 			wp.Submit(func() {
 
-				if tx.IsPendingForLTE(x) {
+				if tx.IsDuplicateOf(targetTx) {
 					commChan <- tx
 					return
 				}
@@ -948,9 +2092,7 @@ func (p *PendingPool) FresherThanX(x time.Duration) []*MemPoolTx {
 	}
 
 	var received uint64
-	mustReceive := txCount
 
-	// Waiting for all go routines to finish
 	for v := range commChan {
 
 		if v != nil {
@@ -958,7 +2100,7 @@ func (p *PendingPool) FresherThanX(x time.Duration) []*MemPoolTx {
 		}
 
 		received++
-		if received >= mustReceive {
+		if received >= txCount {
 			break
 		}
 
@@ -975,83 +2117,1210 @@ func (p *PendingPool) FresherThanX(x time.Duration) []*MemPoolTx {
 
 }
 
-// HigherThanX - Returns a list of pending txs which are paid with
-// gas price >= `X`
-func (p *PendingPool) HigherThanX(x float64) []*MemPoolTx {
-	txs := p.DescListTxs()
-	if txs == nil {
-		return nil
-	}
+// AscListTxs - Returns all tx(s) present in pending pool, as slice, ascending ordered as per gas price paid
+func (p *PendingPool) AscListTxs(ctx context.Context) []*MemPoolTx {
 
-	txCount := uint64(len(txs))
-	result := make([]*MemPoolTx, 0, txCount)
+	p.owner.enter()
 
-	for i := 0; i < len(txs); i++ {
-		// Stop ASAP, because iterating over
-		// descending sorted ( w.r.t. gas price )
-		// tx list
-		if !txs[i].HasGasPriceMoreThan(x) {
-			break
-		}
+	respChan := make(chan []*MemPoolTx, 1)
 
-		result = append(result, txs[i])
+	select {
+	case <-ctx.Done():
+		return nil
+	case p.ListTxsChan <- ListRequest{ResponseChan: respChan, Order: ASC}:
 	}
 
-	CleanSlice(txs)
-	return result
-}
-
-// LowerThanX - Returns a list of pending txs which are paid with
-// gas price <= `X`
-func (p *PendingPool) LowerThanX(x float64) []*MemPoolTx {
-	txs := p.AscListTxs()
-	if txs == nil {
+	select {
+	case <-ctx.Done():
 		return nil
+	case v := <-respChan:
+		return v
 	}
 
-	txCount := uint64(len(txs))
-	result := make([]*MemPoolTx, 0, txCount)
+}
 
-	for i := 0; i < len(txs); i++ {
-		// Stop ASAP, because iterating over
-		// ascending sorted ( w.r.t. gas price )
-		// tx list
-		if !txs[i].HasGasPriceLessThan(x) {
-			break
-		}
+// DescListTxs - Returns all tx(s) present in pending pool, as slice, descending ordered as per gas price paid
+func (p *PendingPool) DescListTxs(ctx context.Context) []*MemPoolTx {
 
-		result = append(result, txs[i])
+	p.owner.enter()
+
+	respChan := make(chan []*MemPoolTx, 1)
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case p.ListTxsChan <- ListRequest{ResponseChan: respChan, Order: DESC}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case v := <-respChan:
+		return v
 	}
 
-	CleanSlice(txs)
-	return result
 }
 
-// Add - Attempts to add new tx found in pending pool into
-// harmony mempool, so that further manipulation can be performed on it
-//
-// If it returns `true`, it denotes, it's success, otherwise it's failure
-// because this tx is already present in pending pool
-func (p *PendingPool) Add(ctx context.Context, tx *MemPoolTx) bool {
+// SortedListTxs - Returns all tx(s) present in pending pool, as slice, ordered as per
+// given multi-key sort specification i.e. `gasPrice desc, nonce asc`
+func (p *PendingPool) SortedListTxs(ctx context.Context, spec string) ([]*MemPoolTx, error) {
 
-	respChan := make(chan bool)
+	keys, err := ParseSortSpec(spec)
+	if err != nil {
+		return nil, err
+	}
 
-	p.AddTxChan <- AddRequest{Tx: tx, ResponseChan: respChan}
+	txs := p.AscListTxs(ctx)
+	SortTxs(txs, keys, p.BaseFee(ctx))
 
-	return <-respChan
+	return txs, nil
 
 }
 
-// AddUnstuck - When attempting to add new tx from queued pool to here
-// it's supposed to be invoked so that queued pool doesn't receive notification
+// listWindow - Returns window of tx(s) present in pending pool, ordered as per gas price
+// paid, starting at `offset` & spanning at max `limit` entries ( 0 denotes no cap )
+func (p *PendingPool) listWindow(ctx context.Context, order int, offset uint64, limit uint64) []*MemPoolTx {
+
+	respChan := make(chan []*MemPoolTx, 1)
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case p.ListTxsChan <- ListRequest{ResponseChan: respChan, Order: order, Offset: offset, Limit: limit}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case v := <-respChan:
+		return v
+	}
+
+}
+
+// ListTxsPaged - Returns one page of tx(s) present in pending pool, ordered as per
+// gas price paid, starting at `offset` & spanning at max `limit` entries, where
+// 0 `limit` denotes no cap. Only requested window is copied out of pool
+//
+// @note Negative/ out of range `offset` results in empty slice
+func (p *PendingPool) ListTxsPaged(ctx context.Context, order int, offset int, limit int) []*MemPoolTx {
+
+	if offset < 0 || limit < 0 {
+		return []*MemPoolTx{}
+	}
+
+	txs := p.listWindow(ctx, order, uint64(offset), uint64(limit))
+	if txs == nil {
+		return []*MemPoolTx{}
+	}
+
+	return txs
+
+}
+
+// StreamTopX - Streams top `X` tx(s) present in pending pool, ordered as per gas price
+// paid, while only copying small chunks out of pool at a time, so that memory usage
+// stays bounded, irrespective of `X`
+func (p *PendingPool) StreamTopX(ctx context.Context, order int, x uint64) <-chan *MemPoolTx {
+
+	return StreamTxs(ctx, x, func(ctx context.Context, offset uint64, limit uint64) []*MemPoolTx {
+		return p.listWindow(ctx, order, offset, limit)
+	})
+
+}
+
+// TxsFromA - Returns a slice of txs, where all of those are sent
+// by address `A`
+func (p *PendingPool) TxsFromA(ctx context.Context, addr common.Address) []*MemPoolTx {
+
+	p.owner.enter()
+
+	respChan := make(chan []*MemPoolTx, 1)
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case p.TxsFromAChan <- TxsFromARequest{ResponseChan: respChan, From: addr}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case v := <-respChan:
+		return v
+	}
+
+}
+
+// TopXWithHighGasPrice - Returns only top `X` tx(s) present in pending mempool,
+// where being top is determined by how much gas price paid by tx sender
+func (p *PendingPool) TopXWithHighGasPrice(ctx context.Context, x uint64) []*MemPoolTx {
+
+	// Window with 0 limit isn't capped, so asking for
+	// none needs to be answered here
+	if x == 0 {
+		return []*MemPoolTx{}
+	}
+
+	// Only top `X` to be copied out of pool, clamped
+	// to what's actually available
+	txs := p.listWindow(ctx, DESC, 0, x)
+	if txs == nil {
+		return []*MemPoolTx{}
+	}
+
+	return txs
+
+}
+
+// TopXWithLowGasPrice - Returns only top `X` tx(s) present in pending mempool,
+// where being top is determined by how low gas price paid by tx sender
+func (p *PendingPool) TopXWithLowGasPrice(ctx context.Context, x uint64) []*MemPoolTx {
+
+	// Window with 0 limit isn't capped, so asking for
+	// none needs to be answered here
+	if x == 0 {
+		return []*MemPoolTx{}
+	}
+
+	// Only top `X` to be copied out of pool, clamped
+	// to what's actually available
+	txs := p.listWindow(ctx, ASC, 0, x)
+	if txs == nil {
+		return []*MemPoolTx{}
+	}
+
+	return txs
+
+}
+
+// SentFrom - Returns a list of pending tx(s) sent from
+// specified address
+func (p *PendingPool) SentFrom(ctx context.Context, address common.Address) []*MemPoolTx {
+	return p.TxsFromA(ctx, address)
+}
+
+// SentFromInNonceRange - Returns pending tx(s) sent from specified address,
+// with nonce in `[lo, hi]`, sorted in ascending order of nonce
+func (p *PendingPool) SentFromInNonceRange(ctx context.Context, address common.Address, lo, hi uint64) []*MemPoolTx {
+
+	txs := p.SentFrom(ctx, address)
+	result := make([]*MemPoolTx, 0, len(txs))
+
+	for _, tx := range txs {
+
+		if nonce := uint64(tx.Nonce); nonce >= lo && nonce <= hi {
+			result = append(result, tx)
+		}
+
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Nonce < result[j].Nonce
+	})
+
+	return result
+
+}
+
+// SentTo - Returns a list of pending tx(s) sent to
+// specified address
+func (p *PendingPool) SentTo(ctx context.Context, address common.Address) []*MemPoolTx {
+
+	txs := p.DescListTxs(ctx)
+	if txs == nil {
+		return nil
+	}
+
+	txCount := uint64(len(txs))
+	commChan := make(chan *MemPoolTx, txCount)
+	result := make([]*MemPoolTx, 0, txCount)
+
+	wp := workerpool.New(runtime.NumCPU())
+
+	for i := 0; i < len(txs); i++ {
+
+		func(tx *MemPoolTx) {
+
+			wp.Submit(func() {
+
+				if tx.IsSentTo(address) {
+					commChan <- tx
+					return
+				}
+
+				commChan <- nil
+
+			})
+
+		}(txs[i])
+
+	}
+
+	var received uint64
+	mustReceive := txCount
+
+	// Waiting for all go routines to finish
+	for v := range commChan {
+
+		if v != nil {
+			result = append(result, v)
+		}
+
+		received++
+		if received >= mustReceive {
+			break
+		}
+
+	}
+
+	// This call is irrelevant here, probably, but still being made
+	//
+	// Because all workers have exited, otherwise we could have never
+	// reached this point
+	wp.Stop()
+	CleanSlice(txs)
+
+	return result
+
+}
+
+// OldestPerSender - Returns oldest tx of each sender, having tx(s)
+// living in pending pool, computed using sender index
+func (p *PendingPool) OldestPerSender(ctx context.Context) map[common.Address]*MemPoolTx {
+
+	respChan := make(chan map[common.Address]*MemPoolTx, 1)
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case p.OldestPerSenderChan <- respChan:
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case v := <-respChan:
+		return v
+	}
+
+}
+
+// MedianWaitTime - Median of how long tx(s) currently living in pending pool
+// have been waiting there, zero if pool is empty
+func (p *PendingPool) MedianWaitTime(ctx context.Context) time.Duration {
+
+	txs := p.DescListTxs(ctx)
+	if txs == nil {
+		return 0
+	}
+
+	now := time.Now().UTC()
+	waits := make([]time.Duration, 0, len(txs))
+
+	for _, tx := range txs {
+		waits = append(waits, now.Sub(tx.PendingFrom))
+	}
+
+	CleanSlice(txs)
+	return MedianDuration(waits)
+
+}
+
+// SizeHistogram - Distribution of approximate serialized size of tx(s) living
+// in pending pool, where each of `buckets` is inclusive upper bound, in bytes.
+// Tx is counted in smallest bucket it fits in, while ones larger than all
+// buckets are counted against `-1`
+//
+// @note Buckets don't need to be sorted, every one of them is present
+// in returned map, even if no tx falls in it
+func (p *PendingPool) SizeHistogram(ctx context.Context, buckets []int) map[int]uint64 {
+
+	bounds := make([]int, len(buckets))
+	copy(bounds, buckets)
+	sort.Ints(bounds)
+
+	histogram := make(map[int]uint64, len(bounds)+1)
+	for _, bound := range bounds {
+		histogram[bound] = 0
+	}
+
+	txs := p.DescListTxs(ctx)
+	if txs == nil {
+		return histogram
+	}
+
+	for _, tx := range txs {
+
+		size := tx.ApproxSize()
+
+		idx := sort.SearchInts(bounds, size)
+		if idx == len(bounds) {
+			histogram[-1]++
+			continue
+		}
+
+		histogram[bounds[idx]]++
+
+	}
+
+	CleanSlice(txs)
+	return histogram
+
+}
+
+// scan - Concurrently goes over all pending tx(s), returning those
+// for which `pred` holds
+func (p *PendingPool) scan(ctx context.Context, pred func(*MemPoolTx) bool) []*MemPoolTx {
+
+	txs := p.DescListTxs(ctx)
+	if txs == nil {
+		return []*MemPoolTx{}
+	}
+
+	txCount := uint64(len(txs))
+	commChan := make(chan *MemPoolTx, txCount)
+	result := make([]*MemPoolTx, 0, txCount)
+
+	wp := workerpool.New(runtime.NumCPU())
+
+	for i := 0; i < len(txs); i++ {
+
+		func(tx *MemPoolTx) {
+
+			wp.Submit(func() {
+
+				if pred(tx) {
+					commChan <- tx
+					return
+				}
+
+				commChan <- nil
+
+			})
+
+		}(txs[i])
+
+	}
+
+	var received uint64
+	mustReceive := txCount
+
+	// Waiting for all go routines to finish
+	for v := range commChan {
+
+		if v != nil {
+			result = append(result, v)
+		}
+
+		received++
+		if received >= mustReceive {
+			break
+		}
+
+	}
+
+	wp.Stop()
+	CleanSlice(txs)
+
+	return result
+
+}
+
+// TxsByValueRange - Returns a list of pending tx(s), transferring value
+// within [`min`, `max`] ( in Wei ), nil bound denoting unbounded side
+func (p *PendingPool) TxsByValueRange(ctx context.Context, min *big.Int, max *big.Int) []*MemPoolTx {
+
+	return p.scan(ctx, func(tx *MemPoolTx) bool {
+		return tx.HasValueWithin(min, max)
+	})
+
+}
+
+// AddedBetween - Returns a list of pending tx(s), which were added into
+// pool within [`start`, `end`) time window
+func (p *PendingPool) AddedBetween(ctx context.Context, start time.Time, end time.Time) []*MemPoolTx {
+
+	return p.scan(ctx, func(tx *MemPoolTx) bool {
+		return !tx.PendingFrom.Before(start) && tx.PendingFrom.Before(end)
+	})
+
+}
+
+// Since - Returns pending tx(s) which entered pool strictly after `cursor`,
+// in ascending order of entry time, along with new cursor to be passed in
+// next call, so that client can keep polling without pulling full dump
+//
+// @note If nothing new found, same cursor is returned back
+func (p *PendingPool) Since(ctx context.Context, cursor time.Time) ([]*MemPoolTx, time.Time) {
+
+	txs := p.scan(ctx, func(tx *MemPoolTx) bool {
+		return tx.PendingFrom.After(cursor)
+	})
+
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].PendingFrom.Before(txs[j].PendingFrom)
+	})
+
+	if len(txs) == 0 {
+		return txs, cursor
+	}
+
+	return txs, txs[len(txs)-1].PendingFrom
+
+}
+
+// Match - Returns a list of pending tx(s), sent from `from`, to `to`, invoking
+// method identified by `selector`, where nil ones are considered to be wildcard
+//
+// All constraints are checked in single pass over pool
+func (p *PendingPool) Match(ctx context.Context, from *common.Address, to *common.Address, selector *[4]byte) []*MemPoolTx {
+
+	txs := p.DescListTxs(ctx)
+	if txs == nil {
+		return nil
+	}
+
+	txCount := uint64(len(txs))
+	commChan := make(chan *MemPoolTx, txCount)
+	result := make([]*MemPoolTx, 0, txCount)
+
+	wp := workerpool.New(runtime.NumCPU())
+
+	for i := 0; i < len(txs); i++ {
+
+		func(tx *MemPoolTx) {
+
+			wp.Submit(func() {
+
+				if tx.Matches(from, to, selector) {
+					commChan <- tx
+					return
+				}
+
+				commChan <- nil
+
+			})
+
+		}(txs[i])
+
+	}
+
+	var received uint64
+	mustReceive := txCount
+
+	// Waiting for all go routines to finish
+	for v := range commChan {
+
+		if v != nil {
+			result = append(result, v)
+		}
+
+		received++
+		if received >= mustReceive {
+			break
+		}
+
+	}
+
+	wp.Stop()
+	CleanSlice(txs)
+
+	return result
+
+}
+
+// DetectSandwichCandidates - Groups pending tx(s) by contract they're sent to
+// & looks for victim tx placed between two tx(s) of some other sender, in
+// descending gas price ordering, where front running one pays more than victim
+//
+// It's heuristic, meant for MEV research, not proof of sandwich attack.
+//
+// @note Contract creation tx(s) are not considered
+func (p *PendingPool) DetectSandwichCandidates(ctx context.Context) []SandwichGroup {
+
+	txs := p.DescListTxs(ctx)
+	if txs == nil {
+		return []SandwichGroup{}
+	}
+
+	// Tx(s) are appended in descending gas price order, so
+	// each group stays ordered same way
+	byContract := make(map[common.Address][]*MemPoolTx)
+
+	for _, tx := range txs {
+
+		if tx.To == nil {
+			continue
+		}
+
+		byContract[*tx.To] = append(byContract[*tx.To], tx)
+
+	}
+
+	CleanSlice(txs)
+
+	baseFee := p.BaseFee(ctx)
+	groups := make([]SandwichGroup, 0)
+
+	for contract, ordered := range byContract {
+
+		for i := 0; i+2 < len(ordered); i++ {
+
+			front, victim, back := ordered[i], ordered[i+1], ordered[i+2]
+
+			if front.From != back.From || front.From == victim.From {
+				continue
+			}
+
+			if front.EffectiveGasPrice(baseFee).Cmp(victim.EffectiveGasPrice(baseFee)) <= 0 {
+				continue
+			}
+
+			groups = append(groups, SandwichGroup{
+				Contract: contract,
+				FrontRun: front,
+				Victim:   victim,
+				BackRun:  back,
+			})
+
+		}
+
+	}
+
+	// Map iteration order is random, so groups are ordered
+	// by contract, keeping result stable across calls
+	sort.SliceStable(groups, func(i, j int) bool {
+		return bytes.Compare(groups[i].Contract.Bytes(), groups[j].Contract.Bytes()) < 0
+	})
+
+	return groups
+
+}
+
+// FeeBumps - Pairs of pending tx(s) sharing sender & nonce, but differing in
+// gas price, where each tx is paired with one of same slot, which entered pool
+// right before it, along with bump in gas price, as percentage of older one
+//
+// @note Pairs are ordered by sender, then nonce, then entry time of newer tx
+func (p *PendingPool) FeeBumps(ctx context.Context) []FeeBumpPair {
+
+	txs := p.DescListTxs(ctx)
+	if txs == nil {
+		return []FeeBumpPair{}
+	}
+
+	chains := replacementChains(txs)
+	CleanSlice(txs)
+
+	pairs := make([]FeeBumpPair, 0)
+
+	for _, slot := range chains {
+
+		for i := 1; i < len(slot); i++ {
+
+			prev, next := slot[i-1], slot[i]
+			if !next.IsDuplicateOf(prev) {
+				continue
+			}
+
+			prevPrice, nextPrice := prev.EffectiveGasPrice(nil), next.EffectiveGasPrice(nil)
+			if prevPrice.Cmp(nextPrice) == 0 || prevPrice.Sign() == 0 {
+				continue
+			}
+
+			bump, _ := big.NewFloat(0).Quo(
+				big.NewFloat(0).SetInt(big.NewInt(0).Mul(big.NewInt(0).Sub(nextPrice, prevPrice), big.NewInt(100))),
+				big.NewFloat(0).SetInt(prevPrice)).Float64()
+
+			pairs = append(pairs, FeeBumpPair{Old: prev, New: next, BumpPercent: bump})
+
+		}
+
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+
+		if cmp := bytes.Compare(pairs[i].New.From.Bytes(), pairs[j].New.From.Bytes()); cmp != 0 {
+			return cmp < 0
+		}
+
+		if pairs[i].New.Nonce != pairs[j].New.Nonce {
+			return pairs[i].New.Nonce < pairs[j].New.Nonce
+		}
+
+		return pairs[i].New.PendingFrom.Before(pairs[j].New.PendingFrom)
+
+	})
+
+	return pairs
+
+}
+
+// MatchingSnapshot - Returns pending tx(s) satisfying given filter, in
+// descending order of gas price, so that subscriber can start off with
+// current matching state & keep applying same filter on streamed events
+//
+// @note Nil filter matches all tx(s)
+func (p *PendingPool) MatchingSnapshot(ctx context.Context, filter Filter) []*MemPoolTx {
+
+	txs := p.DescListTxs(ctx)
+	if txs == nil {
+		return []*MemPoolTx{}
+	}
+
+	result := make([]*MemPoolTx, 0, len(txs))
+
+	for _, tx := range txs {
+
+		if filter == nil || filter(tx) {
+			result = append(result, tx)
+		}
+
+	}
+
+	CleanSlice(txs)
+	return result
+
+}
+
+// TxsByType - Returns a list of pending tx(s) of given EIP-2718 type i.e.
+// 0 for legacy, 1 for access list & 2 for dynamic fee tx
+func (p *PendingPool) TxsByType(ctx context.Context, t uint8) []*MemPoolTx {
+
+	return p.scan(ctx, func(tx *MemPoolTx) bool {
+		return tx.IsOfType(t)
+	})
+
+}
+
+// OlderThanX - Returns a list of all pending tx(s), which are
+// living in mempool for more than or equals to `X` time unit
+func (p *PendingPool) OlderThanX(ctx context.Context, x time.Duration) []*MemPoolTx {
+
+	txs := p.DescListTxs(ctx)
+	if txs == nil {
+		return nil
+	}
+
+	txCount := uint64(len(txs))
+	commChan := make(chan *MemPoolTx, txCount)
+	result := make([]*MemPoolTx, 0, txCount)
+
+	wp := workerpool.New(runtime.NumCPU())
+
+	for i := 0; i < len(txs); i++ {
+
+		func(tx *MemPoolTx) {
+
+			wp.Submit(func() {
+
+				if tx.IsPendingForGTE(x) {
+					commChan <- tx
+					return
+				}
+
+				commChan <- nil
+
+			})
+
+		}(txs[i])
+
+	}
+
+	var received uint64
+	mustReceive := txCount
+
+	// Waiting for all go routines to finish
+	for v := range commChan {
+
+		if v != nil {
+			result = append(result, v)
+		}
+
+		received++
+		if received >= mustReceive {
+			break
+		}
+
+	}
+
+	// This call is irrelevant here, probably, but still being made
+	//
+	// Because all workers have exited, otherwise we could have never
+	// reached this point
+	wp.Stop()
+	CleanSlice(txs)
+
+	return result
+
+}
+
+// FresherThanX - Returns a list of all pending tx(s), which are
+// living in mempool for less than or equals to `X` time unit
+func (p *PendingPool) FresherThanX(ctx context.Context, x time.Duration) []*MemPoolTx {
+
+	txs := p.DescListTxs(ctx)
+	if txs == nil {
+		return nil
+	}
+
+	txCount := uint64(len(txs))
+	commChan := make(chan *MemPoolTx, txCount)
+	result := make([]*MemPoolTx, 0, txCount)
+
+	wp := workerpool.New(runtime.NumCPU())
+
+	for i := 0; i < len(txs); i++ {
+
+		func(tx *MemPoolTx) {
+
+			wp.Submit(func() {
+
+				if tx.IsPendingForLTE(x) {
+					commChan <- tx
+					return
+				}
+
+				commChan <- nil
+
+			})
+
+		}(txs[i])
+
+	}
+
+	var received uint64
+	mustReceive := txCount
+
+	// Waiting for all go routines to finish
+	for v := range commChan {
+
+		if v != nil {
+			result = append(result, v)
+		}
+
+		received++
+		if received >= mustReceive {
+			break
+		}
+
+	}
+
+	// This call is irrelevant here, probably, but still being made
+	//
+	// Because all workers have exited, otherwise we could have never
+	// reached this point
+	wp.Stop()
+	CleanSlice(txs)
+
+	return result
+
+}
+
+// HigherThanX - Returns a list of pending txs which are paid with
+// gas price >= `X`
+func (p *PendingPool) HigherThanX(ctx context.Context, x float64) []*MemPoolTx {
+	txs := p.DescListTxs(ctx)
+	if txs == nil {
+		return nil
+	}
+
+	baseFee := p.BaseFee(ctx)
+	txCount := uint64(len(txs))
+	result := make([]*MemPoolTx, 0, txCount)
+
+	for i := 0; i < len(txs); i++ {
+		// Stop ASAP, because iterating over
+		// descending sorted ( w.r.t. gas price )
+		// tx list
+		if !txs[i].HasGasPriceMoreThan(x, baseFee) {
+			break
+		}
+
+		result = append(result, txs[i])
+	}
+
+	CleanSlice(txs)
+	return result
+}
+
+// LowerThanX - Returns a list of pending txs which are paid with
+// gas price <= `X`
+func (p *PendingPool) LowerThanX(ctx context.Context, x float64) []*MemPoolTx {
+	txs := p.AscListTxs(ctx)
+	if txs == nil {
+		return nil
+	}
+
+	baseFee := p.BaseFee(ctx)
+	txCount := uint64(len(txs))
+	result := make([]*MemPoolTx, 0, txCount)
+
+	for i := 0; i < len(txs); i++ {
+		// Stop ASAP, because iterating over
+		// ascending sorted ( w.r.t. gas price )
+		// tx list
+		if !txs[i].HasGasPriceLessThan(x, baseFee) {
+			break
+		}
+
+		result = append(result, txs[i])
+	}
+
+	CleanSlice(txs)
+	return result
+}
+
+// EscalatedAbove - Returns a list of pending txs, whose (sender, nonce) slot was first
+// seen paying gas price below `threshold`, but now pays >= `threshold`, because
+// of replacement tx(s) i.e. fee escalations into priority range
+func (p *PendingPool) EscalatedAbove(ctx context.Context, threshold *big.Int) []*MemPoolTx {
+	txs := p.DescListTxs(ctx)
+	if txs == nil {
+		return nil
+	}
+
+	baseFee := p.BaseFee(ctx)
+	result := make([]*MemPoolTx, 0, len(txs))
+
+	for i := 0; i < len(txs); i++ {
+		// Stop ASAP, because iterating over
+		// descending sorted ( w.r.t. effective gas price )
+		// tx list
+		if txs[i].EffectiveGasPrice(baseFee).Cmp(threshold) < 0 {
+			break
+		}
+
+		if txs[i].InitialGasPrice == nil {
+			continue
+		}
+
+		if bigOrZero(txs[i].InitialGasPrice).Cmp(threshold) < 0 {
+			result = append(result, txs[i])
+		}
+	}
+
+	CleanSlice(txs)
+	return result
+}
+
+// GasPricePercentile - `pct`-th percentile of effective gas price, paid by
+// tx(s) living in pending pool, under latest known base fee
+//
+// @note Returns nil, if pool is empty or `pct` is not within [0, 100]
+func (p *PendingPool) GasPricePercentile(ctx context.Context, pct float64) *big.Int {
+
+	if !(pct >= 0 && pct <= 100) {
+		return nil
+	}
+
+	respChan := make(chan *big.Int, 1)
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case p.GasPricePercentileChan <- PercentileRequest{P: pct, ResponseChan: respChan}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case v := <-respChan:
+		return v
+	}
+
+}
+
+// GasPricePercentileGwei - Same as `GasPricePercentile`, but in Gwei,
+// rounded to `precision` digits after decimal point
+//
+// @note Returns 0, if pool is empty or `pct` is not within [0, 100]
+func (p *PendingPool) GasPricePercentileGwei(ctx context.Context, pct float64, precision int) float64 {
+
+	return WeiToGwei(p.GasPricePercentile(ctx, pct), precision)
+
+}
+
+// MedianGasPrice - Median of effective gas price, paid by tx(s) living
+// in pending pool
+func (p *PendingPool) MedianGasPrice(ctx context.Context) *big.Int {
+
+	return p.GasPricePercentile(ctx, 50)
+
+}
+
+// IncludableGasPricePercentiles - Percentiles of effective gas price, paid by
+// tx(s) living in pending pool, which can be included in next block i.e. fee cap
+// is at least latest known base fee, one for each of `pcts`
+//
+// @note Returns nil, if no such tx or any of `pcts` is not within [0, 100]
+func (p *PendingPool) IncludableGasPricePercentiles(ctx context.Context, pcts ...float64) []*big.Int {
+
+	for _, pct := range pcts {
+
+		if !(pct >= 0 && pct <= 100) {
+			return nil
+		}
+
+	}
+
+	respChan := make(chan []*big.Int, 1)
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case p.IncludablePercentileChan <- PercentilesRequest{Ps: pcts, ResponseChan: respChan}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case v := <-respChan:
+		return v
+	}
+
+}
+
+// IncludableGasPricePercentilesGwei - Same as `IncludableGasPricePercentiles`,
+// but in Gwei, rounded to `precision` digits after decimal point
+//
+// @note Returns nil, if no such tx or any of `pcts` is not within [0, 100]
+func (p *PendingPool) IncludableGasPricePercentilesGwei(ctx context.Context, precision int, pcts ...float64) []float64 {
+
+	prices := p.IncludableGasPricePercentiles(ctx, pcts...)
+	if prices == nil {
+		return nil
+	}
+
+	result := make([]float64, 0, len(prices))
+	for _, price := range prices {
+		result = append(result, WeiToGwei(price, precision))
+	}
+
+	return result
+
+}
+
+const (
+	// SlowPercentile - Percentile of includable tx(s) gas price, recommended
+	// for tx(s) which can wait
+	SlowPercentile = 30
+	// StandardPercentile - Percentile of includable tx(s) gas price,
+	// recommended for tx(s) to be mined as usual
+	StandardPercentile = 60
+	// FastPercentile - Percentile of includable tx(s) gas price, recommended
+	// for tx(s) to be mined soon
+	FastPercentile = 90
+	// MinTxsForRecommendation - At least these many includable tx(s) need to
+	// be in pending pool, for recommending gas price
+	MinTxsForRecommendation = 10
+)
+
+// RecommendGasPrice - Slow/ standard/ fast effective gas price tiers, being
+// `SlowPercentile`, `StandardPercentile` & `FastPercentile` -th percentiles of
+// effective gas price paid by pending tx(s), which can be included under
+// latest known base fee
+//
+// @note If there're less than `MinTxsForRecommendation` such tx(s), tiers
+// are zero & it's marked to be lacking data
+func (p *PendingPool) RecommendGasPrice(ctx context.Context) GasRecommendation {
+
+	respChan := make(chan GasRecommendation, 1)
+
+	select {
+	case <-ctx.Done():
+		return GasRecommendation{}
+	case p.GasRecommendationChan <- respChan:
+	}
+
+	select {
+	case <-ctx.Done():
+		return GasRecommendation{}
+	case v := <-respChan:
+		return v
+	}
+
+}
+
+// DisplacedBy - When pending pool is at capacity, tx which would be dropped
+// for making room for candidate tx paying `gasPrice`, to be used for fee advice
+// before submitting tx
+//
+// @note Returns nil, if pool has room for candidate or candidate doesn't
+// outbid lowest paying tx in pool or no `gasPrice` is given
+func (p *PendingPool) DisplacedBy(ctx context.Context, gasPrice *big.Int) *MemPoolTx {
+
+	// Nothing can be outbid, without paying anything
+	if gasPrice == nil {
+		return nil
+	}
+
+	p.owner.enter()
+
+	respChan := make(chan *MemPoolTx, 1)
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case p.DisplacedByChan <- DisplacedByRequest{GasPrice: gasPrice, ResponseChan: respChan}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case v := <-respChan:
+		return v
+	}
+
+}
+
+// TxsByGasPriceRange - Returns a list of pending txs which are paid with
+// gas price within [`min`, `max`] ( in Gwei ), where nil denotes unbounded
+func (p *PendingPool) TxsByGasPriceRange(ctx context.Context, min *big.Int, max *big.Int) []*MemPoolTx {
+
+	respChan := make(chan []*MemPoolTx, 1)
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case p.TxsByGasPriceRangeChan <- GasPriceRangeRequest{Min: GweiToWei(min), Max: GweiToWei(max), ResponseChan: respChan}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case v := <-respChan:
+		return v
+	}
+
+}
+
+// MaxBandCount - When splitting gas price range into bands, at max
+// these many of them can be created, so that too narrow band width doesn't
+// end up eating lots of memory
+const MaxBandCount = 4096
+
+// SparseBands - Splits effective gas price range of pending pool into bands of given
+// width, starting from lowest gas price, & returns those bands which have below average
+// occupancy i.e. where few tx(s) sit
+//
+// @note Returns nil, if pool is empty/ bad band width/ too many bands required
+func (p *PendingPool) SparseBands(ctx context.Context, bandWidth *big.Int) []Band {
+	if bandWidth == nil || bandWidth.Sign() <= 0 {
+		return nil
+	}
+
+	txs := p.AscListTxs(ctx)
+	if txs == nil {
+		return nil
+	}
+
+	// Effective gas prices, same as what list is ordered by, but
+	// lowest & highest are still picked explicitly, so that each tx
+	// falls in some band, even if base fee changed since listing
+	baseFee := p.BaseFee(ctx)
+	prices := make([]*big.Int, len(txs))
+
+	low, high := txs[0].EffectiveGasPrice(baseFee), txs[0].EffectiveGasPrice(baseFee)
+	for i := 0; i < len(txs); i++ {
+
+		prices[i] = txs[i].EffectiveGasPrice(baseFee)
+
+		if prices[i].Cmp(low) < 0 {
+			low = prices[i]
+		}
+
+		if prices[i].Cmp(high) > 0 {
+			high = prices[i]
+		}
+
+	}
+
+	span := big.NewInt(0).Sub(high, low)
+
+	bandCount := big.NewInt(0).Div(span, bandWidth)
+	bandCount.Add(bandCount, big.NewInt(1))
+	if bandCount.Cmp(big.NewInt(MaxBandCount)) > 0 {
+		CleanSlice(txs)
+		return nil
+	}
+
+	bands := make([]Band, bandCount.Int64())
+	for i := 0; i < len(bands); i++ {
+		bands[i].Low = big.NewInt(0).Add(low, big.NewInt(0).Mul(bandWidth, big.NewInt(int64(i))))
+		bands[i].High = big.NewInt(0).Add(bands[i].Low, bandWidth)
+	}
+
+	for i := 0; i < len(prices); i++ {
+		idx := big.NewInt(0).Sub(prices[i], low)
+		idx.Div(idx, bandWidth)
+
+		bands[idx.Int64()].Count++
+	}
+
+	// Below average occupancy is what we're interested in
+	//
+	// Comparing `count * #-of bands < #-of txs`, to avoid
+	// floating point arithmetic
+	result := make([]Band, 0, len(bands))
+	for i := 0; i < len(bands); i++ {
+		if bands[i].Count*uint64(len(bands)) < uint64(len(txs)) {
+			result = append(result, bands[i])
+		}
+	}
+
+	CleanSlice(txs)
+	return result
+}
+
+// Add - Attempts to add new tx found in pending pool into
+// harmony mempool, so that further manipulation can be performed on it
+//
+// If it returns `true`, it denotes, it's success, otherwise it's failure
+// because this tx is already present in pending pool
+func (p *PendingPool) Add(ctx context.Context, tx *MemPoolTx) bool {
+
+	p.owner.enter()
+
+	respChan := make(chan bool, 1)
+
+	select {
+	case <-ctx.Done():
+		return false
+	case p.AddTxChan <- AddRequest{Tx: tx, ResponseChan: respChan}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case v := <-respChan:
+		return v
+	}
+
+}
+
+// AddUnstuck - When attempting to add new tx from queued pool to here
+// it's supposed to be invoked so that queued pool doesn't receive notification
 // back to self for so
 func (p *PendingPool) AddUnstuck(ctx context.Context, tx *MemPoolTx) bool {
 
-	respChan := make(chan bool)
+	respChan := make(chan bool, 1)
 
-	p.AddFromQueuedPoolChan <- AddRequest{Tx: tx, ResponseChan: respChan}
+	// Pool stops serving requests once shut down, so
+	// don't wait forever for it to pick this one up
+	select {
+	case <-ctx.Done():
+		return false
+	case p.AddFromQueuedPoolChan <- AddRequest{Tx: tx, ResponseChan: respChan}:
+	}
 
-	return <-respChan
+	select {
+	case <-ctx.Done():
+		return false
+	case v := <-respChan:
+		return v
+	}
 
 }
 
@@ -1060,16 +3329,80 @@ func (p *PendingPool) AddUnstuck(ctx context.Context, tx *MemPoolTx) bool {
 // pending ?
 func (p *PendingPool) VerifiedAdd(ctx context.Context, tx *MemPoolTx) bool {
 
-	ok, err := tx.IsNonceExhausted(ctx, p.RPC)
-	if err != nil {
-		return false
+	return p.VerifiedAddAll(ctx, []*MemPoolTx{tx}) == 1
+
+}
+
+// VerifiedAddAll - Same as `VerifiedAdd`, but for many tx(s) coming from queued
+// pool together, where nonce of each sender is looked up only once, in single
+// batch RPC call, to decide whether tx(s) sent by them are still pending
+//
+// Tx(s) whose sender's nonce couldn't be looked up aren't added. Returns how
+// many of them got added into pending pool.
+func (p *PendingPool) VerifiedAddAll(ctx context.Context, txs []*MemPoolTx) uint64 {
+
+	p.owner.enter()
+
+	if len(txs) == 0 {
+		return 0
 	}
 
-	if ok {
-		return false
+	index := make(map[common.Address]int)
+	senders := make([]common.Address, 0, len(txs))
+
+	for _, tx := range txs {
+
+		if _, ok := index[tx.From]; ok {
+			continue
+		}
+
+		index[tx.From] = len(senders)
+		senders = append(senders, tx.From)
+
+	}
+
+	nonces := make([]hexutil.Uint64, len(senders))
+	batch := make([]rpc.BatchElem, 0, len(senders))
+
+	for i, addr := range senders {
+
+		batch = append(batch, rpc.BatchElem{
+			Method: "eth_getTransactionCount",
+			Args:   []interface{}{addr.Hex(), "latest"},
+			Result: &nonces[i],
+		})
+
+	}
+
+	client, _ := p.Upstreams.Active()
+
+	if err := client.BatchCallContext(ctx, batch); err != nil {
+		limitedLog.Printf("[❗️] Failed to look up nonce of %d sender(s) : %s\n", len(senders), err.Error())
+		return 0
+	}
+
+	var added uint64
+
+	for _, tx := range txs {
+
+		i := index[tx.From]
+		if batch[i].Error != nil {
+			continue
+		}
+
+		// Some other tx with same nonce got mined, this one
+		// isn't pending anymore
+		if tx.Nonce < nonces[i] {
+			continue
+		}
+
+		if p.AddUnstuck(ctx, tx) {
+			added++
+		}
+
 	}
 
-	return p.AddUnstuck(ctx, tx)
+	return added
 
 }
 
@@ -1077,49 +3410,230 @@ func (p *PendingPool) VerifiedAdd(ctx context.Context, tx *MemPoolTx) bool {
 // to pubsub topic
 func (p *PendingPool) PublishAdded(ctx context.Context, msg *MemPoolTx) {
 
-	data, err := msg.ToMessagePack()
+	if !config.GetPendingTxEntryPublishChoice() {
+		return
+	}
+
+	topic := config.GetPendingTxEntryPublishTopic()
+	if !p.published.shouldPublish(msg.Hash, topic, time.Duration(config.GetPublishDedupWindow())*time.Millisecond) {
+		return
+	}
+
+	data, err := msg.ToEvent(config.GetPendingEventSchemaVersion())
 	if err != nil {
-		log.Printf("[❗️] Failed to serialize into messagepack : %s\n", err.Error())
+		limitedLog.Printf("[❗️] Failed to serialize into messagepack : %s\n", err.Error())
 		return
 	}
 
+	topics := TopicsFor(topic, ENTRY, msg)
+	if bucket := GasPriceBucketTopic(topic, msg, p.GasPriceBuckets, p.TxsByGasPrice.baseFee); len(bucket) != 0 {
+		topics = append(topics, bucket)
+	}
+
 	if _, err := p.PubSub.Publish(&ops.Msg{
-		Topics: []string{config.GetPendingTxEntryPublishTopic()},
+		Topics: topics,
 		Data:   data,
 	}); err != nil {
-		log.Printf("[❗️] Failed to publish tx joining pending pool : %s\n", err.Error())
+		limitedLog.Printf("[❗️] Failed to publish tx joining pending pool : %s\n", err.Error())
+	}
+
+}
+
+// PublishReplaced - Publish tx replacing some other tx living in pending pool
+// i.e. same sender & nonce, to pubsub topic
+//
+// Rapid replacements of same (sender, nonce) are coalesced, so that at max
+// one event, carrying latest gas price, is published per configured interval
+func (p *PendingPool) PublishReplaced(ctx context.Context, msg *MemPoolTx) {
+
+	if !config.GetPendingTxReplacementPublishChoice() {
+		return
 	}
 
+	interval := time.Duration(config.GetReplacementCoalesceInterval()) * time.Millisecond
+
+	p.replacements.offer(msg, interval, func(tx *MemPoolTx) {
+
+		// Held back ones may get flushed after shut down began
+		if ctx.Err() != nil {
+			return
+		}
+
+		data, err := tx.ToEvent(config.GetPendingEventSchemaVersion())
+		if err != nil {
+			limitedLog.Printf("[❗️] Failed to serialize into messagepack : %s\n", err.Error())
+			return
+		}
+
+		if _, err := p.PubSub.Publish(&ops.Msg{
+			Topics: []string{config.GetPendingTxReplacementPublishTopic()},
+			Data:   data,
+		}); err != nil {
+			limitedLog.Printf("[❗️] Failed to publish tx replacing pending one : %s\n", err.Error())
+		}
+
+	})
+
 }
 
 // Remove - Removes already existing tx from pending tx pool
 // denoting it has been mined i.e. confirmed/ dropped ( possible too )
 func (p *PendingPool) Remove(ctx context.Context, txStat *TxStatus) bool {
 
-	respChan := make(chan bool)
-	p.RemoveTxChan <- RemoveRequest{TxStat: txStat, ResponseChan: respChan}
+	p.owner.enter()
+
+	respChan := make(chan bool, 1)
+
+	select {
+	case <-ctx.Done():
+		return false
+	case p.RemoveTxChan <- RemoveRequest{TxStat: txStat, ResponseChan: respChan}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case v := <-respChan:
+		return v
+	}
+
+}
+
+// OnRemove - Registers callback to be invoked for every tx leaving pending pool,
+// along with reason i.e. `confirmed`/ `dropped`, in same order as they're removed
+//
+// @note Callback is invoked from pool's own go routine, so it must be fast &
+// non-blocking, also it must not call back into pool, otherwise it'll deadlock
+func (p *PendingPool) OnRemove(ctx context.Context, cb func(*MemPoolTx, string)) bool {
+
+	p.owner.enter()
 
-	return <-respChan
+	respChan := make(chan bool, 1)
+
+	select {
+	case <-ctx.Done():
+		return false
+	case p.OnRemoveChan <- OnRemoveRequest{Callback: cb, ResponseChan: respChan}:
+	}
+
+	// Request is already with pool, so callback will get registered,
+	// even if caller stops waiting for acknowledgement
+	select {
+	case <-ctx.Done():
+		return true
+	case <-respChan:
+		return true
+	}
 
 }
 
-// PublishRemoved - Publish old pending tx pool content ( in messagepack serialized format )
-// to pubsub topic
+// OnAdd - Registers callback to be invoked for every tx joining pending pool,
+// in same order as they're added
 //
-// These tx(s) are leaving pending pool i.e. they're confirmed now
-func (p *PendingPool) PublishRemoved(ctx context.Context, msg *MemPoolTx) {
+// @note Callback is invoked from pool's own go routine, so it must be fast &
+// non-blocking, also it must not call back into pool, otherwise it'll deadlock
+func (p *PendingPool) OnAdd(ctx context.Context, cb func(*MemPoolTx)) bool {
+
+	p.owner.enter()
+
+	respChan := make(chan bool, 1)
+
+	select {
+	case <-ctx.Done():
+		return false
+	case p.OnAddChan <- OnAddRequest{Callback: cb, ResponseChan: respChan}:
+	}
+
+	// Request is already with pool, so callback will get registered,
+	// even if caller stops waiting for acknowledgement
+	select {
+	case <-ctx.Done():
+		return true
+	case <-respChan:
+		return true
+	}
+
+}
+
+// PublishEvicted - Publish tx evicted from pending pool, because pool/ its
+// sender was at cap & it was paying lowest effective gas price, to pubsub topic
+func (p *PendingPool) PublishEvicted(ctx context.Context, msg *MemPoolTx) {
+
+	if !config.GetPendingTxEvictedPublishChoice() {
+		return
+	}
+
+	topic := config.GetPendingTxEvictedPublishTopic()
+	if !p.published.shouldPublish(msg.Hash, topic, time.Duration(config.GetPublishDedupWindow())*time.Millisecond) {
+		return
+	}
 
-	data, err := msg.ToMessagePack()
+	data, err := msg.ToEvent(config.GetPendingEventSchemaVersion())
 	if err != nil {
-		log.Printf("[❗️] Failed to serialize into messagepack : %s\n", err.Error())
+		limitedLog.Printf("[❗️] Failed to serialize into messagepack : %s\n", err.Error())
 		return
 	}
 
 	if _, err := p.PubSub.Publish(&ops.Msg{
-		Topics: []string{config.GetPendingTxExitPublishTopic()},
+		Topics: []string{topic},
 		Data:   data,
 	}); err != nil {
-		log.Printf("[❗️] Failed to publish tx leaving pending pool : %s\n", err.Error())
+		limitedLog.Printf("[❗️] Failed to publish tx evicted from pending pool : %s\n", err.Error())
+	}
+
+}
+
+// PublishRemoved - Publish old pending tx pool content ( in messagepack serialized format )
+// to pubsub topic
+//
+// These tx(s) are leaving pending pool i.e. they're confirmed/ dropped now.
+// Dropped ones, for which no receipt was found, are also published on
+// dedicated topic, so that their senders can be asked to resubmit
+func (p *PendingPool) PublishRemoved(ctx context.Context, msg *MemPoolTx) {
+
+	// Dropped tx(s) may still be wanted on dedicated topic, even
+	// when general exit events aren't
+	dropped := msg.Pool == "dropped" && config.GetPendingTxDroppedPublishChoice()
+	exit := config.GetPendingTxExitPublishChoice()
+
+	if !exit && !dropped {
+		return
+	}
+
+	topic := config.GetPendingTxExitPublishTopic()
+	if !p.published.shouldPublish(msg.Hash, topic, time.Duration(config.GetPublishDedupWindow())*time.Millisecond) {
+		return
+	}
+
+	data, err := msg.ToEvent(config.GetPendingEventSchemaVersion())
+	if err != nil {
+		limitedLog.Printf("[❗️] Failed to serialize into messagepack : %s\n", err.Error())
+		return
+	}
+
+	// Pool & dropped topics are exit topics, while global/ category
+	// ones always carry full tx
+	exitTopics := make([]string, 0, 2)
+	otherTopics := make([]string, 0)
+
+	if exit {
+		topics := TopicsFor(topic, EXIT, msg)
+
+		exitTopics = append(exitTopics, topics[0])
+		otherTopics = append(otherTopics, topics[1:]...)
+	}
+
+	if dropped {
+		exitTopics = append(exitTopics, config.GetPendingTxDroppedPublishTopic())
+	}
+
+	at := msg.ConfirmedAt
+	if msg.Pool == "dropped" {
+		at = msg.DroppedAt
+	}
+
+	if err := publishRemoval(p.PubSub, msg, data, msg.Pool, at, exitTopics, otherTopics, config.GetPendingTxExitFullPublishTopic()); err != nil {
+		limitedLog.Printf("[❗️] Failed to publish tx leaving pending pool : %s\n", err.Error())
 	}
 
 }
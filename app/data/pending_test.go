@@ -0,0 +1,1167 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestPendingSenderCapUnderFlood(t *testing.T) {
+
+	setConfig(t, "MaxSenders", 8)
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	// First 8 senders fill up index, rest are all rejected
+	for i := 0; i < 1000; i++ {
+
+		added := pools.pending.Add(ctx, legacyTx(hashOf(i), addrOf(i), 0, 1_000_000_000))
+		if added != (i < 8) {
+			t.Fatalf("tx from sender %d : added %v", i, added)
+		}
+
+	}
+
+	for i := 0; i < 8; i++ {
+
+		txs := pools.pending.TxsFromA(ctx, addrOf(i))
+		if len(txs) != 1 || txs[0].Hash != hashOf(i) {
+			t.Fatalf("sender %d : expected its only tx, got %v", i, txs)
+		}
+
+	}
+
+	// Known sender is still let in, even when index is full
+	if !pools.pending.Add(ctx, legacyTx(hashOf(2000), addrOf(0), 1, 1_000_000_000)) {
+		t.Fatal("tx from known sender rejected")
+	}
+
+	// Once some sender's last tx leaves, rejected one gets in, when
+	// it's seen again in next poll
+	if !pools.pending.Remove(ctx, &TxStatus{Hash: hashOf(1), Status: CONFIRMED}) {
+		t.Fatal("failed to remove tx")
+	}
+
+	if !pools.pending.Add(ctx, legacyTx(hashOf(100), addrOf(100), 0, 1_000_000_000)) {
+		t.Fatal("tx rejected at cap is not let in, after sender slot freed up")
+	}
+
+	pools.stop()
+
+	if n := len(pools.pending.TxsFromAddress); n != 8 {
+		t.Fatalf("expected 8 senders in index, found %d", n)
+	}
+
+	if n := len(pools.pending.DroppedTxs); n != 0 {
+		t.Fatalf("expected rejected tx(s) not to be remembered, found %d", n)
+	}
+
+}
+
+func TestPendingRequestsGiveUpOnCancelledContext(t *testing.T) {
+
+	// Pool isn't running, nobody is going to serve requests
+	pending := &PendingPool{Watchdog: &Watchdog{}}
+	makeChans(pending)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan struct{})
+
+	go func() {
+
+		defer close(done)
+
+		if v := pending.RecommendGasPrice(ctx); v != (GasRecommendation{}) {
+			t.Errorf("expected zero recommendation, got %v", v)
+		}
+
+		if v := pending.ClockSkew(ctx); v != 0 {
+			t.Errorf("expected zero clock skew, got %v", v)
+		}
+
+		if v := pending.AvgInclusionLatency(ctx, time.Minute); v != 0 {
+			t.Errorf("expected zero latency, got %v", v)
+		}
+
+		if v := pending.HeavilyReplaced(ctx, 1); v != nil {
+			t.Errorf("expected no tx(s), got %v", v)
+		}
+
+		if v := pending.DisplacedBy(ctx, big.NewInt(1)); v != nil {
+			t.Errorf("expected no tx, got %v", v)
+		}
+
+		if rank, _ := pending.SimulateCandidate(ctx, big.NewInt(1), 21000, 42000); rank != 0 {
+			t.Errorf("expected zero rank, got %d", rank)
+		}
+
+		if _, err := pending.Restore(ctx, bytes.NewReader([]byte{0x90})); err == nil {
+			t.Error("expected restore to fail")
+		}
+
+		if v := pending.AscListTxs(ctx); v != nil {
+			t.Errorf("expected no tx(s), got %v", v)
+		}
+
+		if v := pending.TxsFromA(ctx, addrOf(0)); v != nil {
+			t.Errorf("expected no tx(s), got %v", v)
+		}
+
+		if v := pending.TopXWithHighGasPrice(ctx, 10); len(v) != 0 {
+			t.Errorf("expected no tx(s), got %v", v)
+		}
+
+		if v := pending.BaseFee(ctx); v != nil {
+			t.Errorf("expected no base fee, got %v", v)
+		}
+
+		if v := pending.GetLastSeenBlock(ctx); v != (LastSeenBlock{}) {
+			t.Errorf("expected zero block, got %v", v)
+		}
+
+		if v := pending.Processed(ctx); v != 0 {
+			t.Errorf("expected zero, got %d", v)
+		}
+
+		for tx := range pending.StreamTopX(ctx, DESC, 10) {
+			t.Errorf("expected nothing to be streamed, got %v", tx)
+		}
+
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("request blocked on pool, which isn't running")
+	}
+
+}
+
+func TestGasPriceForInclusionWithin(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	const gwei = 1_000_000_000
+
+	// Room for 3 plain transfers per block
+	blockGasLimit := uint64(3 * MinTxGas)
+
+	for i := 1; i <= 9; i++ {
+
+		if !pools.pending.Add(ctx, legacyTx(hashOf(i), addrOf(i), 0, int64(i)*gwei)) {
+			t.Fatalf("failed to add tx %d", i)
+		}
+
+	}
+
+	var prev *big.Int
+
+	for k, expected := range map[int]int64{1: 7*gwei + 1, 2: 4*gwei + 1, 3: 1*gwei + 1, 4: 0} {
+
+		price := pools.pending.GasPriceForInclusionWithin(ctx, k, MinTxGas, blockGasLimit)
+		if price == nil || price.Int64() != expected {
+			t.Fatalf("k = %d : expected %d, got %v", k, expected, price)
+		}
+
+	}
+
+	// Asking for more time must never cost more
+	for k := 1; k <= 5; k++ {
+
+		price := pools.pending.GasPriceForInclusionWithin(ctx, k, MinTxGas, blockGasLimit)
+		if prev != nil && price.Cmp(prev) > 0 {
+			t.Fatalf("k = %d : price %s is higher than for k = %d : %s", k, price, k-1, prev)
+		}
+
+		prev = price
+
+	}
+
+	// Bigger tx needs to push out two cheapest ones of block
+	if price := pools.pending.GasPriceForInclusionWithin(ctx, 1, 2*MinTxGas, blockGasLimit); price == nil || price.Int64() != 8*gwei+1 {
+		t.Fatalf("expected %d for bigger tx, got %v", 8*gwei+1, price)
+	}
+
+	if price := pools.pending.GasPriceForInclusionWithin(ctx, 1, blockGasLimit+1, blockGasLimit); price != nil {
+		t.Fatalf("expected nil for tx not fitting in block, got %v", price)
+	}
+
+	if price := pools.pending.GasPriceForInclusionWithin(ctx, 0, MinTxGas, blockGasLimit); price != nil {
+		t.Fatalf("expected nil for k = 0, got %v", price)
+	}
+
+}
+
+func TestPendingPoolFull(t *testing.T) {
+
+	setConfig(t, "PendingPoolSize", 3)
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	const gwei = 1_000_000_000
+
+	for i := 2; i <= 4; i++ {
+
+		if !pools.pending.Add(ctx, legacyTx(hashOf(i), addrOf(i), 0, int64(i)*gwei)) {
+			t.Fatalf("failed to add tx %d", i)
+		}
+
+	}
+
+	// Paying same as/ less than cheapest one, can't take its place
+	if pools.pending.Add(ctx, legacyTx(hashOf(10), addrOf(10), 0, 2*gwei)) {
+		t.Fatal("tx paying same as cheapest one admitted into full pool")
+	}
+
+	if pools.pending.Add(ctx, legacyTx(hashOf(11), addrOf(11), 0, 1*gwei)) {
+		t.Fatal("tx paying less than cheapest one admitted into full pool")
+	}
+
+	if !pools.pending.Add(ctx, legacyTx(hashOf(12), addrOf(12), 0, 5*gwei)) {
+		t.Fatal("tx paying more than cheapest one rejected")
+	}
+
+	if n := pools.pending.Count(ctx); n != 3 {
+		t.Fatalf("expected pool to stay at 3 tx(s), found %d", n)
+	}
+
+	if pools.pending.Exists(ctx, hashOf(2)) {
+		t.Fatal("cheapest tx not evicted")
+	}
+
+}
+
+func TestPendingMatchingSnapshot(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	const gwei = 1_000_000_000
+
+	for i := 0; i < 6; i++ {
+
+		// Even ones are sent by first sender
+		from := addrOf(i % 2)
+
+		if !pools.pending.Add(ctx, legacyTx(hashOf(i), from, uint64(i/2), int64(i+1)*gwei)) {
+			t.Fatalf("failed to add tx %d", i)
+		}
+
+	}
+
+	if txs := pools.pending.MatchingSnapshot(ctx, nil); len(txs) != 6 {
+		t.Fatalf("expected nil filter to match all 6 tx(s), got %d", len(txs))
+	}
+
+	txs := pools.pending.MatchingSnapshot(ctx, func(tx *MemPoolTx) bool { return tx.IsSentFrom(addrOf(0)) })
+	if len(txs) != 3 {
+		t.Fatalf("expected 3 matching tx(s), got %d", len(txs))
+	}
+
+	// Highest paying one comes first
+	for i, expected := range []int{4, 2, 0} {
+
+		if txs[i].Hash != hashOf(expected) {
+			t.Fatalf("position %d : expected tx %d, got %s", i, expected, txs[i].Hash.Hex())
+		}
+
+	}
+
+}
+
+func TestDisplacedBy(t *testing.T) {
+
+	setConfig(t, "PendingPoolSize", 2)
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	const gwei = 1_000_000_000
+
+	if !pools.pending.Add(ctx, legacyTx(hashOf(2), addrOf(2), 0, 2*gwei)) {
+		t.Fatal("failed to add tx")
+	}
+
+	// Pool has room, nothing to be displaced
+	if tx := pools.pending.DisplacedBy(ctx, big.NewInt(5*gwei)); tx != nil {
+		t.Fatalf("expected nothing to be displaced, got %s", tx.Hash.Hex())
+	}
+
+	if !pools.pending.Add(ctx, legacyTx(hashOf(3), addrOf(3), 0, 3*gwei)) {
+		t.Fatal("failed to add tx")
+	}
+
+	if tx := pools.pending.DisplacedBy(ctx, big.NewInt(5*gwei)); tx == nil || tx.Hash != hashOf(2) {
+		t.Fatalf("expected cheapest tx to be displaced, got %v", tx)
+	}
+
+	if tx := pools.pending.DisplacedBy(ctx, big.NewInt(2*gwei)); tx != nil {
+		t.Fatalf("expected nothing to be displaced without outbidding, got %s", tx.Hash.Hex())
+	}
+
+	if tx := pools.pending.DisplacedBy(ctx, nil); tx != nil {
+		t.Fatalf("expected nothing to be displaced without gas price, got %s", tx.Hash.Hex())
+	}
+
+}
+
+func TestSimulateCandidate(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	const gwei = 1_000_000_000
+
+	// Sent twice, so that first one is surely applied, once
+	// second one is accepted
+	for i := 0; i < 2; i++ {
+		pools.pending.SetBaseFeeChan <- big.NewInt(10 * gwei)
+	}
+
+	for _, tx := range []*MemPoolTx{
+		// Effectively pays 12 Gwei, though fee cap is way higher
+		dynamicTx(hashOf(0), addrOf(0), 0, 100*gwei, 2*gwei),
+		legacyTx(hashOf(1), addrOf(1), 0, 20*gwei),
+		legacyTx(hashOf(2), addrOf(2), 0, 5*gwei),
+	} {
+
+		if !pools.pending.Add(ctx, tx) {
+			t.Fatalf("failed to add tx %s", tx.Hash.Hex())
+		}
+
+	}
+
+	for _, c := range []struct {
+		gasPrice int64
+		rank     int
+		included bool
+	}{
+		{25 * gwei, 1, true},
+		{15 * gwei, 2, true},
+		{11 * gwei, 3, false},
+		{1 * gwei, 4, false},
+	} {
+
+		rank, included := pools.pending.SimulateCandidate(ctx, big.NewInt(c.gasPrice), 21000, 42000)
+		if rank != c.rank || included != c.included {
+			t.Fatalf("candidate paying %d : expected (%d, %v), got (%d, %v)", c.gasPrice, c.rank, c.included, rank, included)
+		}
+
+	}
+
+	if n := pools.pending.Count(ctx); n != 3 {
+		t.Fatalf("expected simulation to leave pool untouched, found %d tx(s)", n)
+	}
+
+}
+
+func TestAddedBetween(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	txs := make([]*MemPoolTx, 4)
+
+	for i := range txs {
+
+		txs[i] = legacyTx(hashOf(i), addrOf(i), 0, 1_000_000_000)
+		if !pools.pending.Add(ctx, txs[i]) {
+			t.Fatalf("failed to add tx %d", i)
+		}
+
+		// So that each one enters pool at distinct time
+		time.Sleep(time.Millisecond)
+
+	}
+
+	for name, c := range map[string]struct {
+		start    time.Time
+		end      time.Time
+		expected []int
+	}{
+		"halfOpen": {txs[1].PendingFrom, txs[3].PendingFrom, []int{1, 2}},
+		"all":      {txs[0].PendingFrom, txs[3].PendingFrom.Add(time.Nanosecond), []int{0, 1, 2, 3}},
+		"single":   {txs[2].PendingFrom, txs[2].PendingFrom.Add(time.Nanosecond), []int{2}},
+		"empty":    {txs[2].PendingFrom, txs[2].PendingFrom, nil},
+		"reversed": {txs[3].PendingFrom, txs[0].PendingFrom, nil},
+	} {
+
+		found := make(map[common.Hash]bool)
+		for _, tx := range pools.pending.AddedBetween(ctx, c.start, c.end) {
+			found[tx.Hash] = true
+		}
+
+		if len(found) != len(c.expected) {
+			t.Fatalf("%s : expected %d tx(s), got %d", name, len(c.expected), len(found))
+		}
+
+		for _, i := range c.expected {
+
+			if !found[hashOf(i)] {
+				t.Fatalf("%s : tx %d not found", name, i)
+			}
+
+		}
+
+	}
+
+}
+
+func TestClockSkew(t *testing.T) {
+
+	const gwei = 1_000_000_000
+
+	for name, offset := range map[string]time.Duration{"behind": 30 * time.Second, "ahead": -30 * time.Second} {
+
+		// Block's timestamp is `offset` behind local clock
+		block := fmt.Sprintf(`{"timestamp":"%#x","baseFeePerGas":"%#x"}`, time.Now().Add(-offset).Unix(), 7*gwei)
+
+		pools := startPoolsWith(t, block)
+		ctx := context.Background()
+
+		if skew := pools.pending.ClockSkew(ctx); skew != 0 {
+			t.Fatalf("%s : expected no skew before seeing any block, got %s", name, skew)
+		}
+
+		pools.pending.SetLastSeenBlockChan <- 1
+
+		// Block is inspected without blocking pool, so waiting for
+		// it to be done, base fee is let known after skew
+		var baseFee *big.Int
+		for deadline := time.Now().Add(time.Second); baseFee == nil && time.Now().Before(deadline); {
+			baseFee = pools.pending.BaseFee(ctx)
+		}
+
+		skew := pools.pending.ClockSkew(ctx)
+
+		// Timestamp is in seconds, so sub-second part of
+		// local clock adds up
+		if skew < offset || skew > offset+2*time.Second {
+			t.Fatalf("%s : expected skew around %s, got %s", name, offset, skew)
+		}
+
+		if baseFee == nil || baseFee.Int64() != 7*gwei {
+			t.Fatalf("%s : expected base fee of block to be applied, got %v", name, baseFee)
+		}
+
+		pools.stop()
+
+	}
+
+}
+
+func TestExistsMany(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	// Even ones are in pool, odd ones aren't
+	hashes := make([]common.Hash, 0, 10)
+
+	for i := 0; i < 10; i++ {
+
+		hashes = append(hashes, hashOf(i))
+
+		if i%2 != 0 {
+			continue
+		}
+
+		if !pools.pending.Add(ctx, legacyTx(hashOf(i), addrOf(i), 0, 1_000_000_000)) {
+			t.Fatalf("failed to add tx %d", i)
+		}
+
+	}
+
+	found := pools.pending.ExistsMany(ctx, hashes)
+	if len(found) != len(hashes) {
+		t.Fatalf("expected answer for %d hashes, got %d", len(hashes), len(found))
+	}
+
+	for i, hash := range hashes {
+
+		if found[hash] != (i%2 == 0) {
+			t.Fatalf("tx %d : expected present %v, got %v", i, i%2 == 0, found[hash])
+		}
+
+		if found[hash] != pools.pending.Exists(ctx, hash) {
+			t.Fatalf("tx %d : disagrees with single lookup", i)
+		}
+
+	}
+
+	if found := pools.pending.ExistsMany(ctx, nil); len(found) != 0 {
+		t.Fatalf("expected nothing for no hashes, got %v", found)
+	}
+
+}
+
+func BenchmarkExistsMany(b *testing.B) {
+
+	pools := startPools(b)
+	ctx := context.Background()
+
+	hashes := make([]common.Hash, 0, 1000)
+
+	for i := 0; i < 1000; i++ {
+
+		hashes = append(hashes, hashOf(i))
+
+		if !pools.pending.Add(ctx, legacyTx(hashOf(i), addrOf(i), 0, 1_000_000_000)) {
+			b.Fatalf("failed to add tx %d", i)
+		}
+
+	}
+
+	b.Run("perHash", func(b *testing.B) {
+
+		for i := 0; i < b.N; i++ {
+
+			for _, hash := range hashes {
+				pools.pending.Exists(ctx, hash)
+			}
+
+		}
+
+	})
+
+	b.Run("batched", func(b *testing.B) {
+
+		for i := 0; i < b.N; i++ {
+			pools.pending.ExistsMany(ctx, hashes)
+		}
+
+	})
+
+}
+
+func TestIncludableGasPricePercentiles(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	const gwei = 1_000_000_000
+
+	for i := 0; i < 2; i++ {
+		pools.pending.SetBaseFeeChan <- big.NewInt(10 * gwei)
+	}
+
+	for _, tx := range []*MemPoolTx{
+		// Can't be included, pay less than base fee
+		dynamicTx(hashOf(0), addrOf(0), 0, 5*gwei, 1*gwei),
+		legacyTx(hashOf(1), addrOf(1), 0, 8*gwei),
+		// Effectively pays 15 Gwei
+		dynamicTx(hashOf(2), addrOf(2), 0, 100*gwei, 5*gwei),
+		legacyTx(hashOf(3), addrOf(3), 0, 10*gwei),
+		legacyTx(hashOf(4), addrOf(4), 0, 20*gwei),
+		legacyTx(hashOf(5), addrOf(5), 0, 30*gwei),
+		legacyTx(hashOf(6), addrOf(6), 0, 40*gwei),
+	} {
+
+		if !pools.pending.Add(ctx, tx) {
+			t.Fatalf("failed to add tx %s", tx.Hash.Hex())
+		}
+
+	}
+
+	prices := pools.pending.IncludableGasPricePercentiles(ctx, 0, 40, 50, 100)
+
+	for i, expected := range []int64{10 * gwei, 15 * gwei, 20 * gwei, 40 * gwei} {
+
+		if prices == nil || prices[i] == nil || prices[i].Int64() != expected {
+			t.Fatalf("percentile %d : expected %d, got %v", i, expected, prices)
+		}
+
+	}
+
+	if gweis := pools.pending.IncludableGasPricePercentilesGwei(ctx, 2, 50, 100); len(gweis) != 2 || gweis[0] != 20 || gweis[1] != 40 {
+		t.Fatalf("expected [20 40] Gwei, got %v", gweis)
+	}
+
+	if prices := pools.pending.IncludableGasPricePercentiles(ctx, 50, 101); prices != nil {
+		t.Fatalf("expected nil for out of range percentile, got %v", prices)
+	}
+
+	// Nobody is paying enough anymore
+	for i := 0; i < 2; i++ {
+		pools.pending.SetBaseFeeChan <- big.NewInt(1000 * gwei)
+	}
+
+	if prices := pools.pending.IncludableGasPricePercentiles(ctx, 50); prices != nil {
+		t.Fatalf("expected nil without any includable tx, got %v", prices)
+	}
+
+}
+
+func TestSizeHistogram(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	// Unsorted on purpose
+	buckets := []int{1000, 200}
+
+	if histogram := pools.pending.SizeHistogram(ctx, buckets); !reflect.DeepEqual(histogram, map[int]uint64{200: 0, 1000: 0}) {
+		t.Fatalf("expected empty buckets, got %v", histogram)
+	}
+
+	// Calldata lengths, making tx(s) land on either side of bucket bounds
+	for i, n := range []int{0, 200 - TxSizeOverhead, 201 - TxSizeOverhead, 1000 - TxSizeOverhead, 2000} {
+
+		tx := legacyTx(hashOf(i), addrOf(i), 0, 1_000_000_000)
+		tx.Input = make(hexutil.Bytes, n)
+
+		if !pools.pending.Add(ctx, tx) {
+			t.Fatalf("failed to add tx %d", i)
+		}
+
+	}
+
+	if histogram := pools.pending.SizeHistogram(ctx, buckets); !reflect.DeepEqual(histogram, map[int]uint64{200: 2, 1000: 2, -1: 1}) {
+		t.Fatalf("unexpected histogram %v", histogram)
+	}
+
+	if histogram := pools.pending.SizeHistogram(ctx, nil); !reflect.DeepEqual(histogram, map[int]uint64{-1: 5}) {
+		t.Fatalf("expected all tx(s) to be larger than no bucket, got %v", histogram)
+	}
+
+	if !reflect.DeepEqual(buckets, []int{1000, 200}) {
+		t.Fatalf("buckets passed in got modified %v", buckets)
+	}
+
+}
+
+func TestValueInFlight(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	if v := pools.pending.ValueInFlight(ctx); v.Sign() != 0 {
+		t.Fatalf("expected nothing in flight, got %s", v)
+	}
+
+	transfer := legacyTx(hashOf(0), addrOf(0), 0, 10)
+	transfer.Value = (*hexutil.Big)(big.NewInt(1000))
+
+	// Charged as per fee cap, not effective gas price
+	call := dynamicTx(hashOf(1), addrOf(1), 0, 100, 2)
+	call.Value = (*hexutil.Big)(big.NewInt(5))
+
+	for _, tx := range []*MemPoolTx{transfer, call} {
+
+		if !pools.pending.Add(ctx, tx) {
+			t.Fatalf("failed to add tx %s", tx.Hash.Hex())
+		}
+
+	}
+
+	if v := pools.pending.ValueInFlight(ctx); v.Int64() != 21000*10+1000+21000*100+5 {
+		t.Fatalf("unexpected value in flight %s", v)
+	}
+
+	if !pools.pending.Remove(ctx, &TxStatus{Hash: hashOf(0), Status: CONFIRMED}) {
+		t.Fatal("failed to remove tx")
+	}
+
+	v := pools.pending.ValueInFlight(ctx)
+	if v.Int64() != 21000*100+5 {
+		t.Fatalf("unexpected value in flight, after removal %s", v)
+	}
+
+	// Caller gets its own copy of running total
+	v.SetInt64(0)
+
+	if v := pools.pending.ValueInFlight(ctx); v.Int64() != 21000*100+5 {
+		t.Fatalf("running total modified by caller %s", v)
+	}
+
+	if !pools.pending.Remove(ctx, &TxStatus{Hash: hashOf(1), Status: DROPPED}) {
+		t.Fatal("failed to remove tx")
+	}
+
+	if v := pools.pending.ValueInFlight(ctx); v.Sign() != 0 {
+		t.Fatalf("expected nothing in flight, once pool emptied, got %s", v)
+	}
+
+}
+
+func TestUnderfundedSenders(t *testing.T) {
+
+	// Every sender holds 100_000 Wei
+	pools := startPoolsWith(t, `"0x186a0"`)
+	ctx := context.Background()
+
+	if senders := pools.pending.UnderfundedSenders(ctx); len(senders) != 0 {
+		t.Fatalf("expected no underfunded sender in empty pool, got %v", senders)
+	}
+
+	txs := make([]*MemPoolTx, 0, 5)
+
+	// Can afford both, costing 42_000 Wei in total
+	for i := 0; i < 2; i++ {
+		txs = append(txs, legacyTx(hashOf(i), addrOf(0), uint64(i), 1))
+	}
+
+	// Can afford any one of them, not all three, costing 153_000 Wei
+	for i := 2; i >= 0; i-- {
+
+		tx := legacyTx(hashOf(10+i), addrOf(1), uint64(i), 1)
+		tx.Value = (*hexutil.Big)(big.NewInt(30_000))
+
+		txs = append(txs, tx)
+
+	}
+
+	for _, tx := range txs {
+
+		if !pools.pending.Add(ctx, tx) {
+			t.Fatalf("failed to add tx %s", tx.Hash.Hex())
+		}
+
+	}
+
+	senders := pools.pending.UnderfundedSenders(ctx)
+	if len(senders) != 1 || len(senders[addrOf(1)]) != 3 {
+		t.Fatalf("expected only second sender to be underfunded, got %v", senders)
+	}
+
+	// Ascending as per nonce, though added in reverse
+	for i, tx := range senders[addrOf(1)] {
+
+		if tx.Hash != hashOf(10+i) {
+			t.Fatalf("position %d : expected tx with nonce %d, got %s", i, i, tx.Hash.Hex())
+		}
+
+	}
+
+}
+
+func TestPublishChoices(t *testing.T) {
+
+	topics := []string{
+		"pending_pool_entry", "pending_pool_exit", "pending_pool_dropped",
+		"pending_pool_evicted", "pending_pool_replacement",
+		"queued_pool_entry", "queued_pool_exit", "queued_pool_evicted",
+	}
+
+	h := startHub(t, topics...)
+	ctx := context.Background()
+
+	// Only pool topics, each tx published once
+	setConfig(t, "GlobalTxTopic", "")
+	setConfig(t, "TransferTxTopic", "")
+	setConfig(t, "ContractCallTxTopic", "")
+	setConfig(t, "DeployTxTopic", "")
+	setConfig(t, "PublishDedupWindow", 0)
+	setConfig(t, "ReplacementCoalesceInterval", 0)
+	setConfig(t, "CompactRemovalEnabled", false)
+
+	pending := &PendingPool{PubSub: h.pub, TxsByGasPrice: NewSortedTxs()}
+	queued := &QueuedPool{PubSub: h.pub}
+
+	publishAll := func(i int) {
+
+		dropped := legacyTx(hashOf(i), addrOf(0), 0, 1)
+		dropped.Pool = "dropped"
+
+		pending.PublishAdded(ctx, legacyTx(hashOf(i), addrOf(0), 0, 1))
+		pending.PublishReplaced(ctx, legacyTx(hashOf(i), addrOf(0), 0, 1))
+		pending.PublishEvicted(ctx, legacyTx(hashOf(i), addrOf(0), 0, 1))
+		pending.PublishRemoved(ctx, dropped)
+		queued.PublishAdded(ctx, legacyTx(hashOf(i), addrOf(0), 0, 1))
+		queued.PublishRemoved(ctx, legacyTx(hashOf(i), addrOf(0), 0, 1))
+		queued.PublishEvicted(ctx, legacyTx(hashOf(i), addrOf(0), 0, 1))
+
+	}
+
+	// Everything is enabled by default
+	publishAll(0)
+
+	received := h.received(t)
+	for _, topic := range topics {
+
+		if len(received[topic]) != 1 {
+			t.Fatalf("expected one message on %s, got %d", topic, len(received[topic]))
+		}
+
+	}
+
+	for key, disabled := range map[string]string{
+		"PendingTxEntryEnabled":       "pending_pool_entry",
+		"PendingTxReplacementEnabled": "pending_pool_replacement",
+		"PendingTxEvictedEnabled":     "pending_pool_evicted",
+		"PendingTxDroppedEnabled":     "pending_pool_dropped",
+		"QueuedTxEntryEnabled":        "queued_pool_entry",
+		"QueuedTxExitEnabled":         "queued_pool_exit",
+		"QueuedTxEvictedEnabled":      "queued_pool_evicted",
+	} {
+
+		setConfig(t, key, false)
+		publishAll(1)
+		setConfig(t, key, true)
+
+		received := h.received(t)
+		for _, topic := range topics {
+
+			if expected := topic != disabled; (len(received[topic]) == 1) != expected {
+				t.Fatalf("%s disabled : expected message on %s %v, got %d", key, topic, expected, len(received[topic]))
+			}
+
+		}
+
+	}
+
+	// Dropped tx(s) still make it to dedicated topic, when
+	// general exit events are disabled
+	setConfig(t, "PendingTxExitEnabled", false)
+	publishAll(2)
+
+	received = h.received(t)
+	if len(received["pending_pool_exit"]) != 0 || len(received["pending_pool_dropped"]) != 1 {
+		t.Fatalf("expected dropped tx only on dedicated topic, got %d on exit & %d on dropped", len(received["pending_pool_exit"]), len(received["pending_pool_dropped"]))
+	}
+
+	setConfig(t, "PendingTxDroppedEnabled", false)
+	publishAll(3)
+
+	received = h.received(t)
+	if len(received["pending_pool_exit"]) != 0 || len(received["pending_pool_dropped"]) != 0 {
+		t.Fatal("expected nothing on exit topics, when both are disabled")
+	}
+
+}
+
+func TestAvgInclusionLatency(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	if v := pools.pending.AvgInclusionLatency(ctx, time.Minute); v != 0 {
+		t.Fatalf("expected zero latency without confirmations, got %s", v)
+	}
+
+	// Latency is counted since tx was first seen, in queued pool
+	now := time.Now().UTC()
+
+	for i, waited := range []time.Duration{40 * time.Second, 20 * time.Second, 1000 * time.Second} {
+
+		tx := legacyTx(hashOf(i), addrOf(i), 0, 1_000_000_000)
+		tx.QueuedAt = now.Add(-waited)
+
+		if !pools.pending.Add(ctx, tx) {
+			t.Fatalf("failed to add tx %d", i)
+		}
+
+	}
+
+	// Dropped one never counts
+	for i, status := range []*TxStatus{{Hash: hashOf(0), Status: CONFIRMED}, {Hash: hashOf(2), Status: DROPPED}, {Hash: hashOf(1), Status: CONFIRMED}} {
+
+		if !pools.pending.Remove(ctx, status) {
+			t.Fatalf("failed to remove tx %d", i)
+		}
+
+		time.Sleep(200 * time.Millisecond)
+
+	}
+
+	within := func(v time.Duration, expected time.Duration) bool {
+		return v >= expected && v < expected+time.Second
+	}
+
+	if v := pools.pending.AvgInclusionLatency(ctx, time.Minute); !within(v, 30*time.Second) {
+		t.Fatalf("expected average latency around 30s, got %s", v)
+	}
+
+	// Only last confirmation falls within window
+	if v := pools.pending.AvgInclusionLatency(ctx, 300*time.Millisecond); !within(v, 20*time.Second) {
+		t.Fatalf("expected latency around 20s within narrow window, got %s", v)
+	}
+
+}
+
+func TestHeavilyReplaced(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	const gwei = 1_000_000_000
+
+	// First slot gets replaced 3 times, second one once, while
+	// third one never
+	add := func(i int, sender int, price int64) {
+
+		t.Helper()
+
+		if !pools.pending.Add(ctx, legacyTx(hashOf(i), addrOf(sender), 0, price*gwei)) {
+			t.Fatalf("failed to add tx %d", i)
+		}
+
+		// So that latest one of slot can be told apart
+		time.Sleep(time.Millisecond)
+
+	}
+
+	for i := 0; i < 4; i++ {
+		add(i, 0, int64(10+i))
+	}
+
+	add(10, 1, 20)
+	add(11, 1, 21)
+	add(20, 2, 30)
+
+	hashes := func(txs []*MemPoolTx) []common.Hash {
+
+		result := make([]common.Hash, 0, len(txs))
+		for _, tx := range txs {
+			result = append(result, tx.Hash)
+		}
+
+		return result
+
+	}
+
+	if txs := pools.pending.HeavilyReplaced(ctx, 3); !reflect.DeepEqual(hashes(txs), []common.Hash{hashOf(3)}) {
+		t.Fatalf("expected only latest tx of first slot, got %v", hashes(txs))
+	}
+
+	if txs := pools.pending.HeavilyReplaced(ctx, 1); !reflect.DeepEqual(hashes(txs), []common.Hash{hashOf(11), hashOf(3)}) {
+		t.Fatalf("expected latest tx of both replaced slots, by gas price, got %v", hashes(txs))
+	}
+
+	if txs := pools.pending.HeavilyReplaced(ctx, 4); len(txs) != 0 {
+		t.Fatalf("expected nothing replaced 4 times, got %v", hashes(txs))
+	}
+
+	// Once slot is emptied, its history is forgotten
+	for i := 0; i < 4; i++ {
+
+		if !pools.pending.Remove(ctx, &TxStatus{Hash: hashOf(i), Status: DROPPED}) {
+			t.Fatalf("failed to remove tx %d", i)
+		}
+
+	}
+
+	add(4, 0, 50)
+
+	if txs := pools.pending.HeavilyReplaced(ctx, 1); !reflect.DeepEqual(hashes(txs), []common.Hash{hashOf(11)}) {
+		t.Fatalf("expected history of emptied slot to be forgotten, got %v", hashes(txs))
+	}
+
+}
+
+func TestSince(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	add := func(from int, to int) {
+
+		t.Helper()
+
+		for i := from; i < to; i++ {
+
+			if !pools.pending.Add(ctx, legacyTx(hashOf(i), addrOf(i), 0, 1_000_000_000)) {
+				t.Fatalf("failed to add tx %d", i)
+			}
+
+			// So that each one enters pool at distinct time
+			time.Sleep(time.Millisecond)
+
+		}
+
+	}
+
+	expect := func(txs []*MemPoolTx, from int, to int) {
+
+		t.Helper()
+
+		if len(txs) != to-from {
+			t.Fatalf("expected %d tx(s), got %d", to-from, len(txs))
+		}
+
+		// Ascending as per entry time
+		for i, tx := range txs {
+
+			if tx.Hash != hashOf(from+i) {
+				t.Fatalf("position %d : expected tx %d, got %s", i, from+i, tx.Hash.Hex())
+			}
+
+		}
+
+	}
+
+	add(0, 3)
+
+	txs, cursor := pools.pending.Since(ctx, time.Time{})
+	expect(txs, 0, 3)
+
+	if !cursor.Equal(txs[2].PendingFrom) {
+		t.Fatalf("expected cursor at last tx's entry time, got %s", cursor)
+	}
+
+	// Only ones added in between are seen by next call
+	add(3, 5)
+
+	txs, next := pools.pending.Since(ctx, cursor)
+	expect(txs, 3, 5)
+
+	if !next.After(cursor) {
+		t.Fatalf("expected cursor to move forward, got %s after %s", next, cursor)
+	}
+
+	// Nothing new, same cursor given back
+	txs, same := pools.pending.Since(ctx, next)
+	expect(txs, 5, 5)
+
+	if !same.Equal(next) {
+		t.Fatalf("expected cursor to stay at %s, got %s", next, same)
+	}
+
+}
+
+func TestVerifiedAddAll(t *testing.T) {
+
+	// Every sender's nonce is reported to be 2
+	pools := startPoolsWith(t, `"0x2"`)
+	ctx := context.Background()
+
+	txs := []*MemPoolTx{
+		legacyTx(hashOf(0), addrOf(0), 1, 1_000_000_000),
+		legacyTx(hashOf(1), addrOf(0), 2, 1_000_000_000),
+		legacyTx(hashOf(2), addrOf(0), 3, 1_000_000_000),
+		legacyTx(hashOf(3), addrOf(1), 5, 1_000_000_000),
+	}
+
+	if n := pools.pending.VerifiedAddAll(ctx, txs); n != 3 {
+		t.Fatalf("expected 3 tx(s) to be added, got %d", n)
+	}
+
+	if pools.pending.Exists(ctx, hashOf(0)) {
+		t.Fatal("tx with exhausted nonce added")
+	}
+
+	for i := 1; i < 4; i++ {
+
+		if !pools.pending.Exists(ctx, hashOf(i)) {
+			t.Fatalf("tx %d not added", i)
+		}
+
+	}
+
+	if pools.pending.VerifiedAdd(ctx, legacyTx(hashOf(4), addrOf(2), 0, 1_000_000_000)) {
+		t.Fatal("tx with exhausted nonce added")
+	}
+
+}
+
+func TestTopX(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+
+		if !pools.pending.Add(ctx, legacyTx(hashOf(i), addrOf(i), 0, int64(i+1)*1_000_000_000)) {
+			t.Fatalf("failed to add tx %d to pending pool", i)
+		}
+
+		if !pools.queued.Add(ctx, legacyTx(hashOf(10+i), addrOf(i), 5, int64(i+1)*1_000_000_000)) {
+			t.Fatalf("failed to add tx %d to queued pool", i)
+		}
+
+	}
+
+	for name, top := range map[string]func(context.Context, uint64) []*MemPoolTx{
+		"pendingHigh": pools.pending.TopXWithHighGasPrice,
+		"pendingLow":  pools.pending.TopXWithLowGasPrice,
+		"queuedHigh":  pools.queued.TopXWithHighGasPrice,
+		"queuedLow":   pools.queued.TopXWithLowGasPrice,
+	} {
+
+		if txs := top(ctx, 0); txs == nil || len(txs) != 0 {
+			t.Fatalf("%s : expected empty slice for x = 0, got %v", name, txs)
+		}
+
+		if txs := top(ctx, 2); len(txs) != 2 {
+			t.Fatalf("%s : expected 2 tx(s), got %d", name, len(txs))
+		}
+
+		if txs := top(ctx, 10); len(txs) != 5 {
+			t.Fatalf("%s : expected all 5 tx(s), got %d", name, len(txs))
+		}
+
+	}
+
+}
+
+func TestSenderCapEviction(t *testing.T) {
+
+	h := startHub(t, "pending_pool_evicted", "queued_pool_evicted")
+
+	setConfig(t, "MaxTxsPerSender", 1)
+	setConfig(t, "PublishDedupWindow", 0)
+
+	pools := startPoolsPublishing(t, `"0x0"`, h.pub)
+	ctx := context.Background()
+
+	setConfig(t, "PendingTxEvictedEnabled", true)
+	setConfig(t, "QueuedTxEvictedEnabled", true)
+
+	removed := make(chan *MemPoolTx, 2)
+
+	for name, c := range map[string]struct {
+		add      func(context.Context, *MemPoolTx) bool
+		onRemove func(context.Context, func(*MemPoolTx, string)) bool
+		topic    string
+	}{
+		"pending": {pools.pending.Add, pools.pending.OnRemove, "pending_pool_evicted"},
+		"queued":  {pools.queued.Add, pools.queued.OnRemove, "queued_pool_evicted"},
+	} {
+
+		if !c.onRemove(ctx, func(tx *MemPoolTx, reason string) {
+			if reason == "dropped" {
+				removed <- tx
+			}
+		}) {
+			t.Fatalf("%s : failed to register removal callback", name)
+		}
+
+		cheap := legacyTx(hashOf(0), addrOf(0), 0, 1_000_000_000)
+
+		if !c.add(ctx, cheap) {
+			t.Fatalf("%s : failed to add tx", name)
+		}
+
+		// Sender at cap, its cheaper tx makes room for this one
+		if !c.add(ctx, legacyTx(hashOf(1), addrOf(0), 1, 2_000_000_000)) {
+			t.Fatalf("%s : failed to add tx paying more", name)
+		}
+
+		select {
+		case tx := <-removed:
+			if tx.Hash != cheap.Hash || tx.Pool != "dropped" || tx.DroppedAt.IsZero() {
+				t.Fatalf("%s : expected cheaper tx to be marked dropped, got %s in %s", name, tx.Hash.Hex(), tx.Pool)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s : removal callback not invoked for evicted tx", name)
+		}
+
+		if n := len(h.received(t)[c.topic]); n != 1 {
+			t.Fatalf("%s : expected evicted tx to be published, got %d message(s)", name, n)
+		}
+
+	}
+
+}
@@ -2,10 +2,17 @@ package data
 
 import (
 	"context"
+	"io"
 	"log"
+	"math/big"
+	"sort"
+	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/itzmeanjan/harmony/app/metrics"
 )
 
 // MemPool - Current state of mempool, where all pending/ queued tx(s)
@@ -15,164 +22,486 @@ import (
 //
 // Tx(s) ending up in queued pool, happens very commonly due to account nonce gaps
 type MemPool struct {
-	Pending *PendingPool
-	Queued  *QueuedPool
+	// Kept first, so that it's 64-bit aligned for atomic ops
+	lastPolled int64
+	Pending    *PendingPool
+	Queued     *QueuedPool
+	restored   bool
+	waiters    removalWaiters
+	watchers   txWatchers
 }
 
 // Get - Given a txhash, attempts to find out tx, if
 // present in any of pending/ queued pool
-func (m *MemPool) Get(hash common.Hash) *MemPoolTx {
+func (m *MemPool) Get(ctx context.Context, hash common.Hash) *MemPoolTx {
 
-	queued := m.Queued.Get(hash)
+	queued := m.Queued.Get(ctx, hash)
 	if queued != nil {
 		return queued
 	}
 
-	return m.Pending.Get(hash)
+	return m.Pending.Get(ctx, hash)
 
 }
 
 // Exists - Given a txHash, attempts to check whether this tx is present
 // in either of pending/ queued pool
-func (m *MemPool) Exists(hash common.Hash) bool {
+func (m *MemPool) Exists(ctx context.Context, hash common.Hash) bool {
 
-	queued := m.Queued.Exists(hash)
+	queued := m.Queued.Exists(ctx, hash)
 	if queued {
 		return queued
 	}
 
-	return m.Pending.Exists(hash)
+	return m.Pending.Exists(ctx, hash)
 
 }
 
 // PendingDuplicates - Find duplicate tx(s), given txHash, present
 // in pending mempool
-func (m *MemPool) PendingDuplicates(hash common.Hash) []*MemPoolTx {
-	return m.Pending.DuplicateTxs(hash)
+func (m *MemPool) PendingDuplicates(ctx context.Context, hash common.Hash) []*MemPoolTx {
+	return m.Pending.DuplicateTxs(ctx, hash)
 }
 
 // QueuedDuplicates - Find duplicate tx(s), given txHash, present
 // in queued mempool
-func (m *MemPool) QueuedDuplicates(hash common.Hash) []*MemPoolTx {
-	return m.Queued.DuplicateTxs(hash)
+func (m *MemPool) QueuedDuplicates(ctx context.Context, hash common.Hash) []*MemPoolTx {
+	return m.Queued.DuplicateTxs(ctx, hash)
+}
+
+// TopPendingSendersByFee - Top `n` senders, as per cumulative fee offered
+// by their pending tx(s)
+func (m *MemPool) TopPendingSendersByFee(ctx context.Context, n int) []SenderFee {
+	return m.Pending.TopSendersByCumulativeFee(ctx, n)
+}
+
+// PendingFeeBumps - Pending tx(s) replacing earlier ones of same sender &
+// nonce, by paying different gas price, along with bump percentage
+func (m *MemPool) PendingFeeBumps(ctx context.Context) []FeeBumpPair {
+	return m.Pending.FeeBumps(ctx)
+}
+
+// PendingReplacement - Find tx replacing given one, by paying higher gas
+// price with same nonce, present in pending mempool, along with fee delta
+func (m *MemPool) PendingReplacement(ctx context.Context, hash common.Hash) (*MemPoolTx, *big.Int) {
+	return m.Pending.FindReplacement(ctx, hash)
 }
 
 // PendingPoolLength - Returning current pending tx queue length
-func (m *MemPool) PendingPoolLength() uint64 {
-	return m.Pending.Count()
+func (m *MemPool) PendingPoolLength(ctx context.Context) uint64 {
+	return m.Pending.Count(ctx)
+}
+
+// PendingCountMatching - #-of pending tx(s) satisfying given filter
+func (m *MemPool) PendingCountMatching(ctx context.Context, filter TxFilter) uint64 {
+	return m.Pending.CountMatching(ctx, filter)
+}
+
+// QueuedCountMatching - #-of queued tx(s) satisfying given filter
+func (m *MemPool) QueuedCountMatching(ctx context.Context, filter TxFilter) uint64 {
+	return m.Queued.CountMatching(ctx, filter)
+}
+
+// QueuedLowestNoncePerSender - Lowest nonce queued tx of each sender,
+// likely to be blocking rest of its queued tx(s)
+func (m *MemPool) QueuedLowestNoncePerSender(ctx context.Context) map[common.Address]*MemPoolTx {
+	return m.Queued.LowestNonceQueuedPerSender(ctx)
+}
+
+// PendingMatchingSnapshot - Pending tx(s) satisfying given filter, in
+// descending order of gas price
+func (m *MemPool) PendingMatchingSnapshot(ctx context.Context, filter Filter) []*MemPoolTx {
+	return m.Pending.MatchingSnapshot(ctx, filter)
+}
+
+// CancelQueuedPrune - Pauses queued pool pruner & discards tx(s) marked
+// unstuck by it, which are not yet moved into pending pool
+func (m *MemPool) CancelQueuedPrune() {
+	m.Queued.CancelPrune()
+}
+
+// ResumeQueuedPrune - Lets paused queued pool pruner move unstuck tx(s)
+// into pending pool again
+func (m *MemPool) ResumeQueuedPrune() {
+	m.Queued.ResumePrune()
+}
+
+// QueuedPrunePaused - Whether queued pool pruner is paused or not
+func (m *MemPool) QueuedPrunePaused() bool {
+	return m.Queued.PrunePaused()
+}
+
+// QueuedSenderStats - #-of tx(s) of sender, which got queued &
+// #-of them which got unstuck later
+func (m *MemPool) QueuedSenderStats(addr common.Address) (uint64, uint64) {
+	return m.Queued.GetSenderStats(addr)
+}
+
+// QueuedSenderReputationScore - Share of sender's queued tx(s), which
+// got unstuck later, usable for spam filtering
+func (m *MemPool) QueuedSenderReputationScore(addr common.Address) float64 {
+	return m.Queued.SenderReputationScore(addr)
 }
 
 // QueuedPoolLength - Returning current queued tx queue length
-func (m *MemPool) QueuedPoolLength() uint64 {
-	return m.Queued.Count()
+func (m *MemPool) QueuedPoolLength(ctx context.Context) uint64 {
+	return m.Queued.Count(ctx)
 }
 
 // DoneTxCount - #-of tx(s) seen to processed during this node's life time
-func (m *MemPool) DoneTxCount() uint64 {
-	return m.Pending.Processed()
+func (m *MemPool) DoneTxCount(ctx context.Context) uint64 {
+	return m.Pending.Processed(ctx)
 }
 
 // LastSeenBlock - Last seen block by mempool & when it was seen, to be invoked
 // by stat generator http request handler method
-func (m *MemPool) LastSeenBlock() LastSeenBlock {
-	return m.Pending.GetLastSeenBlock()
+func (m *MemPool) LastSeenBlock(ctx context.Context) LastSeenBlock {
+	return m.Pending.GetLastSeenBlock(ctx)
+}
+
+// ClockSkew - How far off local clock is from chain, as per timestamp of
+// last seen block, used for tx age calculation
+func (m *MemPool) ClockSkew(ctx context.Context) time.Duration {
+	return m.Pending.ClockSkew(ctx)
+}
+
+// PendingValueInFlight - Total value at risk in pending pool i.e. value being
+// transferred along with max fee, summed over all pending tx(s)
+func (m *MemPool) PendingValueInFlight(ctx context.Context) *big.Int {
+	return m.Pending.ValueInFlight(ctx)
+}
+
+// AvgInclusionLatency - Average time from first seen to mined, of tx(s)
+// confirmed within last `window`
+func (m *MemPool) AvgInclusionLatency(ctx context.Context, window time.Duration) time.Duration {
+	return m.Pending.AvgInclusionLatency(ctx, window)
 }
 
 // PendingForGTE - Returning list of tx(s), pending for more than
 // x time unit
-func (m *MemPool) PendingForGTE(x time.Duration) []*MemPoolTx {
-	return m.Pending.OlderThanX(x)
+func (m *MemPool) PendingForGTE(ctx context.Context, x time.Duration) []*MemPoolTx {
+	return m.Pending.OlderThanX(ctx, x)
+}
+
+// PendingOldestPerSender - Oldest pending tx of each sender
+func (m *MemPool) PendingOldestPerSender(ctx context.Context) map[common.Address]*MemPoolTx {
+	return m.Pending.OldestPerSender(ctx)
+}
+
+// PendingMedianWaitTime - Median of how long tx(s) have been waiting
+// in pending pool, as of now
+func (m *MemPool) PendingMedianWaitTime(ctx context.Context) time.Duration {
+	return m.Pending.MedianWaitTime(ctx)
+}
+
+// PendingAddedBetween - Returns list of tx(s), which joined pending
+// pool within [`start`, `end`) time window
+func (m *MemPool) PendingAddedBetween(ctx context.Context, start time.Time, end time.Time) []*MemPoolTx {
+	return m.Pending.AddedBetween(ctx, start, end)
+}
+
+// PendingSince - Returns pending tx(s) joined after `cursor`, along
+// with cursor to be used in next poll
+func (m *MemPool) PendingSince(ctx context.Context, cursor time.Time) ([]*MemPoolTx, time.Time) {
+	return m.Pending.Since(ctx, cursor)
 }
 
 // PendingForLTE - Returning list of tx(s), pending for less than
 // x time unit
-func (m *MemPool) PendingForLTE(x time.Duration) []*MemPoolTx {
-	return m.Pending.FresherThanX(x)
+func (m *MemPool) PendingForLTE(ctx context.Context, x time.Duration) []*MemPoolTx {
+	return m.Pending.FresherThanX(ctx, x)
 }
 
 // QueuedForGTE - Returning list of tx(s), queued for more than
 // x time unit
-func (m *MemPool) QueuedForGTE(x time.Duration) []*MemPoolTx {
-	return m.Queued.OlderThanX(x)
+func (m *MemPool) QueuedForGTE(ctx context.Context, x time.Duration) []*MemPoolTx {
+	return m.Queued.OlderThanX(ctx, x)
 }
 
 // QueuedForLTE - Returning list of tx(s), queued for less than
 // x time unit
-func (m *MemPool) QueuedForLTE(x time.Duration) []*MemPoolTx {
-	return m.Queued.FresherThanX(x)
+func (m *MemPool) QueuedForLTE(ctx context.Context, x time.Duration) []*MemPoolTx {
+	return m.Queued.FresherThanX(ctx, x)
 }
 
 // PendingWithGTE - Returns list of tx(s), pending with gas price >= `X`
-func (m *MemPool) PendingWithGTE(x float64) []*MemPoolTx {
-	return m.Pending.HigherThanX(x)
+func (m *MemPool) PendingWithGTE(ctx context.Context, x float64) []*MemPoolTx {
+	return m.Pending.HigherThanX(ctx, x)
 }
 
 // PendingWithLTE - Returns list of tx(s), pending with gas price <= `X`
-func (m *MemPool) PendingWithLTE(x float64) []*MemPoolTx {
-	return m.Pending.LowerThanX(x)
+func (m *MemPool) PendingWithLTE(ctx context.Context, x float64) []*MemPoolTx {
+	return m.Pending.LowerThanX(ctx, x)
 }
 
 // QueuedWithGTE - Returns list of tx(s), queued with gas price >= `X`
-func (m *MemPool) QueuedWithGTE(x float64) []*MemPoolTx {
-	return m.Queued.HigherThanX(x)
+func (m *MemPool) QueuedWithGTE(ctx context.Context, x float64) []*MemPoolTx {
+	return m.Queued.HigherThanX(ctx, x)
 }
 
 // QueuedWithLTE - Returns list of tx(s), queued with gas price <= `X`
-func (m *MemPool) QueuedWithLTE(x float64) []*MemPoolTx {
-	return m.Queued.LowerThanX(x)
+func (m *MemPool) QueuedWithLTE(ctx context.Context, x float64) []*MemPoolTx {
+	return m.Queued.LowerThanX(ctx, x)
+}
+
+// PendingSorted - Returns list of pending tx(s), ordered as per given
+// multi-key sort specification
+func (m *MemPool) PendingSorted(ctx context.Context, spec string) ([]*MemPoolTx, error) {
+	return m.Pending.SortedListTxs(ctx, spec)
+}
+
+// QueuedSorted - Returns list of queued tx(s), ordered as per given
+// multi-key sort specification
+func (m *MemPool) QueuedSorted(ctx context.Context, spec string) ([]*MemPoolTx, error) {
+	return m.Queued.SortedListTxs(ctx, spec)
+}
+
+// PendingIncludableGasPricePercentiles - Percentiles of effective gas price
+// paid by pending tx(s), which can be included under latest base fee, in Wei
+func (m *MemPool) PendingIncludableGasPricePercentiles(ctx context.Context, pcts ...float64) []*big.Int {
+	return m.Pending.IncludableGasPricePercentiles(ctx, pcts...)
+}
+
+// PendingIncludableGasPricePercentilesGwei - Same as
+// `PendingIncludableGasPricePercentiles`, but in Gwei, rounded to `precision`
+// digits after decimal point
+func (m *MemPool) PendingIncludableGasPricePercentilesGwei(ctx context.Context, precision int, pcts ...float64) []float64 {
+	return m.Pending.IncludableGasPricePercentilesGwei(ctx, precision, pcts...)
+}
+
+// RecommendGasPrice - Slow/ standard/ fast effective gas price tiers, in Wei,
+// derived from percentiles of includable pending tx(s)
+func (m *MemPool) RecommendGasPrice(ctx context.Context) GasRecommendation {
+	return m.Pending.RecommendGasPrice(ctx)
+}
+
+// PendingHeavilyReplaced - Current pending tx of each (sender, nonce) slot,
+// replaced at least `minReplacements` times
+func (m *MemPool) PendingHeavilyReplaced(ctx context.Context, minReplacements int) []*MemPoolTx {
+	return m.Pending.HeavilyReplaced(ctx, minReplacements)
+}
+
+// PendingDisplacedBy - Pending tx, which would be dropped for making room
+// for candidate tx paying `gasPrice`, when pool is at capacity
+func (m *MemPool) PendingDisplacedBy(ctx context.Context, gasPrice *big.Int) *MemPoolTx {
+	return m.Pending.DisplacedBy(ctx, gasPrice)
+}
+
+// PendingGasPricePercentile - `p`-th percentile of effective gas price
+// paid by pending tx(s), in Wei
+func (m *MemPool) PendingGasPricePercentile(ctx context.Context, p float64) *big.Int {
+	return m.Pending.GasPricePercentile(ctx, p)
+}
+
+// PendingGasPricePercentileGwei - `p`-th percentile of effective gas price
+// paid by pending tx(s), in Gwei, rounded to `precision` digits after decimal point
+func (m *MemPool) PendingGasPricePercentileGwei(ctx context.Context, p float64, precision int) float64 {
+	return m.Pending.GasPricePercentileGwei(ctx, p, precision)
+}
+
+// PendingWithinRange - Returns list of tx(s), pending with gas price
+// within [`min`, `max`] ( in Gwei )
+func (m *MemPool) PendingWithinRange(ctx context.Context, min *big.Int, max *big.Int) []*MemPoolTx {
+	return m.Pending.TxsByGasPriceRange(ctx, min, max)
+}
+
+// QueuedWithinRange - Returns list of tx(s), queued with gas price
+// within [`min`, `max`] ( in Gwei )
+func (m *MemPool) QueuedWithinRange(ctx context.Context, min *big.Int, max *big.Int) []*MemPoolTx {
+	return m.Queued.TxsByGasPriceRange(ctx, min, max)
+}
+
+// PendingWithinValueRange - Returns list of tx(s), pending with value
+// within [`min`, `max`] ( in Wei )
+func (m *MemPool) PendingWithinValueRange(ctx context.Context, min *big.Int, max *big.Int) []*MemPoolTx {
+	return m.Pending.TxsByValueRange(ctx, min, max)
+}
+
+// QueuedWithinValueRange - Returns list of tx(s), queued with value
+// within [`min`, `max`] ( in Wei )
+func (m *MemPool) QueuedWithinValueRange(ctx context.Context, min *big.Int, max *big.Int) []*MemPoolTx {
+	return m.Queued.TxsByValueRange(ctx, min, max)
+}
+
+// PendingOfType - Returns list of tx(s), pending with given EIP-2718 type
+func (m *MemPool) PendingOfType(ctx context.Context, t uint8) []*MemPoolTx {
+	return m.Pending.TxsByType(ctx, t)
+}
+
+// QueuedOfType - Returns list of tx(s), queued with given EIP-2718 type
+func (m *MemPool) QueuedOfType(ctx context.Context, t uint8) []*MemPoolTx {
+	return m.Queued.TxsByType(ctx, t)
 }
 
 // PendingFrom - List of tx(s) pending from address
 //
 // @note These are going to be same nonce tx(s), only one of them will
 // make to next block, others to be dropped
-func (m *MemPool) PendingFrom(address common.Address) []*MemPoolTx {
-	return m.Pending.SentFrom(address)
+func (m *MemPool) PendingFrom(ctx context.Context, address common.Address) []*MemPoolTx {
+	return m.Pending.SentFrom(ctx, address)
 }
 
 // PendingTo - List of tx(s) living in pending pool, sent to specified address
-func (m *MemPool) PendingTo(address common.Address) []*MemPoolTx {
-	return m.Pending.SentTo(address)
+func (m *MemPool) PendingTo(ctx context.Context, address common.Address) []*MemPoolTx {
+	return m.Pending.SentTo(ctx, address)
+}
+
+// PendingMatching - List of tx(s) living in pending pool, matching given sender,
+// recipient & method selector, where nil ones are wildcard
+func (m *MemPool) PendingMatching(ctx context.Context, from *common.Address, to *common.Address, selector *[4]byte) []*MemPoolTx {
+	return m.Pending.Match(ctx, from, to, selector)
 }
 
 // QueuedFrom - List of stuck tx(s) from specified address, due to nonce gap
-func (m *MemPool) QueuedFrom(address common.Address) []*MemPoolTx {
-	return m.Queued.SentFrom(address)
+func (m *MemPool) QueuedFrom(ctx context.Context, address common.Address) []*MemPoolTx {
+	return m.Queued.SentFrom(ctx, address)
+}
+
+// QueuedNonceGaps - Ranges of missing nonces, because of which tx(s) from
+// specified address are stuck in queued pool
+func (m *MemPool) QueuedNonceGaps(ctx context.Context, address common.Address) []NonceRange {
+	return m.Queued.NonceGaps(ctx, address)
+}
+
+// QueuedMissingNonces - Missing nonces, at max `limit` of them, because of
+// which tx(s) from specified address are stuck in queued pool
+func (m *MemPool) QueuedMissingNonces(ctx context.Context, address common.Address, limit uint64) []uint64 {
+	return m.Queued.MissingNonces(ctx, address, limit)
 }
 
 // QueuedTo - List of stuck tx(s) present in queued pool, sent to specified
 // address
-func (m *MemPool) QueuedTo(address common.Address) []*MemPoolTx {
-	return m.Queued.SentTo(address)
+func (m *MemPool) QueuedTo(ctx context.Context, address common.Address) []*MemPoolTx {
+	return m.Queued.SentTo(ctx, address)
+}
+
+// PendingSenderSummary - Summary of all pending tx(s) sent from
+// specified address
+func (m *MemPool) PendingSenderSummary(ctx context.Context, address common.Address) *SenderStats {
+	return m.Pending.SenderSummary(ctx, address)
+}
+
+// NextBlock - Pending tx(s) which would be included in next block
+// of given gas limit, when paying at least `baseFee`
+func (m *MemPool) NextBlock(ctx context.Context, gasLimit uint64, baseFee *big.Int) []*MemPoolTx {
+	return m.Pending.SimulateNextBlock(ctx, gasLimit, baseFee)
+}
+
+// GasPriceForInclusionWithin - Minimum gas price, for tx with `txGas` gas limit
+// to be included within next `k` blocks of `blockGasLimit`, as per simulation
+// of pending pool
+func (m *MemPool) GasPriceForInclusionWithin(ctx context.Context, k int, txGas uint64, blockGasLimit uint64) *big.Int {
+	return m.Pending.GasPriceForInclusionWithin(ctx, k, txGas, blockGasLimit)
+}
+
+// NextBlockTipRevenue - Total tip block producer would earn from
+// including pending tx(s) in next block
+func (m *MemPool) NextBlockTipRevenue(ctx context.Context, gasLimit uint64, baseFee *big.Int) *big.Int {
+	return m.Pending.NextBlockTipRevenue(ctx, gasLimit, baseFee)
+}
+
+// TotalPendingFees - Rough upper bound on fees extractable from
+// pending pool, as gas price x gas limit summed over all tx(s)
+func (m *MemPool) TotalPendingFees(ctx context.Context) *big.Int {
+	return m.Pending.TotalPendingFees(ctx)
+}
+
+// EstimateBlockFees - Fees block producer would earn from filling up
+// block of given gas limit, with highest paying pending tx(s)
+func (m *MemPool) EstimateBlockFees(ctx context.Context, gasLimit uint64) *big.Int {
+	return m.Pending.EstimateBlockFees(ctx, gasLimit)
+}
+
+// SimulateCandidate - Where hypothetical tx would be ranked in pending pool
+// & whether it'd be included in next block, if submitted now
+func (m *MemPool) SimulateCandidate(ctx context.Context, gasPrice *big.Int, gas uint64, blockGasLimit uint64) (int, bool) {
+	return m.Pending.SimulateCandidate(ctx, gasPrice, gas, blockGasLimit)
 }
 
 // TopXPendingWithHighGasPrice - Returns a list of top `X` pending tx(s)
 // where high gas price tx(s) are prioritized
-func (m *MemPool) TopXPendingWithHighGasPrice(x uint64) []*MemPoolTx {
-	return m.Pending.TopXWithHighGasPrice(x)
+func (m *MemPool) TopXPendingWithHighGasPrice(ctx context.Context, x uint64) []*MemPoolTx {
+	return m.Pending.TopXWithHighGasPrice(ctx, x)
 }
 
 // TopXQueuedWithHighGasPrice - Returns a list of top `X` queued tx(s)
 // where high gas price tx(s) are prioritized
-func (m *MemPool) TopXQueuedWithHighGasPrice(x uint64) []*MemPoolTx {
-	return m.Queued.TopXWithHighGasPrice(x)
+func (m *MemPool) TopXQueuedWithHighGasPrice(ctx context.Context, x uint64) []*MemPoolTx {
+	return m.Queued.TopXWithHighGasPrice(ctx, x)
 }
 
 // TopXPendingWithLowGasPrice - Returns a list of top `X` pending tx(s)
 // where low gas price tx(s) are prioritized
-func (m *MemPool) TopXPendingWithLowGasPrice(x uint64) []*MemPoolTx {
-	return m.Pending.TopXWithLowGasPrice(x)
+func (m *MemPool) TopXPendingWithLowGasPrice(ctx context.Context, x uint64) []*MemPoolTx {
+	return m.Pending.TopXWithLowGasPrice(ctx, x)
 }
 
 // TopXQueuedWithLowGasPrice - Returns a list of top `X` queued tx(s)
 // where low gas price tx(s) are prioritized
-func (m *MemPool) TopXQueuedWithLowGasPrice(x uint64) []*MemPoolTx {
-	return m.Queued.TopXWithLowGasPrice(x)
+func (m *MemPool) TopXQueuedWithLowGasPrice(ctx context.Context, x uint64) []*MemPoolTx {
+	return m.Queued.TopXWithLowGasPrice(ctx, x)
+}
+
+// PendingPaged - Returns one page of pending tx(s), ordered as per
+// gas price paid, in requested order
+func (m *MemPool) PendingPaged(ctx context.Context, order int, offset int, limit int) []*MemPoolTx {
+	return m.Pending.ListTxsPaged(ctx, order, offset, limit)
+}
+
+// QueuedPaged - Returns one page of queued tx(s), ordered as per
+// gas price paid, in requested order
+func (m *MemPool) QueuedPaged(ctx context.Context, order int, offset int, limit int) []*MemPoolTx {
+	return m.Queued.ListTxsPaged(ctx, order, offset, limit)
+}
+
+// WritePendingParquet - Writes all pending tx(s) to `w`, as parquet file
+func (m *MemPool) WritePendingParquet(ctx context.Context, w io.Writer) error {
+	return m.Pending.WriteParquet(ctx, w)
+}
+
+// StreamTopXPending - Streams top `X` pending tx(s), ordered as per
+// gas price paid, in requested order
+func (m *MemPool) StreamTopXPending(ctx context.Context, order int, x uint64) <-chan *MemPoolTx {
+	return m.Pending.StreamTopX(ctx, order, x)
+}
+
+// StreamTopXQueued - Streams top `X` queued tx(s), ordered as per
+// gas price paid, in requested order
+func (m *MemPool) StreamTopXQueued(ctx context.Context, order int, x uint64) <-chan *MemPoolTx {
+	return m.Queued.StreamTopX(ctx, order, x)
 }
 
 // Process - Process all current pending & queued tx pool content & populate our in-memory buffer
 func (m *MemPool) Process(ctx context.Context, pending map[string]map[string]*MemPoolTx, queued map[string]map[string]*MemPoolTx) {
 
+	if config.GetSenderRecoveryChoice() {
+
+		if n := ResolveSenders(pending) + ResolveSenders(queued); n != 0 {
+			log.Printf("[❗️] Failed to resolve sender of %d tx(s)\n", n)
+		}
+
+	}
+
+	if config.GetPollDedupChoice() {
+
+		if n := DedupPollResult(pending, queued); n != 0 {
+			log.Printf("[❗️] Found %d tx(s) in both pending & queued section, considered pending\n", n)
+		}
+
+	}
+
+	// Pools restored from snapshot may hold tx(s), which left
+	// mempool while `harmony` was down, first poll tells which
+	if m.restored {
+
+		m.restored = false
+
+		if n := m.PruneStale(ctx, pending, queued); n != 0 {
+			log.Printf("[➖] Pruned %d stale tx(s) restored from snapshot\n", n)
+		}
+
+	}
+
 	start := time.Now().UTC()
 
 	if addedQ := m.Queued.AddQueued(ctx, queued); addedQ != 0 {
@@ -185,12 +514,232 @@ func (m *MemPool) Process(ctx context.Context, pending map[string]map[string]*Me
 		log.Printf("[➕] Added %d tx(s) to pending tx pool, in %s\n", addedP, time.Now().UTC().Sub(start))
 	}
 
+	if n := m.Reconcile(ctx); n != 0 {
+		log.Printf("[➖] Removed %d tx(s) from queued tx pool, already in pending pool\n", n)
+	}
+
+}
+
+// TxPoolContent - Pending & queued pool content, in shape of `txpool_content`
+// response i.e. tx(s) keyed by sender address, then nonce, so that tooling
+// parsing node's response can be pointed to `harmony`
+//
+// @note Sender may have multiple tx(s) with same nonce, when replaced, where
+// only one paying highest gas price is kept, as node would do
+func (m *MemPool) TxPoolContent(ctx context.Context) map[string]map[string]map[string]*MemPoolTx {
+
+	group := func(txs []*MemPoolTx) map[string]map[string]*MemPoolTx {
+
+		grouped := make(map[string]map[string]*MemPoolTx)
+
+		for _, tx := range txs {
+
+			from := tx.From.Hex()
+
+			if _, ok := grouped[from]; !ok {
+				grouped[from] = make(map[string]*MemPoolTx)
+			}
+
+			// Tx(s) are in descending order of gas price,
+			// so first one seen for nonce is kept
+			nonce := strconv.FormatUint(uint64(tx.Nonce), 10)
+			if _, ok := grouped[from][nonce]; !ok {
+				grouped[from][nonce] = tx
+			}
+
+		}
+
+		return grouped
+
+	}
+
+	pending := m.Pending.DescListTxs(ctx)
+	queued := m.Queued.DescListTxs(ctx)
+
+	content := map[string]map[string]map[string]*MemPoolTx{
+		"pending": group(pending),
+		"queued":  group(queued),
+	}
+
+	CleanSlice(pending)
+	CleanSlice(queued)
+
+	return content
+
+}
+
+// Reconcile - Tx moving from queued to pending pool, between polls, may be
+// living in both pools for a while, until queued pool pruner catches up, which
+// would get it double counted. Such tx(s) are removed from queued pool, so that
+// each tx lives in exactly one pool, returning how many were removed
+func (m *MemPool) Reconcile(ctx context.Context) uint64 {
+
+	txs := m.Queued.AscListTxs(ctx)
+	if len(txs) == 0 {
+		return 0
+	}
+
+	hashes := make([]common.Hash, 0, len(txs))
+	for _, tx := range txs {
+		hashes = append(hashes, tx.Hash)
+	}
+
+	CleanSlice(txs)
+
+	var count uint64
+
+	for hash, ok := range m.Pending.ExistsMany(ctx, hashes) {
+
+		if !ok {
+			continue
+		}
+
+		// Already made it to pending pool, so it got unstuck,
+		// even though pruner didn't catch it
+		if tx := m.Queued.Remove(ctx, hash); tx != nil {
+			m.Queued.reputations.unstuck(tx.From)
+			count++
+		}
+
+	}
+
+	return count
+
+}
+
+// DedupPollResult - Node may report same tx in both pending & queued section
+// of one `txpool_content` response, due to race, which would result in conflicting
+// events being published. Pending classification is preferred, so such tx(s) are
+// removed from queued section, returning how many were removed
+func DedupPollResult(pending map[string]map[string]*MemPoolTx, queued map[string]map[string]*MemPoolTx) uint64 {
+
+	seen := make(map[common.Hash]struct{})
+
+	for _, txs := range pending {
+		for _, tx := range txs {
+			seen[tx.Hash] = struct{}{}
+		}
+	}
+
+	var removed uint64
+
+	for addr, txs := range queued {
+
+		for nonce, tx := range txs {
+
+			if _, ok := seen[tx.Hash]; !ok {
+				continue
+			}
+
+			delete(txs, nonce)
+			removed++
+
+		}
+
+		if len(txs) == 0 {
+			delete(queued, addr)
+		}
+
+	}
+
+	return removed
+
+}
+
+// Stats - Aggregate view of mempool, computed from current pool size(s)
+// & pending tx(s), gas prices being in Wei
+func (m *MemPool) Stats(ctx context.Context) *PoolStats {
+
+	stats := &PoolStats{
+		PendingCount:   m.PendingPoolLength(ctx),
+		QueuedCount:    m.QueuedPoolLength(ctx),
+		MinGasPrice:    big.NewInt(0),
+		MaxGasPrice:    big.NewInt(0),
+		MedianGasPrice: big.NewInt(0),
+		At:             time.Now().UTC(),
+	}
+
+	txs := m.Pending.DescListTxs(ctx)
+	if len(txs) == 0 {
+		return stats
+	}
+
+	baseFee := m.Pending.BaseFee(ctx)
+	prices := make([]*big.Int, 0, len(txs))
+
+	for _, tx := range txs {
+
+		prices = append(prices, tx.EffectiveGasPrice(baseFee))
+
+		if tx.IsPendingForGTE(stats.OldestTxAge) {
+			stats.OldestTxAge = stats.At.Sub(tx.PendingFrom)
+		}
+
+	}
+
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].Cmp(prices[j]) < 0
+	})
+
+	stats.MinGasPrice = prices[0]
+	stats.MaxGasPrice = prices[len(prices)-1]
+	stats.MedianGasPrice = prices[len(prices)/2]
+
+	CleanSlice(txs)
+	return stats
+
+}
+
+// AddWatch - Tx(s) sent from/ to this address, entering pending pool,
+// to be delivered to webhook
+func (m *MemPool) AddWatch(addr common.Address) {
+	m.Pending.Webhook.AddWatch(addr)
+}
+
+// RemoveWatch - Stop delivering tx(s) sent from/ to this address to webhook
+func (m *MemPool) RemoveWatch(addr common.Address) {
+	m.Pending.Webhook.RemoveWatch(addr)
+}
+
+// MarkPolled - Records that mempool content was just fetched successfully,
+// or, when ingesting over subscription, that it's still alive
+//
+// @note Concurrent safe, to be invoked by poller
+func (m *MemPool) MarkPolled() {
+	atomic.StoreInt64(&m.lastPolled, time.Now().UTC().UnixNano())
+}
+
+// LastPolledAt - When mempool content was last fetched successfully,
+// zero time if never
+func (m *MemPool) LastPolledAt() time.Time {
+
+	at := atomic.LoadInt64(&m.lastPolled)
+	if at == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, at).UTC()
+
+}
+
+// ObserveSenderDominance - Updates largest single sender share metrics of
+// both pools, to be invoked after each mempool poll
+func (m *MemPool) ObserveSenderDominance(ctx context.Context) {
+
+	pending := m.Pending.SenderDominance(ctx)
+	metrics.SenderTxShare.WithLabelValues("pending").Set(pending.TxShare)
+	metrics.SenderGasShare.WithLabelValues("pending").Set(pending.GasShare)
+
+	queued := m.Queued.SenderDominance(ctx)
+	metrics.SenderTxShare.WithLabelValues("queued").Set(queued.TxShare)
+	metrics.SenderGasShare.WithLabelValues("queued").Set(queued.GasShare)
+
 }
 
 // Stat - Log current mempool state
-func (m *MemPool) Stat(start time.Time) {
+func (m *MemPool) Stat(ctx context.Context, start time.Time) {
 
-	log.Printf("❇️ Pending Tx(s) : %d | Queued Tx(s) : %d, in %s\n", m.PendingPoolLength(), m.QueuedPoolLength(), time.Now().UTC().Sub(start))
+	log.Printf("❇️ Pending Tx(s) : %d | Queued Tx(s) : %d, in %s\n", m.PendingPoolLength(ctx), m.QueuedPoolLength(ctx), time.Now().UTC().Sub(start))
 
 }
 
@@ -202,7 +751,7 @@ func (m *MemPool) HandleTxFromPeer(ctx context.Context, tx *MemPoolTx) bool {
 
 	// Checking whether we already have this tx included in pool
 	// or not
-	exists := m.Exists(tx.Hash)
+	exists := m.Exists(ctx, tx.Hash)
 
 	var status bool
 
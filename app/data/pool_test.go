@@ -0,0 +1,70 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMarkPolled(t *testing.T) {
+
+	m := &MemPool{}
+
+	if !m.LastPolledAt().IsZero() {
+		t.Fatal("expected zero time, before ever being polled")
+	}
+
+	before := time.Now().UTC()
+	m.MarkPolled()
+
+	if at := m.LastPolledAt(); at.Before(before) || at.After(time.Now().UTC()) {
+		t.Fatalf("unexpected last polled time %s", at)
+	}
+
+}
+
+func TestReconcileAcrossPolls(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	m := &MemPool{Pending: pools.pending, Queued: pools.queued}
+
+	// Fresh copy for each poll, same as node's response is decoded anew
+	moving := func() *MemPoolTx { return legacyTx(hashOf(0), addrOf(0), 1, 1_000_000_000) }
+	stuck := func() *MemPoolTx { return legacyTx(hashOf(1), addrOf(1), 5, 1_000_000_000) }
+
+	m.Process(ctx, pollResultOf(), pollResultOf(moving(), stuck()))
+
+	if pending, queued := m.Pending.Count(ctx), m.Queued.Count(ctx); pending != 0 || queued != 2 {
+		t.Fatalf("after first poll : expected (0, 2) tx(s), got (%d, %d)", pending, queued)
+	}
+
+	// Nonce gap got filled between polls, queued pool pruner isn't
+	// running, so it's reconciliation catching moved tx
+	m.Process(ctx, pollResultOf(moving()), pollResultOf(stuck()))
+
+	if pending, queued := m.Pending.Count(ctx), m.Queued.Count(ctx); pending != 1 || queued != 1 {
+		t.Fatalf("after second poll : expected (1, 1) tx(s), got (%d, %d)", pending, queued)
+	}
+
+	if !m.Pending.Exists(ctx, hashOf(0)) || m.Queued.Exists(ctx, hashOf(0)) {
+		t.Fatal("expected moved tx to live only in pending pool")
+	}
+
+	if !m.Queued.Exists(ctx, hashOf(1)) {
+		t.Fatal("expected stuck tx to stay in queued pool")
+	}
+
+	// Nothing left to reconcile on repeated poll
+	m.Process(ctx, pollResultOf(moving()), pollResultOf(stuck()))
+
+	if n := m.Reconcile(ctx); n != 0 {
+		t.Fatalf("expected nothing to reconcile, got %d", n)
+	}
+
+	if _, unstuck := m.Queued.GetSenderStats(addrOf(0)); unstuck != 1 {
+		t.Fatalf("expected moved tx's sender to be credited once, got %d", unstuck)
+	}
+
+}
@@ -0,0 +1,91 @@
+package data
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PublishedEventsCapacity - At max these many recently published events are
+// remembered by each pool, beyond that least recently published ones are forgotten
+const PublishedEventsCapacity = 16384
+
+// publishedEvent - Tx along with topic it was published on
+type publishedEvent struct {
+	hash  common.Hash
+	topic string
+}
+
+// publishedAt - Entry kept in LRU list, remembering when event was published
+type publishedAt struct {
+	event publishedEvent
+	at    time.Time
+}
+
+// publishedEvents - Short lived LRU of events published by pool, so that
+// tx(s) flickering between pools across polls don't get re-announced
+// with same event again & again
+//
+// @note Zero value is ready to use
+type publishedEvents struct {
+	lock    sync.Mutex
+	order   *list.List
+	entries map[publishedEvent]*list.Element
+}
+
+// shouldPublish - Checks whether same event for same tx was published within
+// last `window` or not, if not it's remembered as published now
+//
+// @note Zero `window` disables deduplication
+func (p *publishedEvents) shouldPublish(hash common.Hash, topic string, window time.Duration) bool {
+
+	if window == 0 {
+		return true
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.entries == nil {
+		p.order = list.New()
+		p.entries = make(map[publishedEvent]*list.Element)
+	}
+
+	now := time.Now().UTC()
+	event := publishedEvent{hash: hash, topic: topic}
+
+	if elem, ok := p.entries[event]; ok {
+
+		if now.Sub(elem.Value.(*publishedAt).at) < window {
+			return false
+		}
+
+		elem.Value.(*publishedAt).at = now
+		p.order.MoveToBack(elem)
+		return true
+
+	}
+
+	p.entries[event] = p.order.PushBack(&publishedAt{event: event, at: now})
+
+	// Least recently published ones are at front, they're forgotten
+	// once expired or when capacity is exceeded
+	for p.order.Len() != 0 {
+
+		front := p.order.Front()
+		entry := front.Value.(*publishedAt)
+
+		if p.order.Len() <= PublishedEventsCapacity && now.Sub(entry.at) < window {
+			break
+		}
+
+		p.order.Remove(front)
+		delete(p.entries, entry.event)
+
+	}
+
+	return true
+
+}
@@ -3,13 +3,15 @@ package data
 import (
 	"context"
 	"log"
+	"math/big"
 	"runtime"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/gammazero/workerpool"
 	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/itzmeanjan/harmony/app/metrics"
 	"github.com/itzmeanjan/pub0sub/ops"
 	"github.com/itzmeanjan/pub0sub/publisher"
 )
@@ -21,28 +23,40 @@ import (
 // when next block is going to be picked, when these tx(s) are going to be
 // moved to pending pool, only they can be considered before mining
 type QueuedPool struct {
-	Transactions      map[common.Hash]*MemPoolTx
-	TxsFromAddress    map[common.Address]TxList
-	DroppedTxs        map[common.Hash]time.Time
-	RemovedTxs        map[common.Hash]time.Time
-	AscTxsByGasPrice  TxList
-	DescTxsByGasPrice TxList
-	AddTxChan         chan AddRequest
-	RemoveTxChan      chan RemovedUnstuckTx
-	TxExistsChan      chan ExistsRequest
-	GetTxChan         chan GetRequest
-	CountTxsChan      chan CountRequest
-	ListTxsChan       chan ListRequest
-	TxsFromAChan      chan TxsFromARequest
-	PubSub            *publisher.Publisher
-	RPC               *rpc.Client
-	PendingPool       *PendingPool
+	Transactions           map[common.Hash]*MemPoolTx
+	TxsFromAddress         map[common.Address]TxList
+	DroppedTxs             map[common.Hash]time.Time
+	RemovedTxs             map[common.Hash]time.Time
+	TxsByGasPrice          *SortedTxs
+	AddTxChan              chan AddRequest
+	RemoveTxChan           chan RemovedUnstuckTx
+	TxExistsChan           chan ExistsRequest
+	GetTxChan              chan GetRequest
+	CountTxsChan           chan CountRequest
+	ListTxsChan            chan ListRequest
+	TxsFromAChan           chan TxsFromARequest
+	TxsByGasPriceRangeChan chan GasPriceRangeRequest
+	SenderDominanceChan    chan chan SenderDominance
+	RestoreChan            chan RestoreRequest
+	OnAddChan              chan OnAddRequest
+	OnRemoveChan           chan OnRemoveRequest
+	CancelPruneChan        chan struct{}
+	PubSub                 *publisher.Publisher
+	Upstreams              *Upstreams
+	PendingPool            *PendingPool
+	Watchdog               *Watchdog
+	owner                  ownerGuard
+	published              publishedEvents
+	reputations            reputations
+	prunePaused            uint32
 }
 
 // hasBeenAllocatedFor - Checking whether memory has been allocated
 // for storing all txs from certain address `A`, living in queued pool
 func (q *QueuedPool) hasBeenAllocatedFor(addr common.Address) bool {
 
+	q.owner.check()
+
 	_, ok := q.TxsFromAddress[addr]
 	return ok
 
@@ -53,6 +67,8 @@ func (q *QueuedPool) hasBeenAllocatedFor(addr common.Address) bool {
 // as per nonce
 func (q *QueuedPool) allocateFor(addr common.Address) TxList {
 
+	q.owner.check()
+
 	if q.hasBeenAllocatedFor(addr) {
 		return q.TxsFromAddress[addr]
 	}
@@ -67,6 +83,10 @@ func (q *QueuedPool) allocateFor(addr common.Address) TxList {
 // through out its life
 func (q *QueuedPool) Start(ctx context.Context) {
 
+	// This go routine owns pool state, mutations from anywhere
+	// else are caught in debug build
+	q.owner.claim()
+
 	// Closure for checking whether adding new tx triggers
 	// condition for dropping some other tx
 	//
@@ -81,21 +101,21 @@ func (q *QueuedPool) Start(ctx context.Context) {
 	//
 	// @note Don't accept tx which are already dropped
 	needToDropTxs := func() bool {
-		return uint64(q.AscTxsByGasPrice.len())+1 > config.GetQueuedPoolSize()
+		return uint64(q.TxsByGasPrice.len())+1 > config.GetQueuedPoolSize()
 	}
 
 	pickTxWithLowestGasPrice := func() *MemPoolTx {
-		return q.AscTxsByGasPrice.get()[0]
+		return q.TxsByGasPrice.first()
 	}
 
 	// For adding new tx into queued pool, always
 	// invoke this closure
 	addTx := func(tx *MemPoolTx) {
 
-		q.AscTxsByGasPrice = Insert(q.AscTxsByGasPrice, tx)
-		q.DescTxsByGasPrice = Insert(q.DescTxsByGasPrice, tx)
+		q.TxsByGasPrice.insert(tx)
 		q.TxsFromAddress[tx.From] = Insert(q.allocateFor(tx.From), tx)
 		q.Transactions[tx.Hash] = tx
+		metrics.QueuedTxs.Set(float64(len(q.Transactions)))
 
 	}
 
@@ -104,10 +124,37 @@ func (q *QueuedPool) Start(ctx context.Context) {
 	removeTx := func(tx *MemPoolTx) {
 
 		// Remove from sorted tx list, keep it sorted
-		q.AscTxsByGasPrice = Remove(q.AscTxsByGasPrice, tx)
-		q.DescTxsByGasPrice = Remove(q.DescTxsByGasPrice, tx)
+		q.TxsByGasPrice.remove(tx)
 		q.TxsFromAddress[tx.From] = Remove(q.TxsFromAddress[tx.From], tx)
+		// Sender index entry to be released as soon as last tx from
+		// that address leaves queued pool, so that it doesn't keep
+		// growing with every address ever seen
+		if txs := q.TxsFromAddress[tx.From]; txs == nil || txs.len() == 0 {
+			delete(q.TxsFromAddress, tx.From)
+		}
 		delete(q.Transactions, tx.Hash)
+		metrics.QueuedTxs.Set(float64(len(q.Transactions)))
+
+	}
+
+	// Registered addition & removal callbacks, invoked
+	// from this go routine, in order of addition/ removal
+	onAdd := make([]func(*MemPoolTx), 0)
+	onRemove := make([]func(*MemPoolTx, string), 0)
+
+	notifyAdded := func(tx *MemPoolTx) {
+
+		for _, cb := range onAdd {
+			cb(tx)
+		}
+
+	}
+
+	notifyRemoved := func(tx *MemPoolTx, reason string) {
+
+		for _, cb := range onRemove {
+			cb(tx, reason)
+		}
 
 	}
 
@@ -117,12 +164,78 @@ func (q *QueuedPool) Start(ctx context.Context) {
 	dropTx := func(tx *MemPoolTx) {
 
 		removeTx(tx)
+		notifyRemoved(tx, "dropped")
 		// Marking that tx has been dropped, so that
 		// it won't get picked up next time
 		q.DroppedTxs[tx.Hash] = time.Now().UTC()
 
 	}
 
+	// Tx is evicted, when pool is full/ its sender is at cap, for paying
+	// lowest gas price, letting subscribers know it didn't leave mempool
+	// on its own
+	evictTx := func(tx *MemPoolTx) {
+
+		if tx == nil {
+			return
+		}
+
+		tx.Pool = "dropped"
+		tx.DroppedAt = time.Now().UTC()
+
+		dropTx(tx)
+		q.PublishEvicted(ctx, tx)
+
+	}
+
+	// Single sender can't hold more than configured #-of tx(s) in pool,
+	// if it's already at cap, its lowest gas price paying tx is evicted,
+	// given new one pays more, otherwise new one is rejected
+	makeRoomForSender := func(tx *MemPoolTx) bool {
+
+		// Sender index is bounded, once full, new senders are
+		// not let in, until some existing one leaves
+		if _, ok := q.TxsFromAddress[tx.From]; !ok {
+
+			if max := config.GetMaxSenders(); max != 0 && uint64(len(q.TxsFromAddress)) >= max {
+
+				// Not remembered as dropped, so that it's let in on some
+				// later poll, once any sender's last tx leaves pool
+				limitedLog.Printf("[❗️] Queued pool at cap of %d senders, rejected %s\n", max, tx.Hash.Hex())
+				return false
+
+			}
+
+		}
+
+		limit := config.GetMaxTxsPerSender()
+		if limit == 0 {
+			return true
+		}
+
+		txs, ok := q.TxsFromAddress[tx.From]
+		if !ok || uint64(txs.len()) < limit {
+			return true
+		}
+
+		lowest := q.TxsByGasPrice.lowestOf(txs.get())
+		if lowest == nil || !q.TxsByGasPrice.outbids(tx, lowest) {
+
+			limitedLog.Printf("[❗️] Sender %s at cap of %d queued tx(s), rejected %s\n", tx.From.Hex(), limit, tx.Hash.Hex())
+
+			// Not to be considered again, every time it's seen in poll
+			q.DroppedTxs[tx.Hash] = time.Now().UTC()
+			return false
+
+		}
+
+		evictTx(lowest)
+		limitedLog.Printf("[➖] Sender %s at cap of %d queued tx(s), evicted %s\n", tx.From.Hex(), limit, lowest.Hash.Hex())
+
+		return true
+
+	}
+
 	txAdder := func(tx *MemPoolTx) bool {
 
 		if _, ok := q.Transactions[tx.Hash]; ok {
@@ -139,8 +252,12 @@ func (q *QueuedPool) Start(ctx context.Context) {
 			return false
 		}
 
+		if !makeRoomForSender(tx) {
+			return false
+		}
+
 		if needToDropTxs() {
-			dropTx(pickTxWithLowestGasPrice())
+			evictTx(pickTxWithLowestGasPrice())
 		}
 
 		// Marking we found this tx in mempool now
@@ -148,7 +265,10 @@ func (q *QueuedPool) Start(ctx context.Context) {
 		tx.Pool = "queued"
 
 		addTx(tx)
+		notifyAdded(tx)
+		q.reputations.queued(tx.From)
 		q.PublishAdded(ctx, tx)
+		q.Watchdog.Seen()
 
 		return true
 
@@ -164,6 +284,7 @@ func (q *QueuedPool) Start(ctx context.Context) {
 		tx.UnstuckAt = time.Now().UTC()
 
 		removeTx(tx)
+		notifyRemoved(tx, "unstuck")
 		q.PublishRemoved(ctx, tx)
 
 		return tx
@@ -180,6 +301,39 @@ func (q *QueuedPool) Start(ctx context.Context) {
 
 			req.ResponseChan <- txAdder(req.Tx)
 
+		case req := <-q.RestoreChan:
+
+			// Tx(s) read from snapshot are put back as they were, without
+			// publishing, they've already been seen by subscribers
+			var count int
+
+			for _, tx := range req.Txs {
+
+				if _, ok := q.Transactions[tx.Hash]; ok {
+					continue
+				}
+
+				if needToDropTxs() {
+					break
+				}
+
+				addTx(tx)
+				count++
+
+			}
+
+			req.ResponseChan <- count
+
+		case req := <-q.OnAddChan:
+
+			onAdd = append(onAdd, req.Callback)
+			req.ResponseChan <- true
+
+		case req := <-q.OnRemoveChan:
+
+			onRemove = append(onRemove, req.Callback)
+			req.ResponseChan <- true
+
 		case req := <-q.RemoveTxChan:
 
 			// if removed will return non-nil reference to removed tx
@@ -206,42 +360,41 @@ func (q *QueuedPool) Start(ctx context.Context) {
 
 			req.ResponseChan <- nil
 
-		case req := <-q.CountTxsChan:
-
-			req.ResponseChan <- uint64(q.AscTxsByGasPrice.len())
+		case req := <-q.SenderDominanceChan:
 
-		case req := <-q.ListTxsChan:
+			req <- dominanceOf(q.TxsFromAddress)
 
-			if req.Order == ASC {
+		case req := <-q.CountTxsChan:
 
-				// If empty, just return nil
-				if q.AscTxsByGasPrice.len() == 0 {
-					req.ResponseChan <- nil
-					break
-				}
+			if req.Filter == nil || req.Filter.IsEmpty() {
+				req.ResponseChan <- uint64(q.TxsByGasPrice.len())
+				break
+			}
 
-				copied := make([]*MemPoolTx, q.AscTxsByGasPrice.len())
-				copy(copied, q.AscTxsByGasPrice.get())
+			// Counted in place, without collecting them
+			var count uint64
+			for _, tx := range q.Transactions {
 
-				req.ResponseChan <- copied
-				break
+				if req.Filter.Matches(tx) {
+					count++
+				}
 
 			}
 
-			if req.Order == DESC {
+			req.ResponseChan <- count
 
-				// If empty, just return nil
-				if q.DescTxsByGasPrice.len() == 0 {
-					req.ResponseChan <- nil
-					break
-				}
+		case req := <-q.ListTxsChan:
 
-				copied := make([]*MemPoolTx, q.DescTxsByGasPrice.len())
-				copy(copied, q.DescTxsByGasPrice.get())
+			// If empty/ requested window is out of range,
+			// nil to be returned
+			req.ResponseChan <- q.TxsByGasPrice.window(req.Order, req.Offset, req.Limit)
 
-				req.ResponseChan <- copied
+		case req := <-q.TxsByGasPriceRangeChan:
+			// Only those txs which are paying gas price within
+			// requested range, found by seeking to lower bound in
+			// gas price ordered list
 
-			}
+			req.ResponseChan <- q.TxsByGasPrice.rangeByGasPrice(req.Min, req.Max)
 
 		case req := <-q.TxsFromAChan:
 
@@ -304,15 +457,44 @@ func (q *QueuedPool) Start(ctx context.Context) {
 // @note Start this method as an independent go routine
 func (q *QueuedPool) Prune(ctx context.Context, confirmedTxsChan chan ConfirmedTx, pendingTxsChan chan *MemPoolTx) {
 
-	// Creating worker pool, where jobs to be submitted
-	// for concurrently checking whether tx has been unstuck or not
-	// so that it can be moved to pending pool
-	wp := workerpool.New(config.GetConcurrencyFactor())
-	defer wp.Stop()
-
+	// Unstuck status is decided locally, from nonce of tx(s) seen
+	// getting mined/ joining pending pool. Before moving them into
+	// pending pool, nonce of each of their senders is looked up, only
+	// once for all tx(s) found unstuck together
 	internalChan := make(chan *TxStatus, 4096)
 	var unstuck uint64
 
+	// Tx(s) are marked unstuck, one after another, to be removed
+	// by this go routine itself, returning false if shutting down
+	markUnstuck := func(txs []*MemPoolTx) bool {
+
+		for i := 0; i < len(txs); i++ {
+
+			// Buffer being full must not block shutdown
+			select {
+			case <-ctx.Done():
+				return false
+			case internalChan <- &TxStatus{Hash: txs[i].Hash, Status: UNSTUCK}:
+			}
+
+		}
+
+		return true
+
+	}
+
+	// Scheduled sweeps run on this same go routine, so they never
+	// overlap with event driven pruning
+	var sweep <-chan time.Time
+	if interval := config.GetQueuedPruneInterval(); interval != 0 {
+
+		ticker := time.NewTicker(time.Duration(interval) * time.Millisecond)
+		defer ticker.Stop()
+
+		sweep = ticker.C
+
+	}
+
 	for {
 
 		select {
@@ -321,6 +503,11 @@ func (q *QueuedPool) Prune(ctx context.Context, confirmedTxsChan chan ConfirmedT
 			return
 
 		case mined := <-confirmedTxsChan:
+
+			if q.PrunePaused() {
+				break
+			}
+
 			// As soon as we learn a new tx got mined
 			// for which we've received txfrom & respective nonce
 			// we'll attempt to find out how many txs from same address
@@ -329,42 +516,162 @@ func (q *QueuedPool) Prune(ctx context.Context, confirmedTxsChan chan ConfirmedT
 			// If any, we'll attempt to go through all of those & see any of them
 			// unstuck or not, if yes we're going to attempt to mark it as
 			// unstuck
-			txs := q.TxsFromA(mined.From)
+			txs := q.TxsFromA(ctx, mined.From)
 			if txs == nil {
 				break
 			}
 
 			noGap := UntilNonceGap(txs, mined.Nonce)
 
-			for i := 0; i < len(noGap); i++ {
-				internalChan <- &TxStatus{Hash: noGap[i].Hash, Status: UNSTUCK}
+			if len(noGap) != 0 {
+				log.Printf("[❃] Triggered queued pool prune, by mined tx, found %d unstuck tx(s)\n", len(noGap))
+			}
+
+			if !markUnstuck(noGap) {
+				return
 			}
 
 			CleanSlice(txs)
 			CleanSlice(noGap)
 
 		case pending := <-pendingTxsChan:
+
+			if q.PrunePaused() {
+				break
+			}
+
 			// This new tx was added into pending pool
 			// and pending pool is letting us know about it so that
 			// we can remove all nonce gapless txs, sent from this user
 
-			txs := q.TxsFromA(pending.From)
+			txs := q.TxsFromA(ctx, pending.From)
 			if txs == nil {
 				break
 			}
 
 			noGap := UntilNonceGap(txs, pending.Nonce)
 
-			for i := 0; i < len(noGap); i++ {
-				internalChan <- &TxStatus{Hash: noGap[i].Hash, Status: UNSTUCK}
+			if len(noGap) != 0 {
+				log.Printf("[❃] Triggered queued pool prune, by pending tx, found %d unstuck tx(s)\n", len(noGap))
+			}
+
+			if !markUnstuck(noGap) {
+				return
 			}
 
 			CleanSlice(txs)
 			CleanSlice(noGap)
 
+		case <-sweep:
+
+			if q.PrunePaused() {
+				break
+			}
+
+			// Events may get missed, so whole pool is checked against
+			// pending pool, in same way, once in a while
+			var found int
+
+			all := q.AscListTxs(ctx)
+			senders := make(map[common.Address]struct{})
+
+			for _, tx := range all {
+				senders[tx.From] = struct{}{}
+			}
+
+			CleanSlice(all)
+
+			for from := range senders {
+
+				// Both are ordered by nonce, highest pending
+				// one is last
+				pendingTxs := q.PendingPool.TxsFromA(ctx, from)
+				if len(pendingTxs) == 0 {
+					continue
+				}
+
+				txs := q.TxsFromA(ctx, from)
+				noGap := UntilNonceGap(txs, pendingTxs[len(pendingTxs)-1].Nonce)
+
+				// This go routine itself drains buffer, so it must
+				// not be overfilled, rest are left for next sweep
+				if len(noGap) > cap(internalChan)-len(internalChan) {
+					break
+				}
+
+				found += len(noGap)
+
+				if !markUnstuck(noGap) {
+					return
+				}
+
+				CleanSlice(txs)
+				CleanSlice(noGap)
+				CleanSlice(pendingTxs)
+
+			}
+
+			if found != 0 {
+				log.Printf("[❃] Scheduled queued pool prune found %d unstuck tx(s)\n", found)
+			}
+
+		case <-q.CancelPruneChan:
+			// Tx(s) already marked unstuck, but not yet moved into
+			// pending pool, are forgotten, they'll be considered
+			// again on next trigger/ sweep, if still unstuck
+			var discarded int
+
+		DRAIN:
+			for {
+
+				select {
+				case <-internalChan:
+					discarded++
+				default:
+					break DRAIN
+				}
+
+			}
+
+			log.Printf("[❃] Cancelled queued pool prune, discarded %d unstuck tx(s)\n", discarded)
+
 		case txStat := <-internalChan:
 
-			if txStat.Status == UNSTUCK {
+			// Shutting down, nothing more to be removed
+			// from/ added into any pool
+			if ctx.Err() != nil {
+				return
+			}
+
+			// All tx(s) marked unstuck by now are taken out together,
+			// so that their senders' nonces get looked up in one go
+			stats := []*TxStatus{txStat}
+
+		COLLECT:
+			for {
+
+				select {
+				case txStat := <-internalChan:
+					stats = append(stats, txStat)
+				default:
+					break COLLECT
+				}
+
+			}
+
+			removed := make([]*MemPoolTx, 0, len(stats))
+
+			for _, txStat := range stats {
+
+				if txStat.Status != UNSTUCK {
+					continue
+				}
+
+				// Paused after being marked unstuck, it stays
+				// in queued pool
+				if q.PrunePaused() {
+					continue
+				}
 
 				// Removing unstuck tx
 				tx := q.Remove(ctx, txStat.Hash)
@@ -374,11 +681,10 @@ func (q *QueuedPool) Prune(ctx context.Context, confirmedTxsChan chan ConfirmedT
 					continue
 				}
 
-				unstuck++
+				removed = append(removed, tx)
 
-				// Just check whether we need to add this tx into pending
-				// pool first, if not required, we're not adding it
-				q.PendingPool.VerifiedAdd(ctx, tx)
+				unstuck++
+				q.reputations.unstuck(tx.From)
 
 				if unstuck%10 == 0 {
 					log.Printf("[➖] Removed 10 tx(s) from queued tx pool\n")
@@ -386,6 +692,12 @@ func (q *QueuedPool) Prune(ctx context.Context, confirmedTxsChan chan ConfirmedT
 
 			}
 
+			// Just check whether we need to add these tx(s) into pending
+			// pool first, if not required, we're not adding them
+			if added := q.PendingPool.VerifiedAddAll(ctx, removed); added != 0 {
+				metrics.UnstuckTxs.Add(float64(added))
+			}
+
 		}
 
 	}
@@ -394,36 +706,120 @@ func (q *QueuedPool) Prune(ctx context.Context, confirmedTxsChan chan ConfirmedT
 
 // Get - Given tx hash, attempts to find out tx in queued pool, if any
 //
-// Returns nil, if found nothing
-func (q *QueuedPool) Get(hash common.Hash) *MemPoolTx {
+// Returns nil, if found nothing.
+//
+// @note All pool operations taking `ctx` give up & return zero value, as soon as
+// `ctx` is done, so that callers don't hang when pool worker is unresponsive
+func (q *QueuedPool) Get(ctx context.Context, hash common.Hash) *MemPoolTx {
+
+	q.owner.enter()
 
-	respChan := make(chan *MemPoolTx)
+	respChan := make(chan *MemPoolTx, 1)
 
-	q.GetTxChan <- GetRequest{Tx: hash, ResponseChan: respChan}
+	// Pool may have stopped serving requests, so don't
+	// wait beyond what caller is willing to
+	select {
+	case <-ctx.Done():
+		return nil
+	case q.GetTxChan <- GetRequest{Tx: hash, ResponseChan: respChan}:
+	}
 
-	return <-respChan
+	select {
+	case <-ctx.Done():
+		return nil
+	case v := <-respChan:
+		return v
+	}
 
 }
 
 // Exists - Checks whether tx of given hash exists on queued pool or not
-func (q *QueuedPool) Exists(hash common.Hash) bool {
+func (q *QueuedPool) Exists(ctx context.Context, hash common.Hash) bool {
+
+	q.owner.enter()
+
+	respChan := make(chan bool, 1)
+
+	select {
+	case <-ctx.Done():
+		return false
+	case q.TxExistsChan <- ExistsRequest{Tx: hash, ResponseChan: respChan}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case v := <-respChan:
+		return v
+	}
+
+}
+
+// SenderDominance - Largest share of queued pool held by single sender, by
+// #-of tx(s) & by gas limit
+func (q *QueuedPool) SenderDominance(ctx context.Context) SenderDominance {
+
+	respChan := make(chan SenderDominance, 1)
+
+	select {
+	case <-ctx.Done():
+		return SenderDominance{}
+	case q.SenderDominanceChan <- respChan:
+	}
+
+	select {
+	case <-ctx.Done():
+		return SenderDominance{}
+	case v := <-respChan:
+		return v
+	}
+
+}
+
+// CountMatching - #-of queued tx(s) satisfying given filter, counted in
+// single pass over pool, by pool itself, without collecting them
+//
+// @note Empty filter is same as `Count`
+func (q *QueuedPool) CountMatching(ctx context.Context, filter TxFilter) uint64 {
+
+	q.owner.enter()
 
-	respChan := make(chan bool)
+	respChan := make(chan uint64, 1)
 
-	q.TxExistsChan <- ExistsRequest{Tx: hash, ResponseChan: respChan}
+	select {
+	case <-ctx.Done():
+		return 0
+	case q.CountTxsChan <- CountRequest{Filter: &filter, ResponseChan: respChan}:
+	}
 
-	return <-respChan
+	select {
+	case <-ctx.Done():
+		return 0
+	case v := <-respChan:
+		return v
+	}
 
 }
 
 // Count - How many tx(s) currently present in pending pool
-func (q *QueuedPool) Count() uint64 {
+func (q *QueuedPool) Count(ctx context.Context) uint64 {
 
-	respChan := make(chan uint64)
+	q.owner.enter()
 
-	q.CountTxsChan <- CountRequest{ResponseChan: respChan}
+	respChan := make(chan uint64, 1)
+
+	select {
+	case <-ctx.Done():
+		return 0
+	case q.CountTxsChan <- CountRequest{ResponseChan: respChan}:
+	}
 
-	return <-respChan
+	select {
+	case <-ctx.Done():
+		return 0
+	case v := <-respChan:
+		return v
+	}
 
 }
 
@@ -435,14 +831,14 @@ func (q *QueuedPool) Count() uint64 {
 //
 // Considering one tx duplicate of given one, if this tx has same
 // nonce & sender address, as of given ones
-func (q *QueuedPool) DuplicateTxs(hash common.Hash) []*MemPoolTx {
+func (q *QueuedPool) DuplicateTxs(ctx context.Context, hash common.Hash) []*MemPoolTx {
 
-	targetTx := q.Get(hash)
+	targetTx := q.Get(ctx, hash)
 	if targetTx == nil {
 		return nil
 	}
 
-	txs := q.TxsFromA(targetTx.From)
+	txs := q.TxsFromA(ctx, targetTx.From)
 	if txs == nil {
 		return nil
 	}
@@ -505,78 +901,425 @@ func (q *QueuedPool) DuplicateTxs(hash common.Hash) []*MemPoolTx {
 }
 
 // AscListTxs - Returns all tx(s) present in queued pool, as slice, ascending ordered as per gas price paid
-func (q *QueuedPool) AscListTxs() []*MemPoolTx {
+func (q *QueuedPool) AscListTxs(ctx context.Context) []*MemPoolTx {
+
+	q.owner.enter()
 
-	respChan := make(chan []*MemPoolTx)
+	respChan := make(chan []*MemPoolTx, 1)
 
-	q.ListTxsChan <- ListRequest{ResponseChan: respChan, Order: ASC}
+	select {
+	case <-ctx.Done():
+		return nil
+	case q.ListTxsChan <- ListRequest{ResponseChan: respChan, Order: ASC}:
+	}
 
-	return <-respChan
+	select {
+	case <-ctx.Done():
+		return nil
+	case v := <-respChan:
+		return v
+	}
 
 }
 
 // DescListTxs - Returns all tx(s) present in queued pool, as slice, descending ordered as per gas price paid
-func (q *QueuedPool) DescListTxs() []*MemPoolTx {
+func (q *QueuedPool) DescListTxs(ctx context.Context) []*MemPoolTx {
+
+	q.owner.enter()
+
+	respChan := make(chan []*MemPoolTx, 1)
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case q.ListTxsChan <- ListRequest{ResponseChan: respChan, Order: DESC}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case v := <-respChan:
+		return v
+	}
+
+}
+
+// SortedListTxs - Returns all tx(s) present in queued pool, as slice, ordered as per
+// given multi-key sort specification i.e. `gasPrice desc, nonce asc`
+func (q *QueuedPool) SortedListTxs(ctx context.Context, spec string) ([]*MemPoolTx, error) {
+
+	keys, err := ParseSortSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := q.AscListTxs(ctx)
+	SortTxs(txs, keys, nil)
+
+	return txs, nil
+
+}
+
+// listWindow - Returns window of tx(s) present in queued pool, ordered as per gas price
+// paid, starting at `offset` & spanning at max `limit` entries ( 0 denotes no cap )
+func (q *QueuedPool) listWindow(ctx context.Context, order int, offset uint64, limit uint64) []*MemPoolTx {
+
+	respChan := make(chan []*MemPoolTx, 1)
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case q.ListTxsChan <- ListRequest{ResponseChan: respChan, Order: order, Offset: offset, Limit: limit}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case v := <-respChan:
+		return v
+	}
+
+}
+
+// ListTxsPaged - Returns one page of tx(s) present in queued pool, ordered as per
+// gas price paid, starting at `offset` & spanning at max `limit` entries, where
+// 0 `limit` denotes no cap. Only requested window is copied out of pool
+//
+// @note Negative/ out of range `offset` results in empty slice
+func (q *QueuedPool) ListTxsPaged(ctx context.Context, order int, offset int, limit int) []*MemPoolTx {
 
-	respChan := make(chan []*MemPoolTx)
+	if offset < 0 || limit < 0 {
+		return []*MemPoolTx{}
+	}
 
-	q.ListTxsChan <- ListRequest{ResponseChan: respChan, Order: DESC}
+	txs := q.listWindow(ctx, order, uint64(offset), uint64(limit))
+	if txs == nil {
+		return []*MemPoolTx{}
+	}
 
-	return <-respChan
+	return txs
+
+}
+
+// StreamTopX - Streams top `X` tx(s) present in queued pool, ordered as per gas price
+// paid, while only copying small chunks out of pool at a time, so that memory usage
+// stays bounded, irrespective of `X`
+func (q *QueuedPool) StreamTopX(ctx context.Context, order int, x uint64) <-chan *MemPoolTx {
+
+	return StreamTxs(ctx, x, func(ctx context.Context, offset uint64, limit uint64) []*MemPoolTx {
+		return q.listWindow(ctx, order, offset, limit)
+	})
 
 }
 
 // TxsFromA - Returns a slice of txs, where all of those are sent
 // by address `A`
-func (q *QueuedPool) TxsFromA(addr common.Address) []*MemPoolTx {
+func (q *QueuedPool) TxsFromA(ctx context.Context, addr common.Address) []*MemPoolTx {
 
-	respChan := make(chan []*MemPoolTx)
+	q.owner.enter()
 
-	q.TxsFromAChan <- TxsFromARequest{ResponseChan: respChan, From: addr}
+	respChan := make(chan []*MemPoolTx, 1)
 
-	return <-respChan
+	select {
+	case <-ctx.Done():
+		return nil
+	case q.TxsFromAChan <- TxsFromARequest{ResponseChan: respChan, From: addr}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case v := <-respChan:
+		return v
+	}
 
 }
 
 // TopXWithHighGasPrice - Returns only top `X` tx(s) present in queued mempool,
 // where being top is determined by how much gas price paid by tx sender
-func (q *QueuedPool) TopXWithHighGasPrice(x uint64) []*MemPoolTx {
+func (q *QueuedPool) TopXWithHighGasPrice(ctx context.Context, x uint64) []*MemPoolTx {
+
+	// Window with 0 limit isn't capped, so asking for
+	// none needs to be answered here
+	if x == 0 {
+		return []*MemPoolTx{}
+	}
 
-	txs := q.DescListTxs()
-	if uint64(len(txs)) <= x {
-		return txs
+	// Only top `X` to be copied out of pool, clamped
+	// to what's actually available
+	txs := q.listWindow(ctx, DESC, 0, x)
+	if txs == nil {
+		return []*MemPoolTx{}
 	}
 
-	CleanSlice(txs[x:])
-	return txs[:x]
+	return txs
 
 }
 
 // TopXWithLowGasPrice - Returns only top `X` tx(s) present in queued mempool,
 // where being top is determined by how low gas price paid by tx sender
-func (q *QueuedPool) TopXWithLowGasPrice(x uint64) []*MemPoolTx {
+func (q *QueuedPool) TopXWithLowGasPrice(ctx context.Context, x uint64) []*MemPoolTx {
 
-	txs := q.AscListTxs()
-	if uint64(len(txs)) <= x {
-		return txs
+	// Window with 0 limit isn't capped, so asking for
+	// none needs to be answered here
+	if x == 0 {
+		return []*MemPoolTx{}
+	}
+
+	// Only top `X` to be copied out of pool, clamped
+	// to what's actually available
+	txs := q.listWindow(ctx, ASC, 0, x)
+	if txs == nil {
+		return []*MemPoolTx{}
 	}
 
-	CleanSlice(txs[x:])
-	return txs[:x]
+	return txs
 
 }
 
 // SentFrom - Returns a list of queued tx(s) sent from
 // specified address
-func (q *QueuedPool) SentFrom(address common.Address) []*MemPoolTx {
-	return q.TxsFromA(address)
+//
+// @note This is a direct lookup in per sender index, maintained
+// by pool on every add/ remove, no scanning involved
+func (q *QueuedPool) SentFrom(ctx context.Context, address common.Address) []*MemPoolTx {
+	return q.TxsFromA(ctx, address)
+}
+
+// CancelPrune - Pauses queued pool pruner & asks it to discard tx(s) it has
+// marked unstuck, but not yet moved into pending pool, for when operator
+// knows they were marked so, based on bad state
+//
+// Once this returns, no more tx is moved into pending pool, until pruner is
+// resumed, tx(s) getting unstuck meanwhile are picked up by next trigger/
+// sweep after that.
+//
+// @note This is non-blocking call, cancellation already asked for, but not
+// yet served, covers this one too
+func (q *QueuedPool) CancelPrune() {
+
+	q.owner.enter()
+
+	atomic.StoreUint32(&q.prunePaused, 1)
+
+	select {
+	case q.CancelPruneChan <- struct{}{}:
+	default:
+	}
+
+}
+
+// ResumePrune - Lets queued pool pruner move unstuck tx(s) into pending
+// pool again, after being paused by cancellation
+func (q *QueuedPool) ResumePrune() {
+
+	atomic.StoreUint32(&q.prunePaused, 0)
+
+}
+
+// PrunePaused - Whether queued pool pruner is paused or not
+func (q *QueuedPool) PrunePaused() bool {
+
+	return atomic.LoadUint32(&q.prunePaused) == 1
+
+}
+
+// GetSenderStats - How many tx(s) of sender got into queued pool & how
+// many of them later got unstuck, over `harmony`'s life time
+func (q *QueuedPool) GetSenderStats(addr common.Address) (queuedCount uint64, unstuckCount uint64) {
+
+	rep := q.reputations.get(addr)
+	return rep.Queued, rep.Unstuck
+
+}
+
+// SenderReputationScore - Share of sender's queued tx(s) which got unstuck,
+// in [0, 1], where chronically nonce gapping sender scores close to 0
+func (q *QueuedPool) SenderReputationScore(addr common.Address) float64 {
+
+	return q.reputations.get(addr).Score()
+
+}
+
+// LowestNonceQueuedPerSender - Lowest nonce queued tx of each sender, having
+// tx(s) living in queued pool, which is likely one blocking rest of them
+func (q *QueuedPool) LowestNonceQueuedPerSender(ctx context.Context) map[common.Address]*MemPoolTx {
+
+	txs := q.DescListTxs(ctx)
+	if txs == nil {
+		return map[common.Address]*MemPoolTx{}
+	}
+
+	lowest := make(map[common.Address]*MemPoolTx)
+
+	for _, tx := range txs {
+
+		if v, ok := lowest[tx.From]; !ok || tx.Nonce < v.Nonce {
+			lowest[tx.From] = tx
+		}
+
+	}
+
+	CleanSlice(txs)
+	return lowest
+
+}
+
+// NonceGaps - Returns ranges of missing nonces, between lowest & highest
+// nonce of tx(s) sent from specified address, living in queued pool now
+//
+// These are the nonces, which need to be filled up, for unsticking
+// backlog of this account. Each gap is reported as one range, so that
+// output is bounded by #-of tx(s), no matter how wide the gap is.
+//
+// @note Returns nil, if no tx from this address is present in queued pool
+func (q *QueuedPool) NonceGaps(ctx context.Context, address common.Address) []NonceRange {
+
+	// Tx(s) from same sender are kept sorted
+	// ( ascending ) as per nonce
+	txs := q.SentFrom(ctx, address)
+	if txs == nil {
+		return nil
+	}
+
+	gaps := make([]NonceRange, 0)
+
+	for i := 1; i < len(txs); i++ {
+
+		lo, hi := uint64(txs[i-1].Nonce), uint64(txs[i].Nonce)
+
+		// Same nonce/ consecutive ones, nothing missing in between
+		if hi <= lo || hi-lo == 1 {
+			continue
+		}
+
+		gaps = append(gaps, NonceRange{Lo: lo + 1, Hi: hi - 1})
+
+	}
+
+	CleanSlice(txs)
+	return gaps
+
+}
+
+// MissingNonces - Same as `NonceGaps`, but each missing nonce is listed on
+// its own, in ascending order, at max `limit` of them, because single gap
+// can be arbitrarily wide
+//
+// @note Returns nil, if no tx from this address is present in queued pool
+func (q *QueuedPool) MissingNonces(ctx context.Context, address common.Address, limit uint64) []uint64 {
+
+	gaps := q.NonceGaps(ctx, address)
+	if gaps == nil {
+		return nil
+	}
+
+	nonces := make([]uint64, 0)
+
+	for _, gap := range gaps {
+
+		for nonce := gap.Lo; nonce <= gap.Hi; nonce++ {
+
+			if uint64(len(nonces)) >= limit {
+				return nonces
+			}
+
+			nonces = append(nonces, nonce)
+
+		}
+
+	}
+
+	return nonces
+
+}
+
+// UnstuckableBy - Returns queued tx(s) from same sender, which will become
+// eligible for moving to pending pool, once `pendingTx` lands there, because
+// nonce gap in front of them gets filled up
+//
+// Useful for predicting cascade of unsticks, before it happens.
+//
+// @note Same nonce gap logic is used by queued pool pruner, when it's let
+// known about tx added into pending pool
+func (q *QueuedPool) UnstuckableBy(ctx context.Context, pendingTx *MemPoolTx) []*MemPoolTx {
+
+	txs := q.TxsFromA(ctx, pendingTx.From)
+	if txs == nil {
+		return []*MemPoolTx{}
+	}
+
+	noGap := UntilNonceGap(txs, pendingTx.Nonce)
+
+	CleanSlice(txs)
+	return noGap
+
+}
+
+// EstimateUnstuckETA - Heuristic estimate of how long it'll take for given
+// queued tx to enter pending pool, i.e. #-of nonces missing in front of it
+// times observed average time between blocks
+//
+// Nonces are counted missing from next one after sender's highest pending
+// nonce. If sender has no pending tx, at least one nonce before sender's
+// lowest queued one must be missing, otherwise it wouldn't be queued.
+//
+// @note Returns zero if tx is already contiguous, not found in queued pool
+// or not enough blocks have been seen yet
+func (q *QueuedPool) EstimateUnstuckETA(ctx context.Context, hash common.Hash) time.Duration {
+
+	tx := q.Get(context.Background(), hash)
+	if tx == nil {
+		return 0
+	}
+
+	// Both are sorted ( ascending ) as per nonce
+	queued := q.TxsFromA(ctx, tx.From)
+	if queued == nil {
+		return 0
+	}
+
+	var missing uint64
+
+	next := queued[0].Nonce
+	if pending := q.PendingPool.TxsFromA(ctx, tx.From); len(pending) != 0 {
+
+		next = pending[len(pending)-1].Nonce + 1
+		CleanSlice(pending)
+
+	} else {
+		missing++
+	}
+
+	for _, v := range queued {
+
+		if v.Nonce >= tx.Nonce {
+			break
+		}
+
+		if v.Nonce < next {
+			continue
+		}
+
+		missing += uint64(v.Nonce - next)
+		next = v.Nonce + 1
+
+	}
+
+	if tx.Nonce > next {
+		missing += uint64(tx.Nonce - next)
+	}
+
+	CleanSlice(queued)
+	return time.Duration(missing) * q.PendingPool.GetLastSeenBlock(ctx).Interval
+
 }
 
 // SentTo - Returns a list of queued tx(s) sent to
 // specified address
-func (q *QueuedPool) SentTo(address common.Address) []*MemPoolTx {
+func (q *QueuedPool) SentTo(ctx context.Context, address common.Address) []*MemPoolTx {
 
-	txs := q.DescListTxs()
+	txs := q.DescListTxs(ctx)
 	if txs == nil {
 		return nil
 	}
@@ -634,11 +1377,89 @@ func (q *QueuedPool) SentTo(address common.Address) []*MemPoolTx {
 
 }
 
+// scan - Concurrently goes over all queued tx(s), returning those
+// for which `pred` holds
+func (q *QueuedPool) scan(ctx context.Context, pred func(*MemPoolTx) bool) []*MemPoolTx {
+
+	txs := q.DescListTxs(ctx)
+	if txs == nil {
+		return []*MemPoolTx{}
+	}
+
+	txCount := uint64(len(txs))
+	commChan := make(chan *MemPoolTx, txCount)
+	result := make([]*MemPoolTx, 0, txCount)
+
+	wp := workerpool.New(runtime.NumCPU())
+
+	for i := 0; i < len(txs); i++ {
+
+		func(tx *MemPoolTx) {
+
+			wp.Submit(func() {
+
+				if pred(tx) {
+					commChan <- tx
+					return
+				}
+
+				commChan <- nil
+
+			})
+
+		}(txs[i])
+
+	}
+
+	var received uint64
+	mustReceive := txCount
+
+	// Waiting for all go routines to finish
+	for v := range commChan {
+
+		if v != nil {
+			result = append(result, v)
+		}
+
+		received++
+		if received >= mustReceive {
+			break
+		}
+
+	}
+
+	wp.Stop()
+	CleanSlice(txs)
+
+	return result
+
+}
+
+// TxsByValueRange - Returns a list of queued tx(s), transferring value
+// within [`min`, `max`] ( in Wei ), nil bound denoting unbounded side
+func (q *QueuedPool) TxsByValueRange(ctx context.Context, min *big.Int, max *big.Int) []*MemPoolTx {
+
+	return q.scan(ctx, func(tx *MemPoolTx) bool {
+		return tx.HasValueWithin(min, max)
+	})
+
+}
+
+// TxsByType - Returns a list of queued tx(s) of given EIP-2718 type i.e.
+// 0 for legacy, 1 for access list & 2 for dynamic fee tx
+func (q *QueuedPool) TxsByType(ctx context.Context, t uint8) []*MemPoolTx {
+
+	return q.scan(ctx, func(tx *MemPoolTx) bool {
+		return tx.IsOfType(t)
+	})
+
+}
+
 // OlderThanX - Returns a list of all queued tx(s), which are
 // living in mempool for more than or equals to `X` time unit
-func (q *QueuedPool) OlderThanX(x time.Duration) []*MemPoolTx {
+func (q *QueuedPool) OlderThanX(ctx context.Context, x time.Duration) []*MemPoolTx {
 
-	txs := q.DescListTxs()
+	txs := q.DescListTxs(ctx)
 	if txs == nil {
 		return nil
 	}
@@ -698,9 +1519,9 @@ func (q *QueuedPool) OlderThanX(x time.Duration) []*MemPoolTx {
 
 // FresherThanX - Returns a list of all queued tx(s), which are
 // living in mempool for less than or equals to `X` time unit
-func (q *QueuedPool) FresherThanX(x time.Duration) []*MemPoolTx {
+func (q *QueuedPool) FresherThanX(ctx context.Context, x time.Duration) []*MemPoolTx {
 
-	txs := q.DescListTxs()
+	txs := q.DescListTxs(ctx)
 	if txs == nil {
 		return nil
 	}
@@ -760,8 +1581,8 @@ func (q *QueuedPool) FresherThanX(x time.Duration) []*MemPoolTx {
 
 // HigherThanX - Returns a list of queued txs which are paid with
 // gas price >= `X`
-func (q *QueuedPool) HigherThanX(x float64) []*MemPoolTx {
-	txs := q.DescListTxs()
+func (q *QueuedPool) HigherThanX(ctx context.Context, x float64) []*MemPoolTx {
+	txs := q.DescListTxs(ctx)
 	if txs == nil {
 		return nil
 	}
@@ -772,8 +1593,8 @@ func (q *QueuedPool) HigherThanX(x float64) []*MemPoolTx {
 	for i := 0; i < len(txs); i++ {
 		// Stop ASAP, because iterating over
 		// descending sorted ( w.r.t. gas price )
-		// tx list
-		if !txs[i].HasGasPriceMoreThan(x) {
+		// tx list, which is ordered without base fee
+		if !txs[i].HasGasPriceMoreThan(x, nil) {
 			break
 		}
 
@@ -786,8 +1607,8 @@ func (q *QueuedPool) HigherThanX(x float64) []*MemPoolTx {
 
 // LowerThanX - Returns a list of queued txs which are paid with
 // gas price <= `X`
-func (q *QueuedPool) LowerThanX(x float64) []*MemPoolTx {
-	txs := q.AscListTxs()
+func (q *QueuedPool) LowerThanX(ctx context.Context, x float64) []*MemPoolTx {
+	txs := q.AscListTxs(ctx)
 	if txs == nil {
 		return nil
 	}
@@ -798,8 +1619,8 @@ func (q *QueuedPool) LowerThanX(x float64) []*MemPoolTx {
 	for i := 0; i < len(txs); i++ {
 		// Stop ASAP, because iterating over
 		// ascending sorted ( w.r.t. gas price )
-		// tx list
-		if !txs[i].HasGasPriceLessThan(x) {
+		// tx list, which is ordered without base fee
+		if !txs[i].HasGasPriceLessThan(x, nil) {
 			break
 		}
 
@@ -810,6 +1631,27 @@ func (q *QueuedPool) LowerThanX(x float64) []*MemPoolTx {
 	return result
 }
 
+// TxsByGasPriceRange - Returns a list of queued txs which are paid with
+// gas price within [`min`, `max`] ( in Gwei ), where nil denotes unbounded
+func (q *QueuedPool) TxsByGasPriceRange(ctx context.Context, min *big.Int, max *big.Int) []*MemPoolTx {
+
+	respChan := make(chan []*MemPoolTx, 1)
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case q.TxsByGasPriceRangeChan <- GasPriceRangeRequest{Min: GweiToWei(min), Max: GweiToWei(max), ResponseChan: respChan}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case v := <-respChan:
+		return v
+	}
+
+}
+
 // Add - Attempts to add new tx found in pending pool into
 // harmony mempool, so that further manipulation can be performed on it
 //
@@ -817,11 +1659,22 @@ func (q *QueuedPool) LowerThanX(x float64) []*MemPoolTx {
 // because this tx is already present in pending pool
 func (q *QueuedPool) Add(ctx context.Context, tx *MemPoolTx) bool {
 
-	respChan := make(chan bool)
+	q.owner.enter()
+
+	respChan := make(chan bool, 1)
 
-	q.AddTxChan <- AddRequest{Tx: tx, ResponseChan: respChan}
+	select {
+	case <-ctx.Done():
+		return false
+	case q.AddTxChan <- AddRequest{Tx: tx, ResponseChan: respChan}:
+	}
 
-	return <-respChan
+	select {
+	case <-ctx.Done():
+		return false
+	case v := <-respChan:
+		return v
+	}
 
 }
 
@@ -829,17 +1682,26 @@ func (q *QueuedPool) Add(ctx context.Context, tx *MemPoolTx) bool {
 // to pubsub topic
 func (q *QueuedPool) PublishAdded(ctx context.Context, msg *MemPoolTx) {
 
-	data, err := msg.ToMessagePack()
+	if !config.GetQueuedTxEntryPublishChoice() {
+		return
+	}
+
+	topic := config.GetQueuedTxEntryPublishTopic()
+	if !q.published.shouldPublish(msg.Hash, topic, time.Duration(config.GetPublishDedupWindow())*time.Millisecond) {
+		return
+	}
+
+	data, err := msg.ToEvent(config.GetQueuedEventSchemaVersion())
 	if err != nil {
-		log.Printf("[❗️] Failed to serialize into messagepack : %s\n", err.Error())
+		limitedLog.Printf("[❗️] Failed to serialize into messagepack : %s\n", err.Error())
 		return
 	}
 
 	if _, err := q.PubSub.Publish(&ops.Msg{
-		Topics: []string{config.GetQueuedTxEntryPublishTopic()},
+		Topics: TopicsFor(topic, ENTRY, msg),
 		Data:   data,
 	}); err != nil {
-		log.Printf("[❗️] Failed to publish tx joining queued pool : %s\n", err.Error())
+		limitedLog.Printf("[❗️] Failed to publish tx joining queued pool : %s\n", err.Error())
 	}
 
 }
@@ -847,11 +1709,80 @@ func (q *QueuedPool) PublishAdded(ctx context.Context, msg *MemPoolTx) {
 // Remove - Removes unstuck tx from queued pool
 func (q *QueuedPool) Remove(ctx context.Context, txHash common.Hash) *MemPoolTx {
 
-	respChan := make(chan *MemPoolTx)
+	q.owner.enter()
+
+	respChan := make(chan *MemPoolTx, 1)
+
+	// Pool stops serving requests once shut down, so
+	// don't wait forever for it to pick this one up
+	select {
+	case <-ctx.Done():
+		return nil
+	case q.RemoveTxChan <- RemovedUnstuckTx{Hash: txHash, ResponseChan: respChan}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case v := <-respChan:
+		return v
+	}
+
+}
+
+// OnAdd - Registers callback to be invoked for every tx joining queued pool,
+// in same order as they're added
+//
+// @note Callback is invoked from pool's own go routine, so it must be fast &
+// non-blocking, also it must not call back into pool, otherwise it'll deadlock
+func (q *QueuedPool) OnAdd(ctx context.Context, cb func(*MemPoolTx)) bool {
+
+	q.owner.enter()
+
+	respChan := make(chan bool, 1)
+
+	select {
+	case <-ctx.Done():
+		return false
+	case q.OnAddChan <- OnAddRequest{Callback: cb, ResponseChan: respChan}:
+	}
+
+	// Request is already with pool, so callback will get registered,
+	// even if caller stops waiting for acknowledgement
+	select {
+	case <-ctx.Done():
+		return true
+	case <-respChan:
+		return true
+	}
+
+}
+
+// OnRemove - Registers callback to be invoked for every tx leaving queued pool,
+// along with reason i.e. `unstuck`/ `dropped`, in same order as they're removed
+//
+// @note Callback is invoked from pool's own go routine, so it must be fast &
+// non-blocking, also it must not call back into pool, otherwise it'll deadlock
+func (q *QueuedPool) OnRemove(ctx context.Context, cb func(*MemPoolTx, string)) bool {
+
+	q.owner.enter()
+
+	respChan := make(chan bool, 1)
 
-	q.RemoveTxChan <- RemovedUnstuckTx{Hash: txHash, ResponseChan: respChan}
+	select {
+	case <-ctx.Done():
+		return false
+	case q.OnRemoveChan <- OnRemoveRequest{Callback: cb, ResponseChan: respChan}:
+	}
 
-	return <-respChan
+	// Request is already with pool, so callback will get registered,
+	// even if caller stops waiting for acknowledgement
+	select {
+	case <-ctx.Done():
+		return true
+	case <-respChan:
+		return true
+	}
 
 }
 
@@ -863,17 +1794,60 @@ func (q *QueuedPool) Remove(ctx context.Context, txHash common.Hash) *MemPoolTx
 // failed to keep track of it
 func (q *QueuedPool) PublishRemoved(ctx context.Context, msg *MemPoolTx) {
 
-	data, err := msg.ToMessagePack()
+	// Subscribers must never receive garbage, when removed
+	// tx reference couldn't be captured
+	if msg == nil {
+		log.Printf("[❗️] Attempted to publish nil tx leaving queued pool\n")
+		return
+	}
+
+	if !config.GetQueuedTxExitPublishChoice() {
+		return
+	}
+
+	topic := config.GetQueuedTxExitPublishTopic()
+	if !q.published.shouldPublish(msg.Hash, topic, time.Duration(config.GetPublishDedupWindow())*time.Millisecond) {
+		return
+	}
+
+	data, err := msg.ToEvent(config.GetQueuedEventSchemaVersion())
+	if err != nil {
+		limitedLog.Printf("[❗️] Failed to serialize into messagepack : %s\n", err.Error())
+		return
+	}
+
+	topics := TopicsFor(topic, EXIT, msg)
+
+	if err := publishRemoval(q.PubSub, msg, data, "unstuck", msg.UnstuckAt, topics[:1:1], topics[1:], config.GetQueuedTxExitFullPublishTopic()); err != nil {
+		limitedLog.Printf("[❗️] Failed to publish tx leaving queued pool : %s\n", err.Error())
+	}
+
+}
+
+// PublishEvicted - Publish tx evicted from queued pool, because pool/ its
+// sender was at cap & it was paying lowest gas price, to pubsub topic
+func (q *QueuedPool) PublishEvicted(ctx context.Context, msg *MemPoolTx) {
+
+	if !config.GetQueuedTxEvictedPublishChoice() {
+		return
+	}
+
+	topic := config.GetQueuedTxEvictedPublishTopic()
+	if !q.published.shouldPublish(msg.Hash, topic, time.Duration(config.GetPublishDedupWindow())*time.Millisecond) {
+		return
+	}
+
+	data, err := msg.ToEvent(config.GetQueuedEventSchemaVersion())
 	if err != nil {
-		log.Printf("[❗️] Failed to serialize into messagepack : %s\n", err.Error())
+		limitedLog.Printf("[❗️] Failed to serialize into messagepack : %s\n", err.Error())
 		return
 	}
 
 	if _, err := q.PubSub.Publish(&ops.Msg{
-		Topics: []string{config.GetQueuedTxExitPublishTopic()},
+		Topics: []string{topic},
 		Data:   data,
 	}); err != nil {
-		log.Printf("[❗️] Failed to publish tx leaving queued pool : %s\n", err.Error())
+		limitedLog.Printf("[❗️] Failed to publish tx evicted from queued pool : %s\n", err.Error())
 	}
 
 }
@@ -0,0 +1,228 @@
+package data
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestQueuedSenderCapUnderFlood(t *testing.T) {
+
+	setConfig(t, "MaxSenders", 8)
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	for i := 0; i < 1000; i++ {
+
+		added := pools.queued.Add(ctx, legacyTx(hashOf(i), addrOf(i), 5, 1_000_000_000))
+		if added != (i < 8) {
+			t.Fatalf("tx from sender %d : added %v", i, added)
+		}
+
+	}
+
+	for i := 0; i < 8; i++ {
+
+		txs := pools.queued.TxsFromA(ctx, addrOf(i))
+		if len(txs) != 1 || txs[0].Hash != hashOf(i) {
+			t.Fatalf("sender %d : expected its only tx, got %v", i, txs)
+		}
+
+	}
+
+	if pools.queued.Remove(ctx, hashOf(1)) == nil {
+		t.Fatal("failed to remove tx")
+	}
+
+	if !pools.queued.Add(ctx, legacyTx(hashOf(100), addrOf(100), 5, 1_000_000_000)) {
+		t.Fatal("tx rejected at cap is not let in, after sender slot freed up")
+	}
+
+	pools.stop()
+
+	if n := len(pools.queued.TxsFromAddress); n != 8 {
+		t.Fatalf("expected 8 senders in index, found %d", n)
+	}
+
+	if n := len(pools.queued.DroppedTxs); n != 0 {
+		t.Fatalf("expected rejected tx(s) not to be remembered, found %d", n)
+	}
+
+}
+
+func TestQueuedPruneCancel(t *testing.T) {
+
+	pools := startPools(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	confirmedChan := make(chan ConfirmedTx, 1)
+	pendingChan := make(chan *MemPoolTx, 1)
+
+	go pools.queued.Prune(ctx, confirmedChan, pendingChan)
+
+	if !pools.queued.Add(ctx, legacyTx(hashOf(1), addrOf(0), 1, 1_000_000_000)) {
+		t.Fatal("failed to add tx")
+	}
+
+	pools.queued.CancelPrune()
+
+	if !pools.queued.PrunePaused() {
+		t.Fatal("pruner not paused after cancellation")
+	}
+
+	// Tx with previous nonce joining pending pool, would have
+	// unstuck queued one
+	pendingChan <- legacyTx(hashOf(0), addrOf(0), 0, 1_000_000_000)
+	time.Sleep(100 * time.Millisecond)
+
+	if !pools.queued.Exists(ctx, hashOf(1)) {
+		t.Fatal("tx moved out of queued pool, while pruner is paused")
+	}
+
+	pools.queued.ResumePrune()
+	pendingChan <- legacyTx(hashOf(0), addrOf(0), 0, 1_000_000_000)
+
+	deadline := time.Now().Add(time.Second)
+
+	for pools.queued.Exists(ctx, hashOf(1)) {
+
+		if time.Now().After(deadline) {
+			t.Fatal("tx not moved out of queued pool, after resuming pruner")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+
+	}
+
+}
+
+func TestQueuedCountMatching(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	const gwei = 1_000_000_000
+
+	for i := 0; i < 6; i++ {
+
+		if !pools.queued.Add(ctx, legacyTx(hashOf(i), addrOf(i%2), uint64(10+i), int64(i+1)*gwei)) {
+			t.Fatalf("failed to add tx %d", i)
+		}
+
+	}
+
+	from := addrOf(1)
+
+	for name, c := range map[string]struct {
+		filter   TxFilter
+		expected uint64
+	}{
+		"empty":    {TxFilter{}, 6},
+		"from":     {TxFilter{From: &from}, 3},
+		"gasPrice": {TxFilter{MinGasPrice: 4}, 3},
+		"both":     {TxFilter{From: &from, MinGasPrice: 4}, 2},
+		"age":      {TxFilter{MinAge: time.Hour}, 0},
+	} {
+
+		if n := pools.queued.CountMatching(ctx, c.filter); n != c.expected {
+			t.Fatalf("%s : expected %d, got %d", name, c.expected, n)
+		}
+
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	pools.stop()
+
+	// Pool isn't serving anymore, must not block
+	if n := pools.queued.CountMatching(cancelled, TxFilter{From: &from}); n != 0 {
+		t.Fatalf("expected 0 with cancelled context, got %d", n)
+	}
+
+}
+
+func TestGetSenderStats(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	m := &MemPool{Pending: pools.pending, Queued: pools.queued}
+
+	for i := 0; i < 2; i++ {
+
+		if !pools.queued.Add(ctx, legacyTx(hashOf(i), addrOf(0), uint64(5+i), 1)) {
+			t.Fatalf("failed to add tx %d", i)
+		}
+
+	}
+
+	if queuedCount, unstuckCount := pools.queued.GetSenderStats(addrOf(0)); queuedCount != 2 || unstuckCount != 0 {
+		t.Fatalf("expected (2, 0), got (%d, %d)", queuedCount, unstuckCount)
+	}
+
+	// Made it to pending pool, without pruner catching it
+	if !pools.pending.Add(ctx, legacyTx(hashOf(0), addrOf(0), 5, 1)) {
+		t.Fatal("failed to add tx to pending pool")
+	}
+
+	if n := m.Reconcile(ctx); n != 1 {
+		t.Fatalf("expected 1 tx to be reconciled, got %d", n)
+	}
+
+	if queuedCount, unstuckCount := pools.queued.GetSenderStats(addrOf(0)); queuedCount != 2 || unstuckCount != 1 {
+		t.Fatalf("expected (2, 1), got (%d, %d)", queuedCount, unstuckCount)
+	}
+
+	// Survives being written to & read back from snapshot
+	var restored reputations
+	restored.restore(pools.queued.reputations.export())
+
+	if rep := restored.get(addrOf(0)); rep.Queued != 2 || rep.Unstuck != 1 {
+		t.Fatalf("expected (2, 1) after restoring, got (%d, %d)", rep.Queued, rep.Unstuck)
+	}
+
+	if queuedCount, unstuckCount := pools.queued.GetSenderStats(addrOf(1)); queuedCount != 0 || unstuckCount != 0 {
+		t.Fatalf("expected unknown sender to have (0, 0), got (%d, %d)", queuedCount, unstuckCount)
+	}
+
+}
+
+func TestNonceGaps(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	if gaps := pools.queued.NonceGaps(ctx, addrOf(0)); gaps != nil {
+		t.Fatalf("expected nil for unknown sender, got %v", gaps)
+	}
+
+	if nonces := pools.queued.MissingNonces(ctx, addrOf(0), 10); nonces != nil {
+		t.Fatalf("expected nil for unknown sender, got %v", nonces)
+	}
+
+	for i, nonce := range []uint64{3, 4, 7, 1000} {
+
+		if !pools.queued.Add(ctx, legacyTx(hashOf(i), addrOf(0), nonce, 1_000_000_000)) {
+			t.Fatalf("failed to add tx with nonce %d", nonce)
+		}
+
+	}
+
+	if gaps := pools.queued.NonceGaps(ctx, addrOf(0)); !reflect.DeepEqual(gaps, []NonceRange{{Lo: 5, Hi: 6}, {Lo: 8, Hi: 999}}) {
+		t.Fatalf("unexpected gaps %v", gaps)
+	}
+
+	if nonces := pools.queued.MissingNonces(ctx, addrOf(0), 4); !reflect.DeepEqual(nonces, []uint64{5, 6, 8, 9}) {
+		t.Fatalf("unexpected missing nonces %v", nonces)
+	}
+
+	if nonces := pools.queued.MissingNonces(ctx, addrOf(0), 0); nonces == nil || len(nonces) != 0 {
+		t.Fatalf("expected empty slice for 0 limit, got %v", nonces)
+	}
+
+}
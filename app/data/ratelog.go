@@ -0,0 +1,93 @@
+package data
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/itzmeanjan/harmony/app/config"
+)
+
+// logWindow - Span of time, repeated log lines are counted over
+const logWindow = 10 * time.Second
+
+// logLimiter - Collapses repeated log lines, emitted from per tx paths,
+// so that spam events don't flood log. Lines sharing same format are
+// considered repeated, first few of them in each window are logged as is,
+// rest are only counted & summarised once window ends
+//
+// @note Zero value is ready to use
+type logLimiter struct {
+	lock    sync.Mutex
+	windows map[string]*logCount
+}
+
+// logCount - How many times some line was seen in current window
+type logCount struct {
+	seen       uint64
+	suppressed uint64
+}
+
+// limitedLog - Shared by both pools, so that same line logged from
+// either of them is counted together
+var limitedLog = &logLimiter{}
+
+// Printf - Logs line, unless its format has already been logged configured
+// #-of times in current window
+func (l *logLimiter) Printf(format string, args ...interface{}) {
+
+	threshold := config.GetLogRateLimit()
+	if threshold == 0 {
+		log.Printf(format, args...)
+		return
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.windows == nil {
+		l.windows = make(map[string]*logCount)
+	}
+
+	count, ok := l.windows[format]
+	if !ok {
+
+		count = &logCount{}
+		l.windows[format] = count
+
+		// Window starts with first occurrence, when it ends, how
+		// many were suppressed is logged & counting starts afresh
+		time.AfterFunc(logWindow, func() { l.flush(format) })
+
+	}
+
+	count.seen++
+
+	if count.seen > threshold {
+		count.suppressed++
+		return
+	}
+
+	log.Printf(format, args...)
+
+}
+
+// flush - Ends window of given format, summarising suppressed lines
+func (l *logLimiter) flush(format string) {
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	count, ok := l.windows[format]
+	if !ok {
+		return
+	}
+
+	delete(l.windows, format)
+
+	if count.suppressed != 0 {
+		log.Printf("[❗️] %d occurrences in last %s, %d not logged : %s\n", count.seen, logWindow, count.suppressed, strings.TrimSpace(format))
+	}
+
+}
@@ -0,0 +1,65 @@
+package data
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/itzmeanjan/pub0sub/ops"
+	"github.com/itzmeanjan/pub0sub/publisher"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// RemovalEvent - Compact payload, published on exit topics when tx leaves
+// pool, if enabled, for subscribers only interested in knowing it left
+type RemovalEvent struct {
+	Hash   common.Hash
+	Reason string
+	At     time.Time
+}
+
+// ToMessagePack - Serialize to message pack encoded byte array format
+func (r *RemovalEvent) ToMessagePack() ([]byte, error) {
+
+	return msgpack.Marshal(r)
+
+}
+
+// publishRemoval - Publishes tx leaving pool, where `exitTopics` receive compact
+// payload, if enabled, in that case full payload goes to `fullTopic` instead,
+// while `otherTopics` always receive full payload
+func publishRemoval(pub *publisher.Publisher, msg *MemPoolTx, full []byte, reason string, at time.Time, exitTopics []string, otherTopics []string, fullTopic string) error {
+
+	if !config.GetCompactRemovalChoice() {
+
+		_, err := pub.Publish(&ops.Msg{
+			Topics: append(exitTopics, otherTopics...),
+			Data:   full,
+		})
+		return err
+
+	}
+
+	if len(exitTopics) != 0 {
+
+		compact, err := (&RemovalEvent{Hash: msg.Hash, Reason: reason, At: at}).ToMessagePack()
+		if err != nil {
+			return err
+		}
+
+		if _, err := pub.Publish(&ops.Msg{
+			Topics: exitTopics,
+			Data:   compact,
+		}); err != nil {
+			return err
+		}
+
+	}
+
+	_, err := pub.Publish(&ops.Msg{
+		Topics: append([]string{fullTopic}, otherTopics...),
+		Data:   full,
+	})
+	return err
+
+}
@@ -0,0 +1,109 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestCompactRemoval(t *testing.T) {
+
+	h := startHub(t, "pending_pool_exit", "pending_pool_exit_full", "pending_pool_dropped", "mempool_exit", "queued_pool_exit", "queued_pool_exit_full")
+	ctx := context.Background()
+
+	setConfig(t, "GlobalTxTopic", "mempool")
+	setConfig(t, "TransferTxTopic", "")
+	setConfig(t, "ContractCallTxTopic", "")
+	setConfig(t, "DeployTxTopic", "")
+	setConfig(t, "PublishDedupWindow", 0)
+	setConfig(t, "CompactRemovalEnabled", true)
+
+	pending := &PendingPool{PubSub: h.pub, TxsByGasPrice: NewSortedTxs()}
+	queued := &QueuedPool{PubSub: h.pub}
+
+	at := time.Now().UTC().Truncate(time.Second)
+
+	confirmed := legacyTx(hashOf(0), addrOf(0), 0, 1)
+	confirmed.Pool = "confirmed"
+	confirmed.ConfirmedAt = at
+
+	dropped := legacyTx(hashOf(1), addrOf(0), 1, 1)
+	dropped.Pool = "dropped"
+	dropped.DroppedAt = at.Add(time.Second)
+
+	unstuck := legacyTx(hashOf(2), addrOf(0), 2, 1)
+	unstuck.Pool = "queued"
+	unstuck.UnstuckAt = at.Add(2 * time.Second)
+
+	pending.PublishRemoved(ctx, confirmed)
+	pending.PublishRemoved(ctx, dropped)
+	queued.PublishRemoved(ctx, unstuck)
+
+	received := h.received(t)
+
+	compact := func(topic string, i int, tx *MemPoolTx, reason string, at time.Time) {
+
+		t.Helper()
+
+		if len(received[topic]) <= i {
+			t.Fatalf("expected message %d on %s, got %d", i, topic, len(received[topic]))
+		}
+
+		var event RemovalEvent
+		if err := msgpack.Unmarshal(received[topic][i], &event); err != nil {
+			t.Fatalf("%s : expected compact payload : %s", topic, err.Error())
+		}
+
+		if event.Hash != tx.Hash || event.Reason != reason || !event.At.Equal(at) {
+			t.Fatalf("%s : unexpected compact payload %v", topic, event)
+		}
+
+	}
+
+	full := func(topic string, i int, tx *MemPoolTx, version uint64) {
+
+		t.Helper()
+
+		expected, err := tx.ToEvent(version)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(received[topic]) <= i || !bytes.Equal(received[topic][i], expected) {
+			t.Fatalf("expected full payload of %s on %s", tx.Hash.Hex(), topic)
+		}
+
+	}
+
+	// Exit topics carry compact payload, while full one goes to dedicated
+	// topic & global one, which always carries full tx
+	compact("pending_pool_exit", 0, confirmed, "confirmed", confirmed.ConfirmedAt)
+	compact("pending_pool_exit", 1, dropped, "dropped", dropped.DroppedAt)
+	compact("pending_pool_dropped", 0, dropped, "dropped", dropped.DroppedAt)
+	compact("queued_pool_exit", 0, unstuck, "unstuck", unstuck.UnstuckAt)
+
+	full("pending_pool_exit_full", 0, confirmed, 0)
+	full("pending_pool_exit_full", 1, dropped, 0)
+	full("queued_pool_exit_full", 0, unstuck, 0)
+	full("mempool_exit", 0, confirmed, 0)
+	full("mempool_exit", 1, dropped, 0)
+
+	// Disabled, exit topics get full payload, nothing on dedicated ones
+	setConfig(t, "CompactRemovalEnabled", false)
+
+	pending.PublishRemoved(ctx, confirmed)
+	queued.PublishRemoved(ctx, unstuck)
+
+	received = h.received(t)
+
+	full("pending_pool_exit", 0, confirmed, 0)
+	full("queued_pool_exit", 0, unstuck, 0)
+
+	if n := len(received["pending_pool_exit_full"]) + len(received["queued_pool_exit_full"]); n != 0 {
+		t.Fatalf("expected nothing on full topics, got %d", n)
+	}
+
+}
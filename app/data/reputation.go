@@ -0,0 +1,141 @@
+package data
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// maxReputations - At max these many senders' record is kept, once
+// reached, some existing one is forgotten for each new sender
+const maxReputations = 1 << 16
+
+// Reputation - How many tx(s) of sender got stuck in queued pool &
+// how many of them later flowed through into pending pool
+type Reputation struct {
+	Queued  uint64
+	Unstuck uint64
+}
+
+// Score - Share of queued tx(s) which got unstuck, in [0, 1], where
+// chronically nonce gapping sender scores close to 0
+//
+// @note Sender never seen in queued pool scores 1
+func (r Reputation) Score() float64 {
+
+	if r.Queued == 0 {
+		return 1
+	}
+
+	if r.Unstuck >= r.Queued {
+		return 1
+	}
+
+	return float64(r.Unstuck) / float64(r.Queued)
+
+}
+
+// reputations - Per sender record, updated from queued pool's go routine,
+// read from anywhere
+//
+// @note Zero value is ready to use
+type reputations struct {
+	lock    sync.RWMutex
+	senders map[common.Address]*Reputation
+}
+
+// of - Record of sender, creating one, if not yet known
+//
+// @note Must be invoked while holding write lock
+func (r *reputations) of(addr common.Address) *Reputation {
+
+	if r.senders == nil {
+		r.senders = make(map[common.Address]*Reputation)
+	}
+
+	rep, ok := r.senders[addr]
+	if ok {
+		return rep
+	}
+
+	if len(r.senders) >= maxReputations {
+		for k := range r.senders {
+			delete(r.senders, k)
+			break
+		}
+	}
+
+	rep = &Reputation{}
+	r.senders[addr] = rep
+
+	return rep
+
+}
+
+// queued - Records tx of sender getting into queued pool
+func (r *reputations) queued(addr common.Address) {
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.of(addr).Queued++
+
+}
+
+// unstuck - Records tx of sender leaving queued pool for pending one
+func (r *reputations) unstuck(addr common.Address) {
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.of(addr).Unstuck++
+
+}
+
+// get - Record of sender, zero valued if not known
+func (r *reputations) get(addr common.Address) Reputation {
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if rep, ok := r.senders[addr]; ok {
+		return *rep
+	}
+
+	return Reputation{}
+
+}
+
+// export - Copy of all records, keyed by hex encoded address, to be
+// written along with pool snapshot
+func (r *reputations) export() map[string]Reputation {
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	exported := make(map[string]Reputation, len(r.senders))
+	for addr, rep := range r.senders {
+		exported[addr.Hex()] = *rep
+	}
+
+	return exported
+
+}
+
+// restore - Puts back records read from pool snapshot
+func (r *reputations) restore(records map[string]Reputation) {
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for addr, rep := range records {
+
+		if !common.IsHexAddress(addr) {
+			continue
+		}
+
+		*r.of(common.HexToAddress(addr)) = rep
+
+	}
+
+}
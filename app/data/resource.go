@@ -4,14 +4,13 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/ethereum/go-ethereum/rpc"
 )
 
 // Resource - Shared resources among multiple go routines
 //
 // Needs to be released carefully when shutting down
 type Resource struct {
-	RPCClient *rpc.Client
+	Upstreams *Upstreams
 	WSClient  *ethclient.Client
 	Pool      *MemPool
 	StartedAt time.Time
@@ -22,7 +21,7 @@ type Resource struct {
 // from system, to gracefully deallocate all resources
 func (r *Resource) Release() {
 
-	r.RPCClient.Close()
+	r.Upstreams.Close()
 	r.WSClient.Close()
 
 }
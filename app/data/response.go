@@ -1,5 +1,13 @@
 package data
 
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
 // Stat - Response to client queries for current mempool state
 // to be sent in this form
 type Stat struct {
@@ -12,8 +20,145 @@ type Stat struct {
 	NetworkID       uint64 `json:"networkID"`
 }
 
+// Health - Response to readiness probe, telling whether mempool is
+// being polled successfully
+type Health struct {
+	Healthy         bool   `json:"healthy"`
+	LastPolledAt    string `json:"lastPolledAt"`
+	PendingPoolSize uint64 `json:"pendingPoolSize"`
+	QueuedPoolSize  uint64 `json:"queuedPoolSize"`
+}
+
+// Counted - Response to client queries for #-of tx(s) in pool, matching
+// given filter
+type Counted struct {
+	Pool  string `json:"pool"`
+	Count uint64 `json:"count"`
+}
+
 // Msg - Response message sent to client
 type Msg struct {
 	Code    uint8  `json:"code,omitempty"`
 	Message string `json:"message"`
 }
+
+// PruneState - Whether queued pool pruner is paused, by cancellation
+type PruneState struct {
+	Paused bool `json:"paused"`
+}
+
+// SenderStats - Summary of all tx(s) sent from some specific address,
+// which are currently living in pool
+type SenderStats struct {
+	Address      common.Address `json:"address"`
+	TxCount      uint64         `json:"txCount"`
+	TotalValue   *big.Int       `json:"totalValue"`
+	TotalFees    *big.Int       `json:"totalFees"`
+	MinGasPrice  *big.Int       `json:"minGasPrice"`
+	MaxGasPrice  *big.Int       `json:"maxGasPrice"`
+	AvgGasPrice  *big.Int       `json:"avgGasPrice"`
+	LowestNonce  uint64         `json:"lowestNonce"`
+	HighestNonce uint64         `json:"highestNonce"`
+	OldestTxAge  time.Duration  `json:"oldestTxAge"`
+}
+
+// Band - Range of gas price [`Low`, `High`), along with how many
+// tx(s) are paying gas price within this range
+type Band struct {
+	Low   *big.Int `json:"low"`
+	High  *big.Int `json:"high"`
+	Count uint64   `json:"count"`
+}
+
+// NonceRange - Run of consecutive missing nonces [`Lo`, `Hi`], both inclusive
+type NonceRange struct {
+	Lo uint64 `json:"lo"`
+	Hi uint64 `json:"hi"`
+}
+
+// PoolStats - Aggregate health of mempool, periodically published
+// on pubsub topic, in messagepack serialized form
+//
+// @note If pending pool is empty, gas prices are zero
+type PoolStats struct {
+	PendingCount   uint64
+	QueuedCount    uint64
+	MinGasPrice    *big.Int
+	MaxGasPrice    *big.Int
+	MedianGasPrice *big.Int
+	OldestTxAge    time.Duration
+	At             time.Time
+}
+
+// PoolStatsGwei - Gas prices of `PoolStats`, in Gwei
+type PoolStatsGwei struct {
+	MinGasPrice    float64 `json:"minGasPrice"`
+	MaxGasPrice    float64 `json:"maxGasPrice"`
+	MedianGasPrice float64 `json:"medianGasPrice"`
+}
+
+// InGwei - Gas prices converted to Gwei, rounded to `precision` digits
+// after decimal point
+func (p *PoolStats) InGwei(precision int) PoolStatsGwei {
+
+	return PoolStatsGwei{
+		MinGasPrice:    WeiToGwei(p.MinGasPrice, precision),
+		MaxGasPrice:    WeiToGwei(p.MaxGasPrice, precision),
+		MedianGasPrice: WeiToGwei(p.MedianGasPrice, precision),
+	}
+
+}
+
+// ToMessagePack - Serialize to message pack encoded byte array format
+func (p *PoolStats) ToMessagePack() ([]byte, error) {
+
+	return msgpack.Marshal(p)
+
+}
+
+// SenderDominance - Largest share ( within [0, 1] ) of pool held by single
+// sender, both by #-of tx(s) & by gas limit, to catch spam concentration
+//
+// @note Senders holding largest share by count & by gas may differ
+type SenderDominance struct {
+	TxShare  float64 `json:"txShare"`
+	GasShare float64 `json:"gasShare"`
+}
+
+// SenderFee - Cumulative fee ( gas price x gas limit, in Wei ) being offered
+// by all pending tx(s) of one sender
+type SenderFee struct {
+	Address  common.Address `json:"address"`
+	TotalFee *big.Int       `json:"totalFee"`
+	TxCount  uint64         `json:"txCount"`
+}
+
+// SandwichGroup - Pending tx(s) touching same contract, where tx(s) from one
+// sender are placed right before & right after some other sender's tx, in
+// gas price ordering, which looks like victim being sandwiched
+type SandwichGroup struct {
+	Contract common.Address `json:"contract"`
+	FrontRun *MemPoolTx     `json:"frontRun"`
+	Victim   *MemPoolTx     `json:"victim"`
+	BackRun  *MemPoolTx     `json:"backRun"`
+}
+
+// FeeBumpPair - Pending tx replacing earlier one of same sender & nonce,
+// by paying different gas price, along with how much it was bumped by
+type FeeBumpPair struct {
+	Old         *MemPoolTx `json:"old"`
+	New         *MemPoolTx `json:"new"`
+	BumpPercent float64    `json:"bumpPercent"`
+}
+
+// GasRecommendation - Effective gas price ( in Wei ) to be paid, for tx to be
+// mined slowly/ as usual/ fast, derived from live pending pool
+//
+// @note When pool doesn't have enough includable tx(s), all tiers are zero
+// & `InsufficientData` is set
+type GasRecommendation struct {
+	Slow             *big.Int `json:"slow"`
+	Standard         *big.Int `json:"standard"`
+	Fast             *big.Int `json:"fast"`
+	InsufficientData bool     `json:"insufficientData"`
+}
@@ -0,0 +1,106 @@
+package data
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// samplingLock - Random number generator isn't concurrent safe,
+	// so it's guarded
+	samplingLock sync.Mutex
+	// samplingRand - Source of randomness, used when sampling pool
+	samplingRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// SetSamplingSeed - Makes sampling of pool reproducible, by seeding random
+// number generator with given value, to be used when generating test traffic
+func SetSamplingSeed(seed int64) {
+
+	samplingLock.Lock()
+	defer samplingLock.Unlock()
+
+	samplingRand = rand.New(rand.NewSource(seed))
+
+}
+
+// sampleWeighted - Picks at max `n` distinct tx(s) from given ones, without
+// replacement, where chance of being picked is proportional to weight of tx
+//
+// Each tx is assigned key log(u) / w, where u is uniformly random in (0, 1),
+// & ones with largest keys are picked. Tx(s) with zero weight are picked last
+func sampleWeighted(txs []*MemPoolTx, n int, weight func(*MemPoolTx) float64) []*MemPoolTx {
+
+	if n <= 0 || len(txs) == 0 {
+		return []*MemPoolTx{}
+	}
+
+	type keyed struct {
+		tx  *MemPoolTx
+		key float64
+	}
+
+	keys := make([]keyed, len(txs))
+
+	samplingLock.Lock()
+
+	for i, tx := range txs {
+
+		key := math.Inf(-1)
+		if w := weight(tx); w > 0 {
+			key = math.Log(1-samplingRand.Float64()) / w
+		}
+
+		keys[i] = keyed{tx: tx, key: key}
+
+	}
+
+	samplingLock.Unlock()
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].key > keys[j].key
+	})
+
+	if n > len(keys) {
+		n = len(keys)
+	}
+
+	sampled := make([]*MemPoolTx, n)
+	for i := 0; i < n; i++ {
+		sampled[i] = keys[i].tx
+	}
+
+	return sampled
+
+}
+
+// SampleWeightedByGasPrice - Samples `n` distinct tx(s) from pending pool,
+// where chance of tx being picked is proportional to gas price paid by it,
+// for generating realistic test traffic
+//
+// @note Use `SetSamplingSeed` for reproducible samples
+func (p *PendingPool) SampleWeightedByGasPrice(ctx context.Context, n int) []*MemPoolTx {
+
+	txs := p.DescListTxs(ctx)
+	if txs == nil {
+		return []*MemPoolTx{}
+	}
+
+	baseFee := p.BaseFee(ctx)
+
+	sampled := sampleWeighted(txs, n, func(tx *MemPoolTx) float64 {
+
+		weight, _ := new(big.Float).SetInt(tx.EffectiveGasPrice(baseFee)).Float64()
+		return weight
+
+	})
+
+	CleanSlice(txs)
+	return sampled
+
+}
@@ -0,0 +1,127 @@
+package data
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSampleWeighted(t *testing.T) {
+
+	SetSamplingSeed(1)
+	t.Cleanup(func() { SetSamplingSeed(time.Now().UnixNano()) })
+
+	txs := make([]*MemPoolTx, 0, 11)
+	for i := 0; i < 11; i++ {
+		txs = append(txs, legacyTx(hashOf(i), addrOf(i), 0, 1))
+	}
+
+	// Last one weighs as much as all others together, ten
+	// times over, while first one never gets picked
+	weight := func(tx *MemPoolTx) float64 {
+
+		switch tx.Hash {
+		case hashOf(0):
+			return 0
+		case hashOf(10):
+			return 90
+		default:
+			return 1
+		}
+
+	}
+
+	const trials = 2000
+
+	var heavy int
+	for i := 0; i < trials; i++ {
+
+		if sampleWeighted(txs, 1, weight)[0].Hash == hashOf(10) {
+			heavy++
+		}
+
+	}
+
+	// Expected to be picked 90% of time
+	if share := float64(heavy) / trials; share < .85 || share > .95 {
+		t.Fatalf("expected heaviest tx to be picked ~90%% of time, got %.2f", share)
+	}
+
+	sampled := sampleWeighted(txs, 100, weight)
+	if len(sampled) != len(txs) {
+		t.Fatalf("expected all %d tx(s), got %d", len(txs), len(sampled))
+	}
+
+	seen := make(map[*MemPoolTx]bool)
+	for _, tx := range sampled {
+
+		if seen[tx] {
+			t.Fatalf("tx %s picked twice", tx.Hash.Hex())
+		}
+
+		seen[tx] = true
+
+	}
+
+	if sampled[len(sampled)-1].Hash != hashOf(0) {
+		t.Fatal("expected tx with zero weight to be picked last")
+	}
+
+	if sampled := sampleWeighted(txs, 0, weight); len(sampled) != 0 {
+		t.Fatalf("expected nothing for n = 0, got %d tx(s)", len(sampled))
+	}
+
+}
+
+func TestSampleWeightedByGasPrice(t *testing.T) {
+
+	t.Cleanup(func() { SetSamplingSeed(time.Now().UnixNano()) })
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	for i := 1; i <= 20; i++ {
+
+		if !pools.pending.Add(ctx, legacyTx(hashOf(i), addrOf(i), 0, int64(i)*1_000_000_000)) {
+			t.Fatalf("failed to add tx %d", i)
+		}
+
+	}
+
+	var sampled [2][]*MemPoolTx
+
+	// Same seed, same sample
+	for i := range sampled {
+
+		SetSamplingSeed(42)
+		sampled[i] = pools.pending.SampleWeightedByGasPrice(ctx, 5)
+
+	}
+
+	if len(sampled[0]) != 5 || !reflect.DeepEqual(sampled[0], sampled[1]) {
+		t.Fatalf("expected reproducible sample of 5 tx(s), got %v & %v", sampled[0], sampled[1])
+	}
+
+	// Higher paying half should be picked more often, than lower one
+	var high, low int
+	for i := 0; i < 500; i++ {
+
+		for _, tx := range pools.pending.SampleWeightedByGasPrice(ctx, 1) {
+
+			if tx.GasPrice.ToInt().Int64() > 10*1_000_000_000 {
+				high++
+				continue
+			}
+
+			low++
+
+		}
+
+	}
+
+	if high <= 2*low {
+		t.Fatalf("expected sampling biased toward high gas price, got %d high vs %d low", high, low)
+	}
+
+}
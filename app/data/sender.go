@@ -0,0 +1,105 @@
+package data
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// SenderResolver - Given tx missing its sender address, attempts to find out
+// who sent it, so that it can be tracked properly in pool
+type SenderResolver func(tx *MemPoolTx) (common.Address, error)
+
+// senderResolver - Currently active sender resolver, by default sender
+// is recovered from tx signature
+var senderResolver SenderResolver = RecoverSender
+
+// SetSenderResolver - Replaces default sender resolver with custom one,
+// to be invoked during application start up, before any tx is processed
+func SetSenderResolver(resolver SenderResolver) {
+
+	senderResolver = resolver
+
+}
+
+// RecoverSender - Default sender resolver, recovers sender address from
+// signature of tx, by rebuilding signed tx from its fields
+//
+// @note Only legacy tx(s) can be rebuilt, because other tx types carry
+// fields ( e.g. access list ) which are not kept in mempool
+func RecoverSender(m *MemPoolTx) (common.Address, error) {
+
+	if m.V == nil || m.R == nil || m.S == nil {
+		return common.Address{}, errors.New("signature not present")
+	}
+
+	if m.Type != types.LegacyTxType {
+		return common.Address{}, errors.New("only legacy tx supported")
+	}
+
+	tx := types.NewTx(&types.LegacyTx{
+		Nonce:    uint64(m.Nonce),
+		GasPrice: bigOrZero(m.GasPrice),
+		Gas:      uint64(m.Gas),
+		To:       m.To,
+		Value:    bigOrZero(m.Value),
+		Data:     m.Input,
+		V:        BigHexToBigDecimal(m.V),
+		R:        BigHexToBigDecimal(m.R),
+		S:        BigHexToBigDecimal(m.S),
+	})
+
+	// Rebuilt tx must be same as what node reported, otherwise
+	// recovered address would be meaningless
+	if tx.Hash() != m.Hash {
+		return common.Address{}, errors.New("rebuilt tx hash mismatch")
+	}
+
+	var signer types.Signer = types.HomesteadSigner{}
+	if tx.Protected() {
+		signer = types.LatestSignerForChainID(tx.ChainId())
+	}
+
+	return types.Sender(signer, tx)
+
+}
+
+// ResolveSenders - Resolves sender of all tx(s) in `txpool_content` section,
+// which are missing it, returning #-of tx(s) for which it failed
+func ResolveSenders(txs map[string]map[string]*MemPoolTx) uint64 {
+
+	var failed uint64
+
+	for _, v := range txs {
+		for _, tx := range v {
+
+			if !ResolveSender(tx) {
+				failed++
+			}
+
+		}
+	}
+
+	return failed
+
+}
+
+// ResolveSender - If sender address of tx is missing, attempts to find it out
+// using active sender resolver & populates it, returning whether tx now has
+// its sender address or not
+func ResolveSender(tx *MemPoolTx) bool {
+
+	if tx.From != (common.Address{}) {
+		return true
+	}
+
+	from, err := senderResolver(tx)
+	if err != nil {
+		return false
+	}
+
+	tx.From = from
+	return true
+
+}
@@ -0,0 +1,235 @@
+package data
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log"
+	"os"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Snapshot - Writes all tx(s) living in pending pool, along with their
+// timing metadata, to `w`, in messagepack serialized format
+func (p *PendingPool) Snapshot(ctx context.Context, w io.Writer) error {
+
+	txs := p.AscListTxs(ctx)
+	defer CleanSlice(txs)
+
+	return msgpack.NewEncoder(w).Encode(txs)
+
+}
+
+// Restore - Reads tx(s) written by `Snapshot` from `r` & puts them back
+// into pending pool, returning #-of tx(s) restored
+//
+// @note Timing metadata i.e. `PendingFrom` is kept as it was in snapshot
+func (p *PendingPool) Restore(ctx context.Context, r io.Reader) (int, error) {
+
+	p.owner.enter()
+
+	var txs []*MemPoolTx
+	if err := msgpack.NewDecoder(r).Decode(&txs); err != nil {
+		return 0, err
+	}
+
+	respChan := make(chan int, 1)
+
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case p.RestoreChan <- RestoreRequest{Txs: txs, ResponseChan: respChan}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case v := <-respChan:
+		return v, nil
+	}
+
+}
+
+// Snapshot - Writes all tx(s) living in queued pool, along with their
+// timing metadata, to `w`, in messagepack serialized format
+func (q *QueuedPool) Snapshot(ctx context.Context, w io.Writer) error {
+
+	txs := q.AscListTxs(ctx)
+	defer CleanSlice(txs)
+
+	return msgpack.NewEncoder(w).Encode(txs)
+
+}
+
+// Restore - Reads tx(s) written by `Snapshot` from `r` & puts them back
+// into queued pool, returning #-of tx(s) restored
+//
+// @note Timing metadata i.e. `QueuedAt` is kept as it was in snapshot
+func (q *QueuedPool) Restore(ctx context.Context, r io.Reader) (int, error) {
+
+	q.owner.enter()
+
+	var txs []*MemPoolTx
+	if err := msgpack.NewDecoder(r).Decode(&txs); err != nil {
+		return 0, err
+	}
+
+	respChan := make(chan int, 1)
+
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case q.RestoreChan <- RestoreRequest{Txs: txs, ResponseChan: respChan}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case v := <-respChan:
+		return v, nil
+	}
+
+}
+
+// SaveSnapshot - Writes snapshot of pending & queued pool to file, so that
+// it can be restored on next start up
+//
+// Snapshot is first written to temporary file & then moved to `path`, so
+// that partially written snapshot never replaces last good one
+func (m *MemPool) SaveSnapshot(ctx context.Context, path string) error {
+
+	tmp := path + ".tmp"
+
+	fd, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(fd)
+
+	if err := m.Pending.Snapshot(ctx, w); err != nil {
+		fd.Close()
+		return err
+	}
+
+	if err := m.Queued.Snapshot(ctx, w); err != nil {
+		fd.Close()
+		return err
+	}
+
+	if err := msgpack.NewEncoder(w).Encode(m.Queued.reputations.export()); err != nil {
+		fd.Close()
+		return err
+	}
+
+	if err := w.Flush(); err != nil {
+		fd.Close()
+		return err
+	}
+
+	if err := fd.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+
+}
+
+// LoadSnapshot - Restores pending & queued pool from snapshot file, written
+// during last shut down. Missing snapshot file is not considered an error
+//
+// @note Restored tx(s) which are not found in first `txpool_content` poll
+// are pruned, because they've left mempool while `harmony` was down
+func (m *MemPool) LoadSnapshot(ctx context.Context, path string) error {
+
+	fd, err := os.Open(path)
+	if err != nil {
+
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+
+	}
+	defer fd.Close()
+
+	// Both pools read from same buffered reader, so that
+	// none of them consumes what's meant for other one
+	r := bufio.NewReader(fd)
+
+	pending, err := m.Pending.Restore(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	queued, err := m.Queued.Restore(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	// Snapshots written before sender reputations were
+	// persisted end right after queued pool
+	var records map[string]Reputation
+	if err := msgpack.NewDecoder(r).Decode(&records); err != nil && err != io.EOF {
+		return err
+	}
+
+	m.Queued.reputations.restore(records)
+
+	m.restored = true
+
+	log.Printf("[✅] Restored %d pending & %d queued tx(s) from snapshot\n", pending, queued)
+	return nil
+
+}
+
+// PruneStale - Removes tx(s) from pending & queued pool, which are not
+// present in respective section of latest `txpool_content` response,
+// returning #-of tx(s) removed. Presence is checked against bloom filter built
+// from response, so tx(s) which left mempool are mostly found without looking
+// into response.
+//
+// To be invoked after first poll, when pools are restored from snapshot
+func (m *MemPool) PruneStale(ctx context.Context, pending map[string]map[string]*MemPoolTx, queued map[string]map[string]*MemPoolTx) uint64 {
+
+	var count uint64
+
+	live := NewPoolBloom(pending)
+	txs := m.Pending.AscListTxs(ctx)
+
+	for _, tx := range txs {
+
+		if live.IsPresent(pending, tx) {
+			continue
+		}
+
+		if m.Pending.Remove(ctx, &TxStatus{Hash: tx.Hash, Status: DROPPED}) {
+			count++
+		}
+
+	}
+
+	CleanSlice(txs)
+
+	live = NewPoolBloom(queued)
+	txs = m.Queued.AscListTxs(ctx)
+
+	for _, tx := range txs {
+
+		if live.IsPresent(queued, tx) {
+			continue
+		}
+
+		if m.Queued.Remove(ctx, tx.Hash) != nil {
+			count++
+		}
+
+	}
+
+	CleanSlice(txs)
+
+	return count
+
+}
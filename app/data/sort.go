@@ -0,0 +1,144 @@
+package data
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// SortKey - One sorting criteria, as part of multi-key sort specification
+// i.e. which field of tx to be compared & in which direction
+type SortKey struct {
+	Field string
+	Order int
+}
+
+// comparators - Supported sort key(s), each comparing a pair of tx(s)
+// on some field, returning -1/ 0/ 1, in ascending sense
+//
+// @note Gas price is compared as effective one, under given base fee,
+// same as what pools are ordered by
+var comparators = map[string]func(*MemPoolTx, *MemPoolTx, *big.Int) int{
+	"gasPrice": func(a *MemPoolTx, b *MemPoolTx, baseFee *big.Int) int {
+		return a.EffectiveGasPrice(baseFee).Cmp(b.EffectiveGasPrice(baseFee))
+	},
+	"gas": func(a *MemPoolTx, b *MemPoolTx, _ *big.Int) int {
+		return compareUint64(uint64(a.Gas), uint64(b.Gas))
+	},
+	"nonce": func(a *MemPoolTx, b *MemPoolTx, _ *big.Int) int {
+		return compareUint64(uint64(a.Nonce), uint64(b.Nonce))
+	},
+	"value": func(a *MemPoolTx, b *MemPoolTx, _ *big.Int) int {
+		return bigOrZero(a.Value).Cmp(bigOrZero(b.Value))
+	},
+	"from": func(a *MemPoolTx, b *MemPoolTx, _ *big.Int) int {
+		return bytes.Compare(a.From.Bytes(), b.From.Bytes())
+	},
+	"hash": func(a *MemPoolTx, b *MemPoolTx, _ *big.Int) int {
+		return bytes.Compare(a.Hash.Bytes(), b.Hash.Bytes())
+	},
+}
+
+// ParseSortSpec - Given sort specification of form `gasPrice desc, nonce asc`,
+// attempts to parse it into ordered list of sort keys
+//
+// @note If direction is not mentioned for some key, it's considered
+// to be ascending
+func ParseSortSpec(spec string) ([]SortKey, error) {
+
+	parts := strings.Split(spec, ",")
+	keys := make([]SortKey, 0, len(parts))
+
+	for _, part := range parts {
+
+		fields := strings.Fields(part)
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, fmt.Errorf("bad sort key : `%s`", strings.TrimSpace(part))
+		}
+
+		if _, ok := comparators[fields[0]]; !ok {
+			return nil, fmt.Errorf("unknown sort key : `%s`", fields[0])
+		}
+
+		key := SortKey{Field: fields[0], Order: ASC}
+
+		if len(fields) == 2 {
+
+			switch strings.ToLower(fields[1]) {
+			case "asc":
+				key.Order = ASC
+			case "desc":
+				key.Order = DESC
+			default:
+				return nil, fmt.Errorf("unknown sort direction : `%s`", fields[1])
+			}
+
+		}
+
+		keys = append(keys, key)
+
+	}
+
+	return keys, nil
+
+}
+
+// SortTxs - Sorts slice of tx(s) in-place, as per given keys, where
+// next key is only considered when all previous keys compare equal
+//
+// @note `baseFee` is used for comparing effective gas price, nil for
+// comparing fee cap of EIP-1559 tx(s)
+func SortTxs(txs []*MemPoolTx, keys []SortKey, baseFee *big.Int) {
+
+	sort.SliceStable(txs, func(i, j int) bool {
+
+		for _, key := range keys {
+
+			cmp := comparators[key.Field](txs[i], txs[j], baseFee)
+			if cmp == 0 {
+				continue
+			}
+
+			if key.Order == DESC {
+				return cmp > 0
+			}
+
+			return cmp < 0
+
+		}
+
+		return false
+
+	})
+
+}
+
+// bigOrZero - Hex encoded big number to decimal big integer, while
+// considering absent value as zero
+func bigOrZero(num *hexutil.Big) *big.Int {
+
+	if num == nil {
+		return big.NewInt(0)
+	}
+
+	return BigHexToBigDecimal(num)
+
+}
+
+// compareUint64 - Three way comparison of unsigned integers
+func compareUint64(a uint64, b uint64) int {
+
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+
+}
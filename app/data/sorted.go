@@ -0,0 +1,478 @@
+package data
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"math/rand"
+)
+
+// treapNode - Single tx living in treap, along with size of subtree
+// rooted at this node, so that order statistics can be answered
+type treapNode struct {
+	tx       *MemPoolTx
+	priority uint32
+	size     int
+	left     *treapNode
+	right    *treapNode
+}
+
+// sizeOf - Size of subtree, nil safe
+func sizeOf(n *treapNode) int {
+
+	if n == nil {
+		return 0
+	}
+
+	return n.size
+
+}
+
+// update - Recomputes subtree size, after children are changed
+func (n *treapNode) update() {
+
+	n.size = 1 + sizeOf(n.left) + sizeOf(n.right)
+
+}
+
+// SortedTxs - Tx(s) kept sorted ( ascending ) as per effective gas price paid, under
+// latest known base fee, backed by randomised balanced binary search tree i.e. treap,
+// so that both insertion & removal are O(log n), while allowing ordered iteration
+// in either direction
+//
+// @note Tx(s) paying same gas price are ordered by their nonce, then
+// hash, so that each tx has its unique position
+type SortedTxs struct {
+	root    *treapNode
+	baseFee *big.Int
+}
+
+// NewSortedTxs - Creates empty gas price ordered tx list
+func NewSortedTxs() *SortedTxs {
+
+	return &SortedTxs{}
+
+}
+
+// compareTxs - Three way comparison of tx(s), as per their position
+// in gas price ordered list, under given base fee
+//
+// Tx(s) paying same gas price are ordered by nonce, where lower one is
+// placed later i.e. considered better, same as it'd be picked up for
+// inclusion, then by hash, so that order is same across runs
+func compareTxs(a *MemPoolTx, b *MemPoolTx, baseFee *big.Int) int {
+
+	if cmp := a.EffectiveGasPrice(baseFee).Cmp(b.EffectiveGasPrice(baseFee)); cmp != 0 {
+		return cmp
+	}
+
+	if a.Nonce != b.Nonce {
+
+		if a.Nonce > b.Nonce {
+			return -1
+		}
+
+		return 1
+
+	}
+
+	return bytes.Compare(a.Hash[:], b.Hash[:])
+
+}
+
+// split - Splits subtree into two, where left one holds all tx(s) placed
+// before `tx` & right one holds remaining
+func (s *SortedTxs) split(n *treapNode, tx *MemPoolTx) (*treapNode, *treapNode) {
+
+	if n == nil {
+		return nil, nil
+	}
+
+	if compareTxs(n.tx, tx, s.baseFee) < 0 {
+
+		l, r := s.split(n.right, tx)
+		n.right = l
+		n.update()
+
+		return n, r
+
+	}
+
+	l, r := s.split(n.left, tx)
+	n.left = r
+	n.update()
+
+	return l, n
+
+}
+
+// merge - Merges two subtrees, where all tx(s) of left one
+// are placed before all of right one
+func merge(l *treapNode, r *treapNode) *treapNode {
+
+	if l == nil {
+		return r
+	}
+
+	if r == nil {
+		return l
+	}
+
+	if l.priority > r.priority {
+
+		l.right = merge(l.right, r)
+		l.update()
+
+		return l
+
+	}
+
+	r.left = merge(l, r.left)
+	r.update()
+
+	return r
+
+}
+
+// erase - Removes tx from subtree, returning new root of subtree &
+// whether anything was removed or not
+func (s *SortedTxs) erase(n *treapNode, tx *MemPoolTx) (*treapNode, bool) {
+
+	if n == nil {
+		return nil, false
+	}
+
+	var removed bool
+
+	switch cmp := compareTxs(tx, n.tx, s.baseFee); {
+
+	case cmp < 0:
+		n.left, removed = s.erase(n.left, tx)
+
+	case cmp > 0:
+		n.right, removed = s.erase(n.right, tx)
+
+	default:
+		// Hash is part of ordering key, so same position
+		// denotes same tx
+		merged := merge(n.left, n.right)
+		n.left, n.right, n.tx = nil, nil, nil
+
+		return merged, true
+
+	}
+
+	n.update()
+	return n, removed
+
+}
+
+// len - Number of tx(s) present in list
+func (s *SortedTxs) len() int {
+
+	return sizeOf(s.root)
+
+}
+
+// insert - Inserts tx into list, while keeping it sorted
+func (s *SortedTxs) insert(tx *MemPoolTx) {
+
+	l, r := s.split(s.root, tx)
+	s.root = merge(merge(l, &treapNode{tx: tx, priority: rand.Uint32(), size: 1}), r)
+
+}
+
+// remove - Removes tx from list, if present
+func (s *SortedTxs) remove(tx *MemPoolTx) bool {
+
+	root, removed := s.erase(s.root, tx)
+	s.root = root
+
+	return removed
+
+}
+
+// rebase - Reorders all tx(s) as per effective gas price under new base fee,
+// because relative order of EIP-1559 & legacy tx(s) depends on it
+func (s *SortedTxs) rebase(baseFee *big.Int) {
+
+	if baseFee == nil || (s.baseFee != nil && s.baseFee.Cmp(baseFee) == 0) {
+		return
+	}
+
+	txs := s.window(ASC, 0, 0)
+
+	s.root = nil
+	s.baseFee = baseFee
+
+	for _, tx := range txs {
+		s.insert(tx)
+	}
+
+	CleanSlice(txs)
+
+}
+
+// first - Tx with lowest gas price paid, nil if empty
+func (s *SortedTxs) first() *MemPoolTx {
+
+	n := s.root
+	if n == nil {
+		return nil
+	}
+
+	for n.left != nil {
+		n = n.left
+	}
+
+	return n.tx
+
+}
+
+// lowestOf - Among given tx(s), one which'd be placed first in this list
+// i.e. paying lowest gas price, nil if none given
+func (s *SortedTxs) lowestOf(txs []*MemPoolTx) *MemPoolTx {
+
+	var lowest *MemPoolTx
+
+	for _, tx := range txs {
+
+		if lowest == nil || compareTxs(tx, lowest, s.baseFee) < 0 {
+			lowest = tx
+		}
+
+	}
+
+	return lowest
+
+}
+
+// outbids - Checks whether `a` pays strictly higher gas price than `b`,
+// as per current base fee
+func (s *SortedTxs) outbids(a *MemPoolTx, b *MemPoolTx) bool {
+
+	return a.EffectiveGasPrice(s.baseFee).Cmp(b.EffectiveGasPrice(s.baseFee)) > 0
+
+}
+
+// at - Tx placed at `i`-th position ( 0-based ) in ascending order, found by
+// descending using subtree sizes
+//
+// @note Returns nil, if `i` is out of range
+func (s *SortedTxs) at(i int) *MemPoolTx {
+
+	if i < 0 || i >= s.len() {
+		return nil
+	}
+
+	for n := s.root; n != nil; {
+
+		leftSize := sizeOf(n.left)
+
+		switch {
+		case i < leftSize:
+			n = n.left
+		case i == leftSize:
+			return n.tx
+		default:
+			i -= leftSize + 1
+			n = n.right
+		}
+
+	}
+
+	return nil
+
+}
+
+// percentile - Effective gas price at `p`-th percentile, as per nearest rank
+// method, among tx(s) placed at/ after `offset` in ascending order
+//
+// @note Returns nil, if there's no such tx
+func (s *SortedTxs) percentile(p float64, offset int) *big.Int {
+
+	n := s.len() - offset
+	if n <= 0 {
+		return nil
+	}
+
+	rank := int(math.Ceil(p / 100 * float64(n)))
+	if rank < 1 {
+		rank = 1
+	}
+
+	return s.at(offset + rank - 1).EffectiveGasPrice(s.baseFee)
+
+}
+
+// rankOf - Number of tx(s) paying gas price lower than `gasPrice`
+func (s *SortedTxs) rankOf(gasPrice *big.Int) int {
+
+	var rank int
+
+	for n := s.root; n != nil; {
+
+		if n.tx.EffectiveGasPrice(s.baseFee).Cmp(gasPrice) < 0 {
+
+			rank += sizeOf(n.left) + 1
+			n = n.right
+			continue
+
+		}
+
+		n = n.left
+
+	}
+
+	return rank
+
+}
+
+// ascend - Walks over tx(s) in ascending order, starting at `offset`, invoking
+// `fn` for each of them, until it returns false
+func (s *SortedTxs) ascend(offset int, fn func(*MemPoolTx) bool) {
+
+	var walk func(*treapNode, int) bool
+
+	walk = func(n *treapNode, offset int) bool {
+
+		if n == nil {
+			return true
+		}
+
+		// Whole subtree can be skipped
+		if offset >= n.size {
+			return true
+		}
+
+		leftSize := sizeOf(n.left)
+
+		if offset < leftSize {
+			if !walk(n.left, offset) {
+				return false
+			}
+		}
+
+		if offset <= leftSize {
+			if !fn(n.tx) {
+				return false
+			}
+		}
+
+		skip := offset - leftSize - 1
+		if skip < 0 {
+			skip = 0
+		}
+
+		return walk(n.right, skip)
+
+	}
+
+	walk(s.root, offset)
+
+}
+
+// descend - Walks over tx(s) in descending order, starting at `offset`, invoking
+// `fn` for each of them, until it returns false
+func (s *SortedTxs) descend(offset int, fn func(*MemPoolTx) bool) {
+
+	var walk func(*treapNode, int) bool
+
+	walk = func(n *treapNode, offset int) bool {
+
+		if n == nil {
+			return true
+		}
+
+		// Whole subtree can be skipped
+		if offset >= n.size {
+			return true
+		}
+
+		rightSize := sizeOf(n.right)
+
+		if offset < rightSize {
+			if !walk(n.right, offset) {
+				return false
+			}
+		}
+
+		if offset <= rightSize {
+			if !fn(n.tx) {
+				return false
+			}
+		}
+
+		skip := offset - rightSize - 1
+		if skip < 0 {
+			skip = 0
+		}
+
+		return walk(n.left, skip)
+
+	}
+
+	walk(s.root, offset)
+
+}
+
+// window - Copies window of tx(s), in requested order, starting at `offset`
+// & spanning at max `limit` entries, where zero `limit` denotes no cap
+//
+// @note Returns nil, if nothing to copy
+func (s *SortedTxs) window(order int, offset uint64, limit uint64) []*MemPoolTx {
+
+	n := uint64(s.len())
+	if offset >= n {
+		return nil
+	}
+
+	count := n - offset
+	if limit != 0 && limit < count {
+		count = limit
+	}
+
+	copied := make([]*MemPoolTx, 0, count)
+
+	collect := func(tx *MemPoolTx) bool {
+		copied = append(copied, tx)
+		return uint64(len(copied)) < count
+	}
+
+	if order == DESC {
+		s.descend(int(offset), collect)
+		return copied
+	}
+
+	s.ascend(int(offset), collect)
+	return copied
+
+}
+
+// rangeByGasPrice - Copies all tx(s) paying gas price within [min, max], by seeking to
+// lower bound & walking forward until gas price exceeds upper bound
+//
+// @note nil `min`/ `max` denotes range is unbounded on that side
+func (s *SortedTxs) rangeByGasPrice(min *big.Int, max *big.Int) []*MemPoolTx {
+
+	offset := 0
+	if min != nil {
+		offset = s.rankOf(min)
+	}
+
+	result := make([]*MemPoolTx, 0)
+
+	s.ascend(offset, func(tx *MemPoolTx) bool {
+
+		if max != nil && tx.EffectiveGasPrice(s.baseFee).Cmp(max) > 0 {
+			return false
+		}
+
+		result = append(result, tx)
+		return true
+
+	})
+
+	return result
+
+}
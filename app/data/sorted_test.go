@@ -0,0 +1,139 @@
+package data
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// randomTxs - `n` tx(s) paying random gas price, from seeded RNG, so
+// that runs are reproducible
+func randomTxs(n int) []*MemPoolTx {
+
+	rng := rand.New(rand.NewSource(1))
+
+	txs := make([]*MemPoolTx, 0, n)
+	for i := 0; i < n; i++ {
+		txs = append(txs, legacyTx(hashOf(i), addrOf(i%100), uint64(i/100), rng.Int63n(1000)+1))
+	}
+
+	return txs
+
+}
+
+func TestSortedTxs(t *testing.T) {
+
+	txs := randomTxs(1000)
+	s := NewSortedTxs()
+
+	for _, tx := range txs {
+		s.insert(tx)
+	}
+
+	// Every other one leaves
+	for i := 0; i < len(txs); i += 2 {
+
+		if !s.remove(txs[i]) {
+			t.Fatalf("failed to remove tx %d", i)
+		}
+
+	}
+
+	if s.remove(txs[0]) {
+		t.Fatal("removed tx which isn't present")
+	}
+
+	if s.len() != 500 {
+		t.Fatalf("expected 500 tx(s), found %d", s.len())
+	}
+
+	asc := s.window(ASC, 0, 0)
+
+	if !sort.SliceIsSorted(asc, func(i, j int) bool { return compareTxs(asc[i], asc[j], nil) < 0 }) {
+		t.Fatal("ascending list not sorted")
+	}
+
+	desc := s.window(DESC, 0, 0)
+
+	for i := range asc {
+
+		if asc[i] != desc[len(desc)-1-i] || asc[i] != s.at(i) {
+			t.Fatalf("position %d differs across ascending/ descending/ indexed lookup", i)
+		}
+
+	}
+
+}
+
+// sortedSlice - Sorted slice backed list, as gas price ordered tx(s) were
+// kept before, where removal is linear, for comparison
+type sortedSlice []*MemPoolTx
+
+func (s *sortedSlice) insert(tx *MemPoolTx) {
+
+	i := sort.Search(len(*s), func(i int) bool { return compareTxs((*s)[i], tx, nil) >= 0 })
+
+	*s = append(*s, nil)
+	copy((*s)[i+1:], (*s)[i:])
+	(*s)[i] = tx
+
+}
+
+func (s *sortedSlice) remove(tx *MemPoolTx) {
+
+	for i := range *s {
+
+		if (*s)[i] == tx {
+			*s = append((*s)[:i], (*s)[i+1:]...)
+			return
+		}
+
+	}
+
+}
+
+// BenchmarkSortedTxs - Pool of 50k tx(s) churning i.e. one tx leaving &
+// another one joining, for each operation
+func BenchmarkSortedTxs(b *testing.B) {
+
+	txs := randomTxs(50_000)
+
+	b.Run("slice", func(b *testing.B) {
+
+		s := make(sortedSlice, 0, len(txs))
+		for _, tx := range txs {
+			s.insert(tx)
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+
+			tx := txs[i%len(txs)]
+			s.remove(tx)
+			s.insert(tx)
+
+		}
+
+	})
+
+	b.Run("treap", func(b *testing.B) {
+
+		s := NewSortedTxs()
+		for _, tx := range txs {
+			s.insert(tx)
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+
+			tx := txs[i%len(txs)]
+			s.remove(tx)
+			s.insert(tx)
+
+		}
+
+	})
+
+}
@@ -1,13 +1,18 @@
 package data
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"math/big"
+	"strconv"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/itzmeanjan/harmony/app/config"
 	"github.com/itzmeanjan/harmony/app/graph/model"
 
 	"github.com/vmihailenco/msgpack/v5"
@@ -17,29 +22,34 @@ import (
 // RPC call for fetching currently pending/ queued tx(s) in mempool
 // it'll be destructured into this format, for further computation
 type MemPoolTx struct {
-	BlockHash        *common.Hash    `json:"blockHash"`
-	BlockNumber      *hexutil.Big    `json:"blockNumber"`
-	From             common.Address  `json:"from"`
-	Gas              hexutil.Uint64  `json:"gas"`
-	GasPrice         *hexutil.Big    `json:"gasPrice"`
-	Hash             common.Hash     `json:"hash"`
-	Input            hexutil.Bytes   `json:"input"`
-	Nonce            hexutil.Uint64  `json:"nonce"`
-	To               *common.Address `json:"to"`
-	TransactionIndex *hexutil.Uint64 `json:"transactionIndex"`
-	Value            *hexutil.Big    `json:"value"`
-	Type             hexutil.Uint64  `json:"type"`
-	ChainID          *hexutil.Big    `json:"chainId,omitempty"`
-	V                *hexutil.Big    `json:"v"`
-	R                *hexutil.Big    `json:"r"`
-	S                *hexutil.Big    `json:"s"`
-	QueuedAt         time.Time
-	UnstuckAt        time.Time
-	PendingFrom      time.Time
-	ConfirmedAt      time.Time
-	DroppedAt        time.Time
-	Pool             string
-	ReceivedFrom     string
+	BlockHash            *common.Hash    `json:"blockHash"`
+	BlockNumber          *hexutil.Big    `json:"blockNumber"`
+	From                 common.Address  `json:"from"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty" msgpack:",omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty" msgpack:",omitempty"`
+	Hash                 common.Hash     `json:"hash"`
+	Input                hexutil.Bytes   `json:"input"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	To                   *common.Address `json:"to"`
+	TransactionIndex     *hexutil.Uint64 `json:"transactionIndex"`
+	Value                *hexutil.Big    `json:"value"`
+	Type                 hexutil.Uint64  `json:"type"`
+	ChainID              *hexutil.Big    `json:"chainId,omitempty"`
+	V                    *hexutil.Big    `json:"v"`
+	R                    *hexutil.Big    `json:"r"`
+	S                    *hexutil.Big    `json:"s"`
+	QueuedAt             time.Time
+	UnstuckAt            time.Time
+	PendingFrom          time.Time
+	ConfirmedAt          time.Time
+	DroppedAt            time.Time
+	Pool                 string
+	ReceivedFrom         string
+	InitialGasPrice      *hexutil.Big   `msgpack:",omitempty"`
+	EstimatedGas         hexutil.Uint64 `msgpack:",omitempty"`
+	SchemaVersion        uint64         `msgpack:",omitempty"`
 }
 
 // IsDuplicateOf - Checks whether one tx is duplicate of another one or not
@@ -81,6 +91,142 @@ func (m *MemPoolTx) IsSentTo(address common.Address) bool {
 
 }
 
+// HasValueWithin - Checks whether value transferred by this tx is within
+// [`min`, `max`], where nil bound denotes that side is unbounded
+func (m *MemPoolTx) HasValueWithin(min *big.Int, max *big.Int) bool {
+
+	value := bigOrZero(m.Value)
+
+	if min != nil && value.Cmp(min) < 0 {
+		return false
+	}
+
+	if max != nil && value.Cmp(max) > 0 {
+		return false
+	}
+
+	return true
+
+}
+
+// Category - What kind of tx this is i.e. `deploy` ( contract creation ),
+// `transfer` ( plain value transfer ) or `contract-call`
+func (m *MemPoolTx) Category() string {
+
+	if m.To == nil {
+		return DEPLOY
+	}
+
+	if len(m.Input) == 0 {
+		return TRANSFER
+	}
+
+	return CONTRACT_CALL
+
+}
+
+// TxSizeOverhead - Approximate #-of bytes, serialized tx takes, apart from
+// its calldata i.e. nonce, gas price, gas limit, recipient, value & signature
+const TxSizeOverhead = 110
+
+// ApproxSize - Approximate size of serialized tx, in bytes, calldata length
+// along with fixed overhead
+func (m *MemPoolTx) ApproxSize() int {
+
+	return len(m.Input) + TxSizeOverhead
+
+}
+
+// HasSelector - Checks whether this tx is invoking contract method
+// identified by given 4-byte function selector
+func (m *MemPoolTx) HasSelector(selector [4]byte) bool {
+
+	if len(m.Input) < 4 {
+		return false
+	}
+
+	return bytes.Equal(m.Input[:4], selector[:])
+
+}
+
+// Filter - Decides whether tx is of interest to subscriber, so that same check
+// can be applied to streamed events & initial snapshot of pool
+type Filter func(*MemPoolTx) bool
+
+// TxFilter - Combination of constraints tx needs to satisfy, where zero value
+// of any field is considered to be wildcard
+type TxFilter struct {
+	From        *common.Address
+	To          *common.Address
+	MinGasPrice float64 // in Gwei
+	MinAge      time.Duration
+}
+
+// IsEmpty - Checks whether filter doesn't constrain anything
+func (f *TxFilter) IsEmpty() bool {
+
+	return f.From == nil && f.To == nil && f.MinGasPrice == 0 && f.MinAge == 0
+
+}
+
+// Matches - Checks whether given tx satisfies all constraints, where age is
+// measured since tx joined pool it's living in
+func (f *TxFilter) Matches(tx *MemPoolTx) bool {
+
+	if f.From != nil && !tx.IsSentFrom(*f.From) {
+		return false
+	}
+
+	if f.To != nil && !tx.IsSentTo(*f.To) {
+		return false
+	}
+
+	// Base fee isn't known here, so fee cap of EIP-1559 tx is
+	// considered i.e. what it offers at max
+	if f.MinGasPrice != 0 && !tx.HasGasPriceMoreThan(f.MinGasPrice, nil) {
+		return false
+	}
+
+	if f.MinAge != 0 && !(tx.IsPendingForGTE(f.MinAge) || tx.IsQueuedForGTE(f.MinAge)) {
+		return false
+	}
+
+	return true
+
+}
+
+// Matches - Checks whether this tx satisfies all given constraints, where
+// nil constraint is considered to be wildcard
+func (m *MemPoolTx) Matches(from *common.Address, to *common.Address, selector *[4]byte) bool {
+
+	if from != nil && !m.IsSentFrom(*from) {
+		return false
+	}
+
+	if to != nil && !m.IsSentTo(*to) {
+		return false
+	}
+
+	if selector != nil && !m.HasSelector(*selector) {
+		return false
+	}
+
+	return true
+
+}
+
+// firstSeenAt - When tx was first seen, either in queued pool, if it
+// got unstuck from there, or in pending pool
+func (m *MemPoolTx) firstSeenAt() time.Time {
+
+	if !m.QueuedAt.IsZero() {
+		return m.QueuedAt
+	}
+
+	return m.PendingFrom
+
+}
+
 // IsPendingForGTE - Test if this tx has been in pending pool
 // for more than or equal to `X` time unit
 func (m *MemPoolTx) IsPendingForGTE(x time.Duration) bool {
@@ -133,12 +279,15 @@ func (m *MemPoolTx) IsQueuedForLTE(x time.Duration) bool {
 //
 // Dropping can happen due to higher priority tx from same account with same nonce
 // was encountered
+//
+// @note When receipt couldn't be looked up, it's neither known to be dropped
+// nor mined, so error must be checked before trusting returned status
 func (m *MemPoolTx) IsDropped(ctx context.Context, rpc *rpc.Client) (bool, error) {
 
 	var result interface{}
 
 	if err := rpc.CallContext(ctx, &result, "eth_getTransactionReceipt", m.Hash.Hex()); err != nil {
-		return true, err
+		return false, err
 	}
 
 	// Receipt is not available i.e. tx is dropped ( because nonce is exhausted, we already know )
@@ -151,6 +300,36 @@ func (m *MemPoolTx) IsDropped(ctx context.Context, rpc *rpc.Client) (bool, error
 
 }
 
+// EstimateGas - Asks node how much gas this tx would use, if executed
+// against latest state, so that it can be compared with gas limit declared
+//
+// @note Gas limit & price are left out of call, so that estimation is
+// neither capped by declared limit nor fails due to fee affordability
+func (m *MemPoolTx) EstimateGas(ctx context.Context, rpc *rpc.Client) (uint64, error) {
+
+	args := map[string]interface{}{
+		"from": m.From,
+		"data": m.Input,
+	}
+
+	if m.To != nil {
+		args["to"] = m.To
+	}
+
+	if m.Value != nil {
+		args["value"] = m.Value
+	}
+
+	var result hexutil.Uint64
+
+	if err := rpc.CallContext(ctx, &result, "eth_estimateGas", args); err != nil {
+		return 0, err
+	}
+
+	return uint64(result), nil
+
+}
+
 // IsNonceExhausted - Multiple tx(s) of same/ different value
 // can be sent to network with same nonce, where one of them
 // which seems most profitable to miner, will be picked up, while mining next block
@@ -187,10 +366,21 @@ func (m *MemPoolTx) IsUnstuck(ctx context.Context, rpc *rpc.Client) (bool, error
 
 }
 
-// HasGasPriceMoreThan - Returns true if gas price of this tx
-// is more than or equals to `X`
-func (m *MemPoolTx) HasGasPriceMoreThan(x float64) bool {
-	gp, err := BigHexToBigFloat(m.GasPrice)
+// IsOfType - Checks whether tx is of given EIP-2718 type i.e. 0 for legacy,
+// 1 for access list & 2 for dynamic fee tx
+//
+// @note Nodes not reporting type, send only legacy tx(s), which is why missing
+// `type` field is considered legacy
+func (m *MemPoolTx) IsOfType(t uint8) bool {
+
+	return uint64(m.Type) == uint64(t)
+
+}
+
+// HasGasPriceMoreThan - Returns true if effective gas price of this tx,
+// under given base fee, is more than or equals to `X`
+func (m *MemPoolTx) HasGasPriceMoreThan(x float64, baseFee *big.Int) bool {
+	gp, err := BigIntToBigFloat(m.EffectiveGasPrice(baseFee))
 	if err != nil {
 		return false
 	}
@@ -199,10 +389,10 @@ func (m *MemPoolTx) HasGasPriceMoreThan(x float64) bool {
 	return gp.Cmp(given) >= 0
 }
 
-// HasGasPriceLessThan - Returns true if gas price of this tx
-// is less than or equals to `X`
-func (m *MemPoolTx) HasGasPriceLessThan(x float64) bool {
-	gp, err := BigHexToBigFloat(m.GasPrice)
+// HasGasPriceLessThan - Returns true if effective gas price of this tx,
+// under given base fee, is less than or equals to `X`
+func (m *MemPoolTx) HasGasPriceLessThan(x float64, baseFee *big.Int) bool {
+	gp, err := BigIntToBigFloat(m.EffectiveGasPrice(baseFee))
 	if err != nil {
 		return false
 	}
@@ -211,6 +401,48 @@ func (m *MemPoolTx) HasGasPriceLessThan(x float64) bool {
 	return gp.Cmp(given) <= 0
 }
 
+// IsDynamicFee - Checks whether this is EIP-1559 tx, carrying fee cap
+// & priority fee, instead of flat gas price
+func (m *MemPoolTx) IsDynamicFee() bool {
+
+	return m.MaxFeePerGas != nil && m.MaxPriorityFeePerGas != nil
+
+}
+
+// EffectiveGasPrice - Gas price this tx would actually pay, if included in block
+// with given base fee i.e. min(fee cap, base fee + priority fee), for EIP-1559 tx(s)
+//
+// @note Legacy tx(s) always pay their gas price, and if base fee is not
+// known, fee cap of EIP-1559 tx is considered
+func (m *MemPoolTx) EffectiveGasPrice(baseFee *big.Int) *big.Int {
+
+	if !m.IsDynamicFee() {
+		return bigOrZero(m.GasPrice)
+	}
+
+	feeCap := BigHexToBigDecimal(m.MaxFeePerGas)
+	if baseFee == nil {
+		return feeCap
+	}
+
+	price := big.NewInt(0).Add(baseFee, BigHexToBigDecimal(m.MaxPriorityFeePerGas))
+	if price.Cmp(feeCap) > 0 {
+		return feeCap
+	}
+
+	return price
+
+}
+
+// MaxCost - At max how much sender of this tx can get charged, i.e. value
+// being transferred along with max fee, in wei
+func (m *MemPoolTx) MaxCost() *big.Int {
+
+	fee := big.NewInt(0).Mul(big.NewInt(0).SetUint64(uint64(m.Gas)), m.EffectiveGasPrice(nil))
+	return fee.Add(fee, bigOrZero(m.Value))
+
+}
+
 // ToMessagePack - Serialize to message pack encoded byte array format
 func (m *MemPoolTx) ToMessagePack() ([]byte, error) {
 
@@ -218,6 +450,34 @@ func (m *MemPoolTx) ToMessagePack() ([]byte, error) {
 
 }
 
+// ToEvent - Serialize to message pack encoded byte array format, as per
+// requested event schema version, to be published on pubsub topic
+//
+// Fields introduced in later schema versions are left out, so that
+// subscribers pinned to older version never see them
+func (m *MemPoolTx) ToEvent(version uint64) ([]byte, error) {
+
+	if version < EVENT_SCHEMA_V1 || version > LATEST_EVENT_SCHEMA {
+		version = LATEST_EVENT_SCHEMA
+	}
+
+	event := *m
+	event.SchemaVersion = version
+
+	if version < EVENT_SCHEMA_V2 {
+		event.MaxFeePerGas = nil
+		event.MaxPriorityFeePerGas = nil
+		event.InitialGasPrice = nil
+	}
+
+	if version < EVENT_SCHEMA_V3 {
+		event.EstimatedGas = 0
+	}
+
+	return msgpack.Marshal(&event)
+
+}
+
 // FromMessagePack - Given serialized byte array, attempts to deserialize
 // into structured tx format
 func FromMessagePack(data []byte) (*MemPoolTx, error) {
@@ -232,6 +492,59 @@ func FromMessagePack(data []byte) (*MemPoolTx, error) {
 
 }
 
+// rpcTx - Tx, as returned by `eth_getTransactionByHash`, without any of
+// harmony's own metadata
+type rpcTx struct {
+	BlockHash            *common.Hash    `json:"blockHash"`
+	BlockNumber          *hexutil.Big    `json:"blockNumber"`
+	From                 common.Address  `json:"from"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	Hash                 common.Hash     `json:"hash"`
+	Input                hexutil.Bytes   `json:"input"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	To                   *common.Address `json:"to"`
+	TransactionIndex     *hexutil.Uint64 `json:"transactionIndex"`
+	Value                *hexutil.Big    `json:"value"`
+	Type                 hexutil.Uint64  `json:"type"`
+	ChainID              *hexutil.Big    `json:"chainId,omitempty"`
+	V                    *hexutil.Big    `json:"v"`
+	R                    *hexutil.Big    `json:"r"`
+	S                    *hexutil.Big    `json:"s"`
+}
+
+// MarshalJSON - Encodes tx in same form as returned by `eth_getTransactionByHash`
+// i.e. hex encoded standard fields, so that web3 tooling can consume it directly
+//
+// @note Pool timing metadata is left out, while messagepack encoding is
+// not affected by this
+func (m *MemPoolTx) MarshalJSON() ([]byte, error) {
+
+	return json.Marshal(&rpcTx{
+		BlockHash:            m.BlockHash,
+		BlockNumber:          m.BlockNumber,
+		From:                 m.From,
+		Gas:                  m.Gas,
+		GasPrice:             m.GasPrice,
+		MaxFeePerGas:         m.MaxFeePerGas,
+		MaxPriorityFeePerGas: m.MaxPriorityFeePerGas,
+		Hash:                 m.Hash,
+		Input:                m.Input,
+		Nonce:                m.Nonce,
+		To:                   m.To,
+		TransactionIndex:     m.TransactionIndex,
+		Value:                m.Value,
+		Type:                 m.Type,
+		ChainID:              m.ChainID,
+		V:                    m.V,
+		R:                    m.R,
+		S:                    m.S,
+	})
+
+}
+
 // ToGraphQL - Convert to graphql compatible type
 func (m *MemPoolTx) ToGraphQL() *model.MemPoolTx {
 
@@ -371,6 +684,91 @@ const (
 	DROPPED
 )
 
+// Tx categories, based on what tx does
+const (
+	TRANSFER      = "transfer"
+	CONTRACT_CALL = "contract-call"
+	DEPLOY        = "deploy"
+)
+
+// Event schema versions, tx(s) joining/ leaving pool can be published in
+//
+// - v1 : Tx fields as present in `txpool_content` response, along with pool timing metadata
+// - v2 : v1 + EIP-1559 fee caps & gas price tx was first seen with
+// - v3 : v2 + gas usage estimated by node, if estimation is enabled
+const (
+	EVENT_SCHEMA_V1 = iota + 1
+	EVENT_SCHEMA_V2
+	EVENT_SCHEMA_V3
+
+	LATEST_EVENT_SCHEMA = EVENT_SCHEMA_V3
+)
+
+// Kinds of pool events, published on global & tx category topics
+const (
+	ENTRY = "entry"
+	EXIT  = "exit"
+)
+
+// TopicsFor - Pubsub topics where tx joining/ leaving pool to be published, which
+// are pool specific topic along with global & tx category specific ones, if
+// configured
+//
+// Global & category topics are shared by both pools, so event `kind` i.e.
+// `entry`/ `exit` is suffixed to them, e.g. `mempool_entry`, letting
+// subscribers tell tx joining pool apart from one leaving it
+func TopicsFor(poolTopic string, kind string, tx *MemPoolTx) []string {
+
+	topics := []string{poolTopic}
+
+	if topic := config.GetGlobalPublishTopic(); len(topic) != 0 {
+		topics = append(topics, fmt.Sprintf("%s_%s", topic, kind))
+	}
+
+	if topic := config.GetCategoryPublishTopic(tx.Category()); len(topic) != 0 {
+		topics = append(topics, fmt.Sprintf("%s_%s", topic, kind))
+	}
+
+	return topics
+
+}
+
+// GasPriceBucketTopic - Topic of coarse gas price bucket, tx falls in, derived
+// from pool topic, e.g. `pending_pool_entry_gwei_10_50`, where last bucket is
+// open ended i.e. `pending_pool_entry_gwei_50_inf`
+//
+// Bucket is picked as per effective gas price of tx, under `baseFee`, from
+// ascending `buckets` boundaries ( in Gwei ).
+//
+// @note Returns empty string, if no bucket is given
+func GasPriceBucketTopic(poolTopic string, tx *MemPoolTx, buckets []float64, baseFee *big.Int) string {
+
+	if len(buckets) == 0 {
+		return ""
+	}
+
+	gwei := WeiToGwei(tx.EffectiveGasPrice(baseFee), -1)
+
+	format := func(v float64) string {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	lower := 0.0
+
+	for _, upper := range buckets {
+
+		if gwei < upper {
+			return fmt.Sprintf("%s_gwei_%s_%s", poolTopic, format(lower), format(upper))
+		}
+
+		lower = upper
+
+	}
+
+	return fmt.Sprintf("%s_gwei_%s_inf", poolTopic, format(lower))
+
+}
+
 // TxStatus - When ever multiple go routines need to
 // concurrently fetch status of tx, given hash
 // they will communicate back to caller using this
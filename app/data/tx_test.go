@@ -0,0 +1,139 @@
+package data
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestCategory(t *testing.T) {
+
+	to := addrOf(1)
+
+	deploy := legacyTx(hashOf(0), addrOf(0), 0, 1)
+
+	transfer := legacyTx(hashOf(1), addrOf(0), 1, 1)
+	transfer.To = &to
+
+	call := legacyTx(hashOf(2), addrOf(0), 2, 1)
+	call.To = &to
+	call.Input = hexutil.Bytes{0xa9, 0x05, 0x9c, 0xbb}
+
+	for tx, expected := range map[*MemPoolTx]string{deploy: DEPLOY, transfer: TRANSFER, call: CONTRACT_CALL} {
+
+		if category := tx.Category(); category != expected {
+			t.Fatalf("expected %s, got %s", expected, category)
+		}
+
+	}
+
+}
+
+func TestTopicsFor(t *testing.T) {
+
+	to := addrOf(1)
+
+	tx := legacyTx(hashOf(0), addrOf(0), 0, 1)
+	tx.To = &to
+
+	// Nothing shared is configured
+	setConfig(t, "GlobalTxTopic", "")
+	setConfig(t, "TransferTxTopic", "")
+
+	if topics := TopicsFor("pending_pool_entry", ENTRY, tx); !reflect.DeepEqual(topics, []string{"pending_pool_entry"}) {
+		t.Fatalf("expected only pool topic, got %v", topics)
+	}
+
+	setConfig(t, "GlobalTxTopic", "mempool")
+	setConfig(t, "TransferTxTopic", "transfer")
+
+	entry := TopicsFor("pending_pool_entry", ENTRY, tx)
+	if !reflect.DeepEqual(entry, []string{"pending_pool_entry", "mempool_entry", "transfer_entry"}) {
+		t.Fatalf("unexpected entry topics %v", entry)
+	}
+
+	exit := TopicsFor("pending_pool_exit", EXIT, tx)
+	if !reflect.DeepEqual(exit, []string{"pending_pool_exit", "mempool_exit", "transfer_exit"}) {
+		t.Fatalf("unexpected exit topics %v", exit)
+	}
+
+	// Subscriber of shared topic must be able to tell
+	// entry apart from exit
+	for _, a := range entry[1:] {
+
+		for _, b := range exit[1:] {
+
+			if a == b {
+				t.Fatalf("entry & exit both published on %s", a)
+			}
+
+		}
+
+	}
+
+}
+
+func TestGasPriceBucketTopic(t *testing.T) {
+
+	const gwei = 1_000_000_000
+
+	buckets := []float64{10, 50}
+	baseFee := big.NewInt(20 * gwei)
+
+	for name, c := range map[string]struct {
+		tx       *MemPoolTx
+		baseFee  *big.Int
+		expected string
+	}{
+		"lowest":    {legacyTx(hashOf(0), addrOf(0), 0, 5*gwei), baseFee, "entry_gwei_0_10"},
+		"boundary":  {legacyTx(hashOf(1), addrOf(0), 0, 50*gwei), baseFee, "entry_gwei_50_inf"},
+		"effective": {dynamicTx(hashOf(2), addrOf(0), 0, 100*gwei, 2*gwei), baseFee, "entry_gwei_10_50"},
+		"feeCap":    {dynamicTx(hashOf(3), addrOf(0), 0, 100*gwei, 2*gwei), nil, "entry_gwei_50_inf"},
+	} {
+
+		if topic := GasPriceBucketTopic("entry", c.tx, buckets, c.baseFee); topic != c.expected {
+			t.Fatalf("%s : expected %s, got %s", name, c.expected, topic)
+		}
+
+	}
+
+	if topic := GasPriceBucketTopic("entry", legacyTx(hashOf(0), addrOf(0), 0, gwei), nil, baseFee); len(topic) != 0 {
+		t.Fatalf("expected no topic without buckets, got %s", topic)
+	}
+
+}
+
+func TestIsDropped(t *testing.T) {
+
+	ctx := context.Background()
+	tx := legacyTx(hashOf(0), addrOf(0), 0, 1_000_000_000)
+
+	for name, c := range map[string]struct {
+		endpoint string
+		dropped  bool
+		failed   bool
+	}{
+		"noReceipt":   {newTestEndpoint(t, `null`), true, false},
+		"receipt":     {newTestEndpoint(t, `{"status":"0x1"}`), false, false},
+		"unreachable": {"http://127.0.0.1:1", false, true},
+	} {
+
+		client, err := rpc.DialHTTP(c.endpoint)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dropped, err := tx.IsDropped(ctx, client)
+		client.Close()
+
+		if dropped != c.dropped || (err != nil) != c.failed {
+			t.Fatalf("%s : expected (%v, failed %v), got (%v, %v)", name, c.dropped, c.failed, dropped, err)
+		}
+
+	}
+
+}
@@ -1,6 +1,10 @@
 package data
 
-import "github.com/ethereum/go-ethereum/common/hexutil"
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
 
 type TxList interface {
 	len() int
@@ -29,10 +33,6 @@ func Insert(txs TxList, tx *MemPoolTx) TxList {
 
 		switch txs.(type) {
 
-		case MemPoolTxsAsc:
-			return (MemPoolTxsAsc)(_txs)
-		case MemPoolTxsDesc:
-			return (MemPoolTxsDesc)(_txs)
 		case TxsFromAddressAsc:
 			return (TxsFromAddressAsc)(_txs)
 		default:
@@ -55,10 +55,6 @@ func Insert(txs TxList, tx *MemPoolTx) TxList {
 
 	switch txs.(type) {
 
-	case MemPoolTxsAsc:
-		return (MemPoolTxsAsc)(_txs)
-	case MemPoolTxsDesc:
-		return (MemPoolTxsDesc)(_txs)
 	case TxsFromAddressAsc:
 		return (TxsFromAddressAsc)(_txs)
 	default:
@@ -84,10 +80,6 @@ func Remove(txs TxList, tx *MemPoolTx) TxList {
 
 	switch txs.(type) {
 
-	case MemPoolTxsAsc:
-		return (MemPoolTxsAsc)(_txs)
-	case MemPoolTxsDesc:
-		return (MemPoolTxsDesc)(_txs)
 	case TxsFromAddressAsc:
 		return (TxsFromAddressAsc)(_txs)
 	default:
@@ -154,3 +146,68 @@ func UntilNonceGap(txs []*MemPoolTx, nonce hexutil.Uint64) []*MemPoolTx {
 
 	return result
 }
+
+// StreamChunkSize - When streaming txs out of pool, these many of them
+// are copied out at a time, so that memory usage stays bounded, irrespective
+// of how many were requested
+const StreamChunkSize = 256
+
+// StreamTxs - Streams at max `x` txs over returned channel, by copying chunks of
+// txs out of pool, one after another, using provided `window` function
+//
+// Channel gets closed when either `x` txs are sent/ pool has nothing more
+// to offer/ context gets cancelled
+//
+// @note Each chunk is consistent in itself, but pool keeps changing in between
+// chunks, while window is positioned by offset. So tx joining ahead of current
+// offset makes last tx of previous chunk show up again, while one leaving ahead
+// of it makes first tx of next chunk get skipped. Consumers needing exact view
+// must either dedup by hash or list whole pool at once
+func StreamTxs(ctx context.Context, x uint64, window func(context.Context, uint64, uint64) []*MemPoolTx) <-chan *MemPoolTx {
+
+	comm := make(chan *MemPoolTx, StreamChunkSize)
+
+	go func() {
+
+		defer close(comm)
+
+		var offset uint64
+
+		for offset < x {
+
+			limit := x - offset
+			if limit > StreamChunkSize {
+				limit = StreamChunkSize
+			}
+
+			txs := window(ctx, offset, limit)
+
+			for i := 0; i < len(txs); i++ {
+
+				select {
+
+				case <-ctx.Done():
+					return
+
+				case comm <- txs[i]:
+
+				}
+
+			}
+
+			CleanSlice(txs)
+
+			// Pool has nothing more to offer
+			if uint64(len(txs)) < limit {
+				return
+			}
+
+			offset += limit
+
+		}
+
+	}()
+
+	return comm
+
+}
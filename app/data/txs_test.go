@@ -0,0 +1,86 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestStreamTxs(t *testing.T) {
+
+	ctx := context.Background()
+
+	pool := make([]*MemPoolTx, 0, 2*StreamChunkSize)
+	for i := 0; i < 2*StreamChunkSize; i++ {
+		pool = append(pool, legacyTx(hashOf(i), addrOf(i), 0, 1_000_000_000))
+	}
+
+	window := func(_ context.Context, offset uint64, limit uint64) []*MemPoolTx {
+
+		if offset >= uint64(len(pool)) {
+			return nil
+		}
+
+		end := offset + limit
+		if end > uint64(len(pool)) {
+			end = uint64(len(pool))
+		}
+
+		return append([]*MemPoolTx(nil), pool[offset:end]...)
+
+	}
+
+	var streamed int
+	for range StreamTxs(ctx, 0, window) {
+		streamed++
+	}
+
+	if streamed != 0 {
+		t.Fatalf("expected nothing for x = 0, got %d", streamed)
+	}
+
+	// Unchanged pool is streamed as is, stopping when it's exhausted
+	streamed = 0
+	for tx := range StreamTxs(ctx, 3*StreamChunkSize, window) {
+
+		if tx != pool[streamed] {
+			t.Fatalf("tx %d : streamed out of order", streamed)
+		}
+
+		streamed++
+
+	}
+
+	if streamed != len(pool) {
+		t.Fatalf("expected %d tx(s), got %d", len(pool), streamed)
+	}
+
+	// Tx joining ahead of offset, once first chunk is copied out,
+	// makes its last tx show up again, as first of next chunk
+	seen := make(map[common.Hash]int)
+	var chunks int
+
+	moving := func(ctx context.Context, offset uint64, limit uint64) []*MemPoolTx {
+
+		if chunks++; chunks == 2 {
+			pool = append([]*MemPoolTx{legacyTx(hashOf(-1), addrOf(-1), 0, 1_000_000_000)}, pool...)
+		}
+
+		return window(ctx, offset, limit)
+
+	}
+
+	for tx := range StreamTxs(ctx, 2*StreamChunkSize, moving) {
+		seen[tx.Hash]++
+	}
+
+	if n := seen[hashOf(StreamChunkSize-1)]; n != 2 {
+		t.Fatalf("expected last tx of first chunk to be streamed twice, got %d", n)
+	}
+
+	if n := seen[hashOf(-1)]; n != 0 {
+		t.Fatal("tx joining ahead of already streamed chunk got streamed")
+	}
+
+}
@@ -0,0 +1,218 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Upstreams - RPC endpoints of Ethereum node(s), `harmony` can talk to. One of
+// them is active at a time, when it fails, next healthy one takes over, while
+// failed one is periodically checked for getting back to healthy state
+type Upstreams struct {
+	lock    sync.RWMutex
+	urls    []string
+	clients []*rpc.Client
+	healthy []bool
+	active  int
+}
+
+// NewUpstreams - Connects to all given RPC endpoints, first one which could
+// be connected to being active to start with
+//
+// Endpoints which can't be connected to are marked unhealthy, to be dialed
+// again by health checker, boot fails only if none of them can be.
+func NewUpstreams(ctx context.Context, urls []string) (*Upstreams, error) {
+
+	if len(urls) == 0 {
+		return nil, errors.New("no RPC endpoint provided")
+	}
+
+	u := &Upstreams{
+		urls:    urls,
+		clients: make([]*rpc.Client, len(urls)),
+		healthy: make([]bool, len(urls)),
+		active:  -1,
+	}
+
+	var err error
+
+	for i, url := range urls {
+
+		var client *rpc.Client
+
+		client, err = rpc.DialContext(ctx, url)
+		if err != nil {
+
+			// URL may carry credentials, so it's not logged
+			log.Printf("[❗️] Failed to connect to RPC endpoint #%d : %s\n", i, err.Error())
+			continue
+
+		}
+
+		u.clients[i] = client
+		u.healthy[i] = true
+
+		if u.active == -1 {
+			u.active = i
+		}
+
+	}
+
+	if u.active == -1 {
+		return nil, err
+	}
+
+	return u, nil
+
+}
+
+// Active - Client of currently active endpoint, along with its position
+// in configured endpoint list
+func (u *Upstreams) Active() (*rpc.Client, int) {
+
+	u.lock.RLock()
+	defer u.lock.RUnlock()
+
+	return u.clients[u.active], u.active
+
+}
+
+// Failover - Marks given client unhealthy & switches to next healthy endpoint,
+// if any, otherwise simply moves to next one, returning whether switched
+// endpoint is known to be healthy or not
+//
+// @note If some other go routine has already switched away from failed
+// client, it's not switched again
+func (u *Upstreams) Failover(failed *rpc.Client) bool {
+
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	for i, client := range u.clients {
+
+		if client == failed {
+			u.healthy[i] = false
+		}
+
+	}
+
+	if u.clients[u.active] != failed {
+		return u.healthy[u.active]
+	}
+
+	for i := 1; i <= len(u.clients); i++ {
+
+		next := (u.active + i) % len(u.clients)
+		if u.healthy[next] {
+
+			u.active = next
+			return true
+
+		}
+
+	}
+
+	// None is healthy, still moving on to next one which is
+	// connected to, hoping it'll work
+	for i := 1; i <= len(u.clients); i++ {
+
+		next := (u.active + i) % len(u.clients)
+		if u.clients[next] != nil {
+
+			u.active = next
+			break
+
+		}
+
+	}
+
+	return false
+
+}
+
+// CheckHealth - Periodically checks whether unhealthy endpoints are responding
+// again, so that they can be failed over to later
+//
+// @note This method is supposed to be run as independent go routine
+func (u *Upstreams) CheckHealth(ctx context.Context, period time.Duration) {
+
+	for {
+
+		select {
+
+		case <-ctx.Done():
+			return
+
+		case <-time.After(period):
+
+			for i := range u.urls {
+
+				u.lock.RLock()
+				client, healthy := u.clients[i], u.healthy[i]
+				u.lock.RUnlock()
+
+				if healthy {
+					continue
+				}
+
+				// Couldn't be connected to during start up, so
+				// attempting again
+				dialed := client == nil
+				if dialed {
+
+					var err error
+
+					client, err = rpc.DialContext(ctx, u.urls[i])
+					if err != nil {
+						continue
+					}
+
+				}
+
+				var version string
+				if err := client.CallContext(ctx, &version, "net_version"); err != nil {
+
+					if dialed {
+						client.Close()
+					}
+
+					continue
+
+				}
+
+				u.lock.Lock()
+				u.clients[i] = client
+				u.healthy[i] = true
+				u.lock.Unlock()
+
+				// URL may carry credentials, so it's not logged
+				log.Printf("[✅] RPC endpoint #%d is healthy again\n", i)
+
+			}
+
+		}
+
+	}
+
+}
+
+// Close - Closes connection to all endpoints
+func (u *Upstreams) Close() {
+
+	u.lock.RLock()
+	defer u.lock.RUnlock()
+
+	for _, client := range u.clients {
+
+		if client != nil {
+			client.Close()
+		}
+
+	}
+
+}
@@ -0,0 +1,99 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// undialable - Nothing listens on this port, so websocket connection
+// can't be set up
+const undialable = "ws://127.0.0.1:1"
+
+func TestUpstreamsSkipUndialable(t *testing.T) {
+
+	u, err := NewUpstreams(context.Background(), []string{undialable, newTestEndpoint(t, `"1"`)})
+	if err != nil {
+		t.Fatalf("boot failed due to one undialable endpoint : %s", err.Error())
+	}
+	defer u.Close()
+
+	client, active := u.Active()
+	if active != 1 || client == nil {
+		t.Fatalf("expected endpoint #1 to be active, got #%d", active)
+	}
+
+	// Nothing else to switch to, but must never land on
+	// endpoint which isn't connected to
+	if u.Failover(client) {
+		t.Fatal("failed over to unhealthy endpoint, reported as healthy")
+	}
+
+	if client, active = u.Active(); active != 1 || client == nil {
+		t.Fatalf("expected endpoint #1 to stay active, got #%d", active)
+	}
+
+}
+
+func TestUpstreamsAllUndialable(t *testing.T) {
+
+	if _, err := NewUpstreams(context.Background(), []string{undialable, undialable}); err == nil {
+		t.Fatal("expected boot to fail, when no endpoint can be connected to")
+	}
+
+}
+
+func TestUpstreamsFailover(t *testing.T) {
+
+	u, err := NewUpstreams(context.Background(), []string{newTestEndpoint(t, `"1"`), newTestEndpoint(t, `"1"`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer u.Close()
+
+	first, _ := u.Active()
+
+	if !u.Failover(first) {
+		t.Fatal("expected to fail over to healthy endpoint")
+	}
+
+	if _, active := u.Active(); active != 1 {
+		t.Fatalf("expected endpoint #1 to be active, got #%d", active)
+	}
+
+	// Some other go routine failing on same client, must not
+	// switch once again
+	if !u.Failover(first) {
+		t.Fatal("expected active endpoint to be healthy")
+	}
+
+	if _, active := u.Active(); active != 1 {
+		t.Fatalf("expected endpoint #1 to stay active, got #%d", active)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go u.CheckHealth(ctx, 10*time.Millisecond)
+
+	deadline := time.Now().Add(time.Second)
+
+	for {
+
+		u.lock.RLock()
+		healthy := u.healthy[0]
+		u.lock.RUnlock()
+
+		if healthy {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatal("failed endpoint not found healthy again")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+
+	}
+
+}
@@ -0,0 +1,222 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// maxRemovals - At max these many tx(s)' removal reason is remembered, once
+// reached, oldest one is forgotten for each new one
+const maxRemovals = 1 << 14
+
+// removalWaiters - Go routines waiting for specific tx(s) to leave mempool,
+// keyed by tx hash, let known by removal callbacks registered with both
+// pools. Why tx(s) left is remembered, for answering ones asking late.
+//
+// @note Zero value is ready to use
+type removalWaiters struct {
+	registerLock sync.Mutex
+	registered   int
+	lock         sync.Mutex
+	waiting      map[common.Hash][]chan string
+	removed      map[common.Hash]string
+	order        []common.Hash
+}
+
+// register - Registers removal callbacks with both pools, unless it's
+// already done. If `ctx` gets cancelled midway, ones which are yet to be
+// heard by pools are retried on next invocation.
+func (r *removalWaiters) register(ctx context.Context, pending *PendingPool, queued *QueuedPool) bool {
+
+	r.registerLock.Lock()
+	defer r.registerLock.Unlock()
+
+	steps := []func() bool{
+		func() bool { return queued.OnRemove(ctx, r.notify) },
+		func() bool { return pending.OnRemove(ctx, r.notify) },
+	}
+
+	for ; r.registered < len(steps); r.registered++ {
+
+		if !steps[r.registered]() {
+			return false
+		}
+
+	}
+
+	return true
+
+}
+
+// remember - Keeps removal reason of tx, forgetting oldest one, when
+// already at capacity
+//
+// @note Must be invoked while holding lock
+func (r *removalWaiters) remember(hash common.Hash, reason string) {
+
+	if r.removed == nil {
+		r.removed = make(map[common.Hash]string)
+	}
+
+	if _, ok := r.removed[hash]; !ok {
+
+		if len(r.order) >= maxRemovals {
+			delete(r.removed, r.order[0])
+			r.order = r.order[1:]
+		}
+
+		r.order = append(r.order, hash)
+
+	}
+
+	r.removed[hash] = reason
+
+}
+
+// notify - Removal callback, letting all waiters of removed tx know why
+// it left mempool. Tx getting unstuck only moves from queued pool to
+// pending one, so waiters keep waiting.
+//
+// @note Invoked from pools' go routines, never blocks because each
+// waiter's channel is buffered & receives only once
+func (r *removalWaiters) notify(tx *MemPoolTx, reason string) {
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.remember(tx.Hash, reason)
+
+	if reason == "unstuck" {
+		return
+	}
+
+	for _, ch := range r.waiting[tx.Hash] {
+		ch <- reason
+	}
+
+	delete(r.waiting, tx.Hash)
+
+}
+
+// reasonOf - Why tx left pool, if it's known to have left
+func (r *removalWaiters) reasonOf(hash common.Hash) (string, bool) {
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	reason, ok := r.removed[hash]
+	return reason, ok
+
+}
+
+// add - Registers new waiter for given tx, returning channel, where
+// removal reason will be delivered
+func (r *removalWaiters) add(hash common.Hash) chan string {
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.waiting == nil {
+		r.waiting = make(map[common.Hash][]chan string)
+	}
+
+	ch := make(chan string, 1)
+	r.waiting[hash] = append(r.waiting[hash], ch)
+
+	return ch
+
+}
+
+// remove - Unregisters waiter, if it's still waiting, so that giving up
+// doesn't leave anything behind
+func (r *removalWaiters) remove(hash common.Hash, ch chan string) {
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	chans := r.waiting[hash]
+
+	for i := 0; i < len(chans); i++ {
+
+		if chans[i] != ch {
+			continue
+		}
+
+		chans = append(chans[:i], chans[i+1:]...)
+		break
+
+	}
+
+	if len(chans) == 0 {
+		delete(r.waiting, hash)
+		return
+	}
+
+	r.waiting[hash] = chans
+
+}
+
+// TrackRemovals - Registers removal callbacks with both pools, so that why
+// tx(s) leave mempool is remembered from very beginning, to be invoked once
+// pools are started
+func (m *MemPool) TrackRemovals(ctx context.Context) bool {
+
+	return m.waiters.register(ctx, m.Pending, m.Queued)
+
+}
+
+// WaitForRemoval - Blocks until given tx leaves mempool, returning reason
+// i.e. `confirmed`/ `dropped`, or until `ctx` is cancelled. Tx getting
+// unstuck from queued pool is waited for, until it leaves pending pool.
+//
+// If tx has already left, reason is returned immediately, given it's still
+// remembered, otherwise if it's not in mempool, returns with error, because
+// there's nothing to wait for.
+//
+// @note No polling involved, waiters are let known by removal callbacks
+// registered with both pools
+func (m *MemPool) WaitForRemoval(ctx context.Context, hash common.Hash) (string, error) {
+
+	if !m.TrackRemovals(ctx) {
+		return "", ctx.Err()
+	}
+
+	// Registering before checking presence, so that removal
+	// happening in between doesn't go unnoticed
+	ch := m.waiters.add(hash)
+	defer m.waiters.remove(hash, ch)
+
+	// Queued pool is looked up first, because tx only ever
+	// moves from there to pending pool
+	if !m.Queued.Exists(ctx, hash) && !m.Pending.Exists(ctx, hash) {
+
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		// Callbacks are invoked from pools' go routines before they
+		// serve existence check, so if it left in between, reason
+		// must already be known. Unless it's on its way from queued
+		// pool to pending one, it's not going to be seen again.
+		reason, ok := m.waiters.reasonOf(hash)
+		if !ok {
+			return "", errors.New("tx not in mempool")
+		}
+
+		if reason != "unstuck" {
+			return reason, nil
+		}
+
+	}
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case reason := <-ch:
+		return reason, nil
+	}
+
+}
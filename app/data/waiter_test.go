@@ -0,0 +1,152 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// waitAsync - Waits for removal of tx in another go routine, returning
+// channel where outcome is delivered
+func waitAsync(ctx context.Context, m *MemPool, i int) chan error {
+
+	done := make(chan error, 1)
+
+	go func() {
+
+		reason, err := m.WaitForRemoval(ctx, hashOf(i))
+		if err == nil && reason != "confirmed" {
+			err = errors.New("unexpected reason " + reason)
+		}
+
+		done <- err
+
+	}()
+
+	return done
+
+}
+
+func TestWaitForRemoval(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	m := &MemPool{Pending: pools.pending, Queued: pools.queued}
+
+	if !m.TrackRemovals(ctx) {
+		t.Fatal("failed to track removals")
+	}
+
+	if !pools.pending.Add(ctx, legacyTx(hashOf(0), addrOf(0), 0, 1)) {
+		t.Fatal("failed to add tx")
+	}
+
+	done := waitAsync(ctx, m, 0)
+
+	// Letting waiter find tx in pool, before it's removed
+	time.Sleep(10 * time.Millisecond)
+
+	if !pools.pending.Remove(ctx, &TxStatus{Hash: hashOf(0), Status: CONFIRMED}) {
+		t.Fatal("failed to remove tx")
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiter not let known of removal")
+	}
+
+	// Already gone, known reason is returned right away
+	if reason, err := m.WaitForRemoval(ctx, hashOf(0)); err != nil || reason != "confirmed" {
+		t.Fatalf("expected confirmed, got %q, %v", reason, err)
+	}
+
+	if _, err := m.WaitForRemoval(ctx, hashOf(1)); err == nil {
+		t.Fatal("expected error for tx never seen")
+	}
+
+}
+
+func TestWaitForRemovalFromQueued(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	m := &MemPool{Pending: pools.pending, Queued: pools.queued}
+
+	if !pools.queued.Add(ctx, legacyTx(hashOf(0), addrOf(0), 5, 1)) {
+		t.Fatal("failed to add tx")
+	}
+
+	done := waitAsync(ctx, m, 0)
+	time.Sleep(10 * time.Millisecond)
+
+	// Getting unstuck, it's still in mempool
+	if pools.queued.Remove(ctx, hashOf(0)) == nil {
+		t.Fatal("failed to remove tx from queued pool")
+	}
+
+	if !pools.pending.Add(ctx, legacyTx(hashOf(0), addrOf(0), 5, 1)) {
+		t.Fatal("failed to add tx to pending pool")
+	}
+
+	select {
+	case err := <-done:
+		t.Fatalf("waiter returned while tx is still in mempool : %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	if !pools.pending.Remove(ctx, &TxStatus{Hash: hashOf(0), Status: CONFIRMED}) {
+		t.Fatal("failed to remove tx from pending pool")
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiter not let known of removal")
+	}
+
+}
+
+func TestWaitForRemovalDropped(t *testing.T) {
+
+	setConfig(t, "QueuedPoolSize", 1)
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	m := &MemPool{Pending: pools.pending, Queued: pools.queued}
+
+	if !m.TrackRemovals(ctx) {
+		t.Fatal("failed to track removals")
+	}
+
+	if !pools.queued.Add(ctx, legacyTx(hashOf(0), addrOf(0), 5, 1)) {
+		t.Fatal("failed to add tx")
+	}
+
+	// Pool is full, so cheaper one gets dropped
+	if !pools.queued.Add(ctx, legacyTx(hashOf(1), addrOf(1), 5, 2)) {
+		t.Fatal("failed to add tx")
+	}
+
+	if reason, err := m.WaitForRemoval(ctx, hashOf(0)); err != nil || reason != "dropped" {
+		t.Fatalf("expected dropped, got %q, %v", reason, err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	if _, err := m.WaitForRemoval(cancelled, hashOf(1)); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context to be cancelled, got %v", err)
+	}
+
+}
@@ -0,0 +1,283 @@
+package data
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TxLifecycleEvent - State change of watched tx, where `State` is one of
+// `queued`, `unstuck`, `pending`, `confirmed`, `dropped`
+type TxLifecycleEvent struct {
+	Hash  common.Hash
+	State string
+	At    time.Time
+	Tx    *MemPoolTx
+}
+
+// Terminal - Whether tx has left system, after this event, so no more
+// events to be expected for it
+func (t *TxLifecycleEvent) Terminal() bool {
+	return t.State == "confirmed" || t.State == "dropped"
+}
+
+// lifecycle - Position of each state in tx's lifecycle, states are only
+// ever delivered in this order, so that events coming from different
+// pools' go routines can't be seen out of order
+var lifecycle = map[string]int{
+	"queued":    1,
+	"unstuck":   2,
+	"pending":   3,
+	"confirmed": 4,
+	"dropped":   4,
+}
+
+// txWatcher - Single subscriber watching lifecycle of some tx, where
+// accepted events are kept until picked up by watching go routine
+type txWatcher struct {
+	events []TxLifecycleEvent
+	stage  int
+	signal chan struct{}
+}
+
+// accept - Keeps event for delivery, given it moves tx forward in its
+// lifecycle, otherwise it's a stale one, arriving late from other pool's
+// go routine, which is discarded
+//
+// @note Tx joining pending pool from queued one has got unstuck, even if
+// queued pool is yet to say so, which is why it's filled in here
+//
+// @note Because states only move forward, not more than handful of events
+// are ever kept, so nothing needs to be dropped
+func (t *txWatcher) accept(event TxLifecycleEvent) {
+
+	stage := lifecycle[event.State]
+	if stage <= t.stage {
+		return
+	}
+
+	if event.State == "pending" && t.stage == lifecycle["queued"] {
+		t.events = append(t.events, TxLifecycleEvent{Hash: event.Hash, State: "unstuck", At: event.At, Tx: event.Tx})
+	}
+
+	t.events = append(t.events, event)
+	t.stage = stage
+
+	select {
+	case t.signal <- struct{}{}:
+	default:
+	}
+
+}
+
+// txWatchers - Subscribers watching lifecycle of specific tx(s), keyed by
+// tx hash, let known by addition/ removal callbacks registered with both
+// pools
+//
+// @note Zero value is ready to use
+type txWatchers struct {
+	registerLock sync.Mutex
+	registered   int
+	lock         sync.Mutex
+	watching     map[common.Hash][]*txWatcher
+}
+
+// register - Registers callbacks with both pools, unless it's already
+// done. If `ctx` gets cancelled midway, ones which are yet to be heard
+// by pools are retried on next invocation.
+func (w *txWatchers) register(ctx context.Context, pending *PendingPool, queued *QueuedPool) bool {
+
+	w.registerLock.Lock()
+	defer w.registerLock.Unlock()
+
+	steps := []func() bool{
+		func() bool { return queued.OnAdd(ctx, func(tx *MemPoolTx) { w.notify(tx, "queued") }) },
+		func() bool {
+			return queued.OnRemove(ctx, func(tx *MemPoolTx, reason string) { w.notify(tx, reason) })
+		},
+		func() bool { return pending.OnAdd(ctx, func(tx *MemPoolTx) { w.notify(tx, "pending") }) },
+		func() bool {
+			return pending.OnRemove(ctx, func(tx *MemPoolTx, reason string) { w.notify(tx, reason) })
+		},
+	}
+
+	for ; w.registered < len(steps); w.registered++ {
+
+		if !steps[w.registered]() {
+			return false
+		}
+
+	}
+
+	return true
+
+}
+
+// notify - Delivers event to all watchers of tx, unregistering them
+// when it's a terminal one
+//
+// @note Invoked from pools' go routines, never blocks
+func (w *txWatchers) notify(tx *MemPoolTx, state string) {
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	watchers, ok := w.watching[tx.Hash]
+	if !ok {
+		return
+	}
+
+	event := TxLifecycleEvent{Hash: tx.Hash, State: state, At: time.Now().UTC(), Tx: tx}
+
+	for _, watcher := range watchers {
+		watcher.accept(event)
+	}
+
+	if event.Terminal() {
+		delete(w.watching, tx.Hash)
+	}
+
+}
+
+// seed - Lets watcher know of state tx is found in, when it starts
+// watching, unless some state change has already been delivered
+func (w *txWatchers) seed(watcher *txWatcher, event TxLifecycleEvent) {
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	watcher.accept(event)
+
+}
+
+// pop - Picks up events kept for watcher, in order
+func (w *txWatchers) pop(watcher *txWatcher) []TxLifecycleEvent {
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	events := watcher.events
+	watcher.events = nil
+
+	return events
+
+}
+
+// add - Registers new watcher for given tx
+func (w *txWatchers) add(hash common.Hash) *txWatcher {
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.watching == nil {
+		w.watching = make(map[common.Hash][]*txWatcher)
+	}
+
+	watcher := &txWatcher{signal: make(chan struct{}, 1)}
+	w.watching[hash] = append(w.watching[hash], watcher)
+
+	return watcher
+
+}
+
+// remove - Unregisters watcher, if it's still registered, so that giving
+// up doesn't leave anything behind
+func (w *txWatchers) remove(hash common.Hash, watcher *txWatcher) {
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	watchers := w.watching[hash]
+
+	for i := 0; i < len(watchers); i++ {
+
+		if watchers[i] != watcher {
+			continue
+		}
+
+		watchers = append(watchers[:i], watchers[i+1:]...)
+		break
+
+	}
+
+	if len(watchers) == 0 {
+		delete(w.watching, hash)
+		return
+	}
+
+	w.watching[hash] = watchers
+
+}
+
+// WatchTx - Streams state changes of given tx i.e. joining queued pool,
+// getting unstuck, joining pending pool, getting confirmed/ dropped, until
+// it leaves system or `ctx` is cancelled, when channel gets closed
+//
+// If tx is already living in some pool, its current state is sent first,
+// otherwise it's watched for, in case it shows up in some later poll.
+//
+// States are always delivered in lifecycle order, even though they're heard
+// from two different pools, and none of them get lost when consumer is slow.
+//
+// If callbacks can't be registered with pools before `ctx` is cancelled,
+// returned channel is already closed.
+//
+// @note No polling involved, watchers are let known by callbacks registered
+// with both pools, on first invocation
+func (m *MemPool) WatchTx(ctx context.Context, hash common.Hash) <-chan TxLifecycleEvent {
+
+	if !m.watchers.register(ctx, m.Pending, m.Queued) {
+
+		out := make(chan TxLifecycleEvent)
+		close(out)
+
+		return out
+
+	}
+
+	// Registering before looking up, so that state change
+	// happening in between doesn't go unnoticed
+	watcher := m.watchers.add(hash)
+
+	if tx := m.Get(ctx, hash); tx != nil {
+		m.watchers.seed(watcher, TxLifecycleEvent{Hash: hash, State: tx.Pool, At: time.Now().UTC(), Tx: tx})
+	}
+
+	out := make(chan TxLifecycleEvent)
+
+	go func() {
+
+		defer close(out)
+		defer m.watchers.remove(hash, watcher)
+
+		for {
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-watcher.signal:
+			}
+
+			for _, event := range m.watchers.pop(watcher) {
+
+				select {
+				case <-ctx.Done():
+					return
+				case out <- event:
+				}
+
+				if event.Terminal() {
+					return
+				}
+
+			}
+
+		}
+
+	}()
+
+	return out
+
+}
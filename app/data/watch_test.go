@@ -0,0 +1,130 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// expectStates - Reads events off watch channel, expecting given states in
+// order, followed by channel getting closed
+func expectStates(t *testing.T, events <-chan TxLifecycleEvent, states ...string) {
+
+	t.Helper()
+
+	for _, state := range states {
+
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatalf("channel closed, while expecting %s", state)
+			}
+
+			if event.State != state {
+				t.Fatalf("expected %s, got %s", state, event.State)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s not delivered", state)
+		}
+
+	}
+
+	select {
+	case event, ok := <-events:
+		if ok {
+			t.Fatalf("expected channel to be closed, got %s", event.State)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed after terminal state")
+	}
+
+}
+
+func TestWatchTxLifecycle(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	m := &MemPool{Pending: pools.pending, Queued: pools.queued}
+
+	events := m.WatchTx(ctx, hashOf(0))
+
+	if !pools.queued.Add(ctx, legacyTx(hashOf(0), addrOf(0), 1, 1)) {
+		t.Fatal("failed to add tx to queued pool")
+	}
+
+	// Joining pending pool before queued pool lets go of it
+	if !pools.pending.Add(ctx, legacyTx(hashOf(0), addrOf(0), 1, 1)) {
+		t.Fatal("failed to add tx to pending pool")
+	}
+
+	if pools.queued.Remove(ctx, hashOf(0)) == nil {
+		t.Fatal("failed to remove tx from queued pool")
+	}
+
+	if !pools.pending.Remove(ctx, &TxStatus{Hash: hashOf(0), Status: CONFIRMED}) {
+		t.Fatal("failed to remove tx from pending pool")
+	}
+
+	expectStates(t, events, "queued", "unstuck", "pending", "confirmed")
+
+}
+
+func TestWatchTxOutOfOrder(t *testing.T) {
+
+	var w txWatchers
+
+	watcher := w.add(hashOf(0))
+	tx := legacyTx(hashOf(0), addrOf(0), 0, 1)
+
+	// Pending pool's go routine is heard from, before queued one's
+	for _, state := range []string{"queued", "pending", "unstuck", "confirmed"} {
+		w.notify(tx, state)
+	}
+
+	var states []string
+	for _, event := range w.pop(watcher) {
+		states = append(states, event.State)
+	}
+
+	expected := []string{"queued", "unstuck", "pending", "confirmed"}
+
+	if len(states) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, states)
+	}
+
+	for i := range expected {
+
+		if states[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, states)
+		}
+
+	}
+
+	if _, ok := w.watching[hashOf(0)]; ok {
+		t.Fatal("watcher not unregistered on terminal state")
+	}
+
+}
+
+func TestWatchTxSlowConsumer(t *testing.T) {
+
+	pools := startPools(t)
+	ctx := context.Background()
+
+	m := &MemPool{Pending: pools.pending, Queued: pools.queued}
+
+	events := m.WatchTx(ctx, hashOf(0))
+
+	// Nobody reading, while tx goes through its whole lifecycle,
+	// terminal event must still make it
+	for i := 0; i < 8; i++ {
+
+		pools.pending.Add(ctx, legacyTx(hashOf(0), addrOf(0), 1, 1))
+		pools.pending.Remove(ctx, &TxStatus{Hash: hashOf(0), Status: DROPPED})
+
+	}
+
+	expectStates(t, events, "pending", "dropped")
+
+}
@@ -0,0 +1,117 @@
+package data
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/itzmeanjan/pub0sub/ops"
+	"github.com/itzmeanjan/pub0sub/publisher"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Watchdog - Dead man's switch, keeps track of when any new tx was
+// last added into mempool. If nothing is seen for configured period of time,
+// it raises alert, which gets cleared as soon as new tx is seen again
+//
+// @note Pools let it know about newly added tx(s), from their add path
+type Watchdog struct {
+	LastSeenAt time.Time
+	Alerted    bool
+	SeenChan   chan time.Time
+	PubSub     *publisher.Publisher
+}
+
+// DeadMansSwitch - Alert raised/ cleared by watchdog, to be published
+// on pubsub topic, in this form
+type DeadMansSwitch struct {
+	Fired      bool
+	LastSeenAt time.Time
+	At         time.Time
+}
+
+// ToMessagePack - Serialize to message pack encoded byte array format
+func (d *DeadMansSwitch) ToMessagePack() ([]byte, error) {
+
+	return msgpack.Marshal(d)
+
+}
+
+// Seen - Letting watchdog know new tx was just added into mempool
+//
+// @note This is non-blocking call, if watchdog hasn't yet consumed
+// last notification, this one is simply skipped
+func (w *Watchdog) Seen() {
+
+	select {
+	case w.SeenChan <- time.Now().UTC():
+	default:
+	}
+
+}
+
+// Start - Watchdog's life cycle, to be run as a seperate go routine
+func (w *Watchdog) Start(ctx context.Context) {
+
+	for {
+
+		select {
+
+		case <-ctx.Done():
+			return
+
+		case at := <-w.SeenChan:
+
+			quiet := at.Sub(w.LastSeenAt)
+			w.LastSeenAt = at
+
+			if w.Alerted {
+
+				w.Alerted = false
+
+				log.Printf("[✅] Dead man's switch cleared, new tx seen after %s\n", quiet)
+				w.Publish(ctx, &DeadMansSwitch{Fired: false, LastSeenAt: w.LastSeenAt, At: time.Now().UTC()})
+
+			}
+
+		case <-time.After(time.Duration(1) * time.Second):
+
+			if w.Alerted {
+				break
+			}
+
+			period := time.Duration(config.GetDeadMansSwitchPeriod()) * time.Millisecond
+			if time.Now().UTC().Sub(w.LastSeenAt) < period {
+				break
+			}
+
+			w.Alerted = true
+
+			log.Printf("[❗️] Dead man's switch fired, no new tx seen in last %s\n", time.Now().UTC().Sub(w.LastSeenAt))
+			w.Publish(ctx, &DeadMansSwitch{Fired: true, LastSeenAt: w.LastSeenAt, At: time.Now().UTC()})
+
+		}
+
+	}
+
+}
+
+// Publish - Publish dead man's switch alert ( in messagepack serialized format )
+// to pubsub topic
+func (w *Watchdog) Publish(ctx context.Context, msg *DeadMansSwitch) {
+
+	data, err := msg.ToMessagePack()
+	if err != nil {
+		log.Printf("[❗️] Failed to serialize into messagepack : %s\n", err.Error())
+		return
+	}
+
+	if _, err := w.PubSub.Publish(&ops.Msg{
+		Topics: []string{config.GetDeadMansSwitchPublishTopic()},
+		Data:   data,
+	}); err != nil {
+		log.Printf("[❗️] Failed to publish dead man's switch alert : %s\n", err.Error())
+	}
+
+}
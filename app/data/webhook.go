@@ -0,0 +1,174 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gammazero/workerpool"
+)
+
+// Webhook - Keeps watch-list of addresses, whenever tx sent from/ to any
+// of them enters pending pool, it's POST-ed as JSON to configured URL
+//
+// Deliveries are done by bounded worker pool, with retries, so that
+// slow/ failing endpoint never blocks pool. At max `QueueSize` of them
+// can be waiting/ in progress, rest are dropped.
+//
+// @note Empty `URL` disables webhook
+type Webhook struct {
+	URL       string
+	Retries   uint64
+	QueueSize uint64
+	Workers   *workerpool.WorkerPool
+	Client    *http.Client
+	lock      sync.RWMutex
+	watched   map[common.Address]struct{}
+	queued    uint64
+}
+
+// AddWatch - Adds address to watch-list
+func (w *Webhook) AddWatch(addr common.Address) {
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.watched == nil {
+		w.watched = make(map[common.Address]struct{})
+	}
+
+	w.watched[addr] = struct{}{}
+
+}
+
+// RemoveWatch - Removes address from watch-list
+func (w *Webhook) RemoveWatch(addr common.Address) {
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	delete(w.watched, addr)
+
+}
+
+// isWatched - Checks whether tx is sent from/ to any watched address
+func (w *Webhook) isWatched(tx *MemPoolTx) bool {
+
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+
+	if _, ok := w.watched[tx.From]; ok {
+		return true
+	}
+
+	// Contract creation tx doesn't have `to` address
+	if tx.To == nil {
+		return false
+	}
+
+	_, ok := w.watched[*tx.To]
+	return ok
+
+}
+
+// Fire - Submits delivery of tx to webhook, if it's sent from/ to any
+// watched address, to be invoked from pending pool's add path
+//
+// @note This is non-blocking call, delivery happens in worker pool
+func (w *Webhook) Fire(ctx context.Context, tx *MemPoolTx) {
+
+	if w == nil || len(w.URL) == 0 || !w.isWatched(tx) {
+		return
+	}
+
+	body, err := json.Marshal(tx.ToGraphQL())
+	if err != nil {
+		limitedLog.Printf("[❗️] Failed to serialize into JSON : %s\n", err.Error())
+		return
+	}
+
+	hash := tx.Hash
+
+	// Endpoint isn't keeping up, rather than piling up deliveries
+	// without bound, newer ones are dropped
+	if atomic.AddUint64(&w.queued, 1) > w.QueueSize {
+
+		atomic.AddUint64(&w.queued, ^uint64(0))
+		limitedLog.Printf("[❗️] Dropped webhook delivery for tx %s : queue full\n", hash.Hex())
+
+		return
+
+	}
+
+	w.Workers.Submit(func() {
+
+		defer atomic.AddUint64(&w.queued, ^uint64(0))
+
+		if err := w.deliver(ctx, body); err != nil {
+			limitedLog.Printf("[❗️] Failed to deliver webhook for tx %s : %s\n", hash.Hex(), err.Error())
+		}
+
+	})
+
+}
+
+// deliver - POSTs body to webhook URL, retrying with exponential backoff,
+// until it's accepted or retries are exhausted
+func (w *Webhook) deliver(ctx context.Context, body []byte) error {
+
+	var err error
+	delay := 500 * time.Millisecond
+
+	for attempt := uint64(0); attempt <= w.Retries; attempt++ {
+
+		if attempt != 0 {
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+
+			delay *= 2
+
+		}
+
+		if err = w.post(ctx, body); err == nil {
+			return nil
+		}
+
+	}
+
+	return err
+
+}
+
+// post - Single delivery attempt, any non-2xx response is considered failure
+func (w *Webhook) post(ctx context.Context, body []byte) error {
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+
+}
@@ -0,0 +1,153 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gammazero/workerpool"
+)
+
+// newTestWebhook - Webhook delivering to given handler, with one worker
+func newTestWebhook(t *testing.T, handler http.HandlerFunc, queueSize uint64) *Webhook {
+
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	workers := workerpool.New(1)
+	t.Cleanup(workers.StopWait)
+
+	return &Webhook{
+		URL:       srv.URL,
+		QueueSize: queueSize,
+		Workers:   workers,
+		Client:    srv.Client(),
+	}
+
+}
+
+func TestWebhookIsWatched(t *testing.T) {
+
+	w := &Webhook{}
+	w.AddWatch(addrOf(0))
+	w.AddWatch(addrOf(1))
+
+	to := addrOf(1)
+
+	fromWatched := legacyTx(hashOf(0), addrOf(0), 0, 1)
+	toWatched := legacyTx(hashOf(1), addrOf(2), 0, 1)
+	toWatched.To = &to
+	creation := legacyTx(hashOf(2), addrOf(3), 0, 1)
+
+	if !w.isWatched(fromWatched) {
+		t.Fatal("tx sent from watched address not matched")
+	}
+
+	if !w.isWatched(toWatched) {
+		t.Fatal("tx sent to watched address not matched")
+	}
+
+	if w.isWatched(creation) {
+		t.Fatal("contract creation tx from unwatched address matched")
+	}
+
+	w.RemoveWatch(addrOf(0))
+
+	if w.isWatched(fromWatched) {
+		t.Fatal("tx sent from unwatched address matched")
+	}
+
+}
+
+func TestWebhookFireNil(t *testing.T) {
+
+	var w *Webhook
+	w.Fire(context.Background(), legacyTx(hashOf(0), addrOf(0), 0, 1))
+
+}
+
+func TestWebhookDelivery(t *testing.T) {
+
+	received := make(chan string, 1)
+
+	w := newTestWebhook(t, func(rw http.ResponseWriter, r *http.Request) {
+
+		var body struct {
+			Hash string `json:"hash"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode body : %s", err.Error())
+		}
+
+		received <- body.Hash
+
+	}, 16)
+	w.AddWatch(addrOf(0))
+
+	tx := legacyTx(hashOf(0), addrOf(0), 0, 1)
+	tx.Pool = "pending"
+
+	// Not watched, must not be delivered
+	other := legacyTx(hashOf(1), addrOf(1), 0, 1)
+	other.Pool = "pending"
+
+	w.Fire(context.Background(), other)
+	w.Fire(context.Background(), tx)
+
+	select {
+	case hash := <-received:
+		if hash != hashOf(0).Hex() {
+			t.Fatalf("expected %s to be delivered, got %s", hashOf(0).Hex(), hash)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("webhook not delivered")
+	}
+
+}
+
+func TestWebhookQueueBound(t *testing.T) {
+
+	release := make(chan struct{})
+	var delivered uint64
+
+	w := newTestWebhook(t, func(rw http.ResponseWriter, r *http.Request) {
+
+		<-release
+		atomic.AddUint64(&delivered, 1)
+
+	}, 2)
+	w.AddWatch(addrOf(0))
+
+	// Endpoint is stuck, only first 2 get queued
+	for i := 0; i < 10; i++ {
+
+		tx := legacyTx(hashOf(i), addrOf(0), uint64(i), 1)
+		tx.Pool = "pending"
+
+		w.Fire(context.Background(), tx)
+
+	}
+
+	if n := atomic.LoadUint64(&w.queued); n != 2 {
+		t.Fatalf("expected 2 queued deliveries, found %d", n)
+	}
+
+	close(release)
+	w.Workers.StopWait()
+
+	if n := atomic.LoadUint64(&delivered); n != 2 {
+		t.Fatalf("expected 2 deliveries, found %d", n)
+	}
+
+	if n := atomic.LoadUint64(&w.queued); n != 0 {
+		t.Fatalf("expected queue to be drained, found %d", n)
+	}
+
+}
@@ -63,6 +63,7 @@ type ComplexityRoot struct {
 	}
 
 	Query struct {
+		FreshThan                   func(childComplexity int, seconds int) int
 		PendingDuplicates           func(childComplexity int, hash string) int
 		PendingForLessThan          func(childComplexity int, x string) int
 		PendingForMoreThan          func(childComplexity int, x string) int
@@ -74,6 +75,7 @@ type ComplexityRoot struct {
 		QueuedForLessThan           func(childComplexity int, x string) int
 		QueuedForMoreThan           func(childComplexity int, x string) int
 		QueuedFrom                  func(childComplexity int, addr string) int
+		QueuedOlderThan             func(childComplexity int, seconds int) int
 		QueuedTo                    func(childComplexity int, addr string) int
 		QueuedWithLessThan          func(childComplexity int, x float64) int
 		QueuedWithMoreThan          func(childComplexity int, x float64) int
@@ -88,9 +90,9 @@ type ComplexityRoot struct {
 		NewConfirmedTx          func(childComplexity int) int
 		NewConfirmedTxFrom      func(childComplexity int, address string) int
 		NewConfirmedTxTo        func(childComplexity int, address string) int
-		NewPendingTx            func(childComplexity int) int
-		NewPendingTxFrom        func(childComplexity int, address string) int
-		NewPendingTxTo          func(childComplexity int, address string) int
+		NewPendingTx            func(childComplexity int, snapshot *bool) int
+		NewPendingTxFrom        func(childComplexity int, address string, snapshot *bool) int
+		NewPendingTxTo          func(childComplexity int, address string, snapshot *bool) int
 		NewQueuedTx             func(childComplexity int) int
 		NewQueuedTxFrom         func(childComplexity int, address string) int
 		NewQueuedTxTo           func(childComplexity int, address string) int
@@ -114,6 +116,8 @@ type QueryResolver interface {
 	PendingForLessThan(ctx context.Context, x string) ([]*model.MemPoolTx, error)
 	QueuedForMoreThan(ctx context.Context, x string) ([]*model.MemPoolTx, error)
 	QueuedForLessThan(ctx context.Context, x string) ([]*model.MemPoolTx, error)
+	QueuedOlderThan(ctx context.Context, seconds int) ([]*model.MemPoolTx, error)
+	FreshThan(ctx context.Context, seconds int) ([]*model.MemPoolTx, error)
 	PendingFrom(ctx context.Context, addr string) ([]*model.MemPoolTx, error)
 	PendingTo(ctx context.Context, addr string) ([]*model.MemPoolTx, error)
 	QueuedFrom(ctx context.Context, addr string) ([]*model.MemPoolTx, error)
@@ -130,21 +134,21 @@ type QueryResolver interface {
 	QueuedWithLessThan(ctx context.Context, x float64) ([]*model.MemPoolTx, error)
 }
 type SubscriptionResolver interface {
-	NewPendingTx(ctx context.Context) (<-chan *model.MemPoolTx, error)
+	NewPendingTx(ctx context.Context, snapshot *bool) (<-chan *model.MemPoolTx, error)
 	NewQueuedTx(ctx context.Context) (<-chan *model.MemPoolTx, error)
 	NewConfirmedTx(ctx context.Context) (<-chan *model.MemPoolTx, error)
 	NewUnstuckTx(ctx context.Context) (<-chan *model.MemPoolTx, error)
 	PendingPool(ctx context.Context) (<-chan *model.MemPoolTx, error)
 	QueuedPool(ctx context.Context) (<-chan *model.MemPoolTx, error)
 	MemPool(ctx context.Context) (<-chan *model.MemPoolTx, error)
-	NewPendingTxFrom(ctx context.Context, address string) (<-chan *model.MemPoolTx, error)
+	NewPendingTxFrom(ctx context.Context, address string, snapshot *bool) (<-chan *model.MemPoolTx, error)
 	NewQueuedTxFrom(ctx context.Context, address string) (<-chan *model.MemPoolTx, error)
 	NewConfirmedTxFrom(ctx context.Context, address string) (<-chan *model.MemPoolTx, error)
 	NewUnstuckTxFrom(ctx context.Context, address string) (<-chan *model.MemPoolTx, error)
 	NewTxFromAInPendingPool(ctx context.Context, address string) (<-chan *model.MemPoolTx, error)
 	NewTxFromAInQueuedPool(ctx context.Context, address string) (<-chan *model.MemPoolTx, error)
 	NewTxFromAInMemPool(ctx context.Context, address string) (<-chan *model.MemPoolTx, error)
-	NewPendingTxTo(ctx context.Context, address string) (<-chan *model.MemPoolTx, error)
+	NewPendingTxTo(ctx context.Context, address string, snapshot *bool) (<-chan *model.MemPoolTx, error)
 	NewQueuedTxTo(ctx context.Context, address string) (<-chan *model.MemPoolTx, error)
 	NewConfirmedTxTo(ctx context.Context, address string) (<-chan *model.MemPoolTx, error)
 	NewUnstuckTxTo(ctx context.Context, address string) (<-chan *model.MemPoolTx, error)
@@ -274,6 +278,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.MemPoolTx.Value(childComplexity), true
 
+	case "Query.freshThan":
+		if e.complexity.Query.FreshThan == nil {
+			break
+		}
+
+		args, err := ec.field_Query_freshThan_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.FreshThan(childComplexity, args["seconds"].(int)), true
+
 	case "Query.pendingDuplicates":
 		if e.complexity.Query.PendingDuplicates == nil {
 			break
@@ -406,6 +422,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.QueuedFrom(childComplexity, args["addr"].(string)), true
 
+	case "Query.queuedOlderThan":
+		if e.complexity.Query.QueuedOlderThan == nil {
+			break
+		}
+
+		args, err := ec.field_Query_queuedOlderThan_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.QueuedOlderThan(childComplexity, args["seconds"].(int)), true
+
 	case "Query.queuedTo":
 		if e.complexity.Query.QueuedTo == nil {
 			break
@@ -533,7 +561,12 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			break
 		}
 
-		return e.complexity.Subscription.NewPendingTx(childComplexity), true
+		args, err := ec.field_Subscription_newPendingTx_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Subscription.NewPendingTx(childComplexity, args["snapshot"].(*bool)), true
 
 	case "Subscription.newPendingTxFrom":
 		if e.complexity.Subscription.NewPendingTxFrom == nil {
@@ -545,7 +578,7 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			return 0, false
 		}
 
-		return e.complexity.Subscription.NewPendingTxFrom(childComplexity, args["address"].(string)), true
+		return e.complexity.Subscription.NewPendingTxFrom(childComplexity, args["address"].(string), args["snapshot"].(*bool)), true
 
 	case "Subscription.newPendingTxTo":
 		if e.complexity.Subscription.NewPendingTxTo == nil {
@@ -557,7 +590,7 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			return 0, false
 		}
 
-		return e.complexity.Subscription.NewPendingTxTo(childComplexity, args["address"].(string)), true
+		return e.complexity.Subscription.NewPendingTxTo(childComplexity, args["address"].(string), args["snapshot"].(*bool)), true
 
 	case "Subscription.newQueuedTx":
 		if e.complexity.Subscription.NewQueuedTx == nil {
@@ -810,6 +843,8 @@ type Query {
 
   queuedForMoreThan(x: String!): [MemPoolTx!]!
   queuedForLessThan(x: String!): [MemPoolTx!]!
+  queuedOlderThan(seconds: Int!): [MemPoolTx!]!
+  freshThan(seconds: Int!): [MemPoolTx!]!
 
   pendingFrom(addr: String!): [MemPoolTx!]!
   pendingTo(addr: String!): [MemPoolTx!]!
@@ -834,7 +869,7 @@ type Query {
 }
 
 type Subscription {
-  newPendingTx: MemPoolTx!
+  newPendingTx(snapshot: Boolean = false): MemPoolTx!
   newQueuedTx: MemPoolTx!
 
   newConfirmedTx: MemPoolTx!
@@ -845,7 +880,7 @@ type Subscription {
 
   memPool: MemPoolTx!
 
-  newPendingTxFrom(address: String!): MemPoolTx!
+  newPendingTxFrom(address: String!, snapshot: Boolean = false): MemPoolTx!
   newQueuedTxFrom(address: String!): MemPoolTx!
 
   newConfirmedTxFrom(address: String!): MemPoolTx!
@@ -855,7 +890,7 @@ type Subscription {
   newTxFromAInQueuedPool(address: String!): MemPoolTx!
   newTxFromAInMemPool(address: String!): MemPoolTx!
 
-  newPendingTxTo(address: String!): MemPoolTx!
+  newPendingTxTo(address: String!, snapshot: Boolean = false): MemPoolTx!
   newQueuedTxTo(address: String!): MemPoolTx!
 
   newConfirmedTxTo(address: String!): MemPoolTx!
@@ -890,6 +925,21 @@ func (ec *executionContext) field_Query___type_args(ctx context.Context, rawArgs
 	return args, nil
 }
 
+func (ec *executionContext) field_Query_freshThan_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 int
+	if tmp, ok := rawArgs["seconds"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("seconds"))
+		arg0, err = ec.unmarshalNInt2int(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["seconds"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Query_pendingDuplicates_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -1055,6 +1105,21 @@ func (ec *executionContext) field_Query_queuedFrom_args(ctx context.Context, raw
 	return args, nil
 }
 
+func (ec *executionContext) field_Query_queuedOlderThan_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 int
+	if tmp, ok := rawArgs["seconds"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("seconds"))
+		arg0, err = ec.unmarshalNInt2int(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["seconds"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Query_queuedTo_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -1202,6 +1267,15 @@ func (ec *executionContext) field_Subscription_newPendingTxFrom_args(ctx context
 		}
 	}
 	args["address"] = arg0
+	var arg1 *bool
+	if tmp, ok := rawArgs["snapshot"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("snapshot"))
+		arg1, err = ec.unmarshalOBoolean2ᚖbool(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["snapshot"] = arg1
 	return args, nil
 }
 
@@ -1217,6 +1291,30 @@ func (ec *executionContext) field_Subscription_newPendingTxTo_args(ctx context.C
 		}
 	}
 	args["address"] = arg0
+	var arg1 *bool
+	if tmp, ok := rawArgs["snapshot"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("snapshot"))
+		arg1, err = ec.unmarshalOBoolean2ᚖbool(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["snapshot"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Subscription_newPendingTx_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *bool
+	if tmp, ok := rawArgs["snapshot"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("snapshot"))
+		arg0, err = ec.unmarshalOBoolean2ᚖbool(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["snapshot"] = arg0
 	return args, nil
 }
 
@@ -2116,6 +2214,90 @@ func (ec *executionContext) _Query_queuedForLessThan(ctx context.Context, field
 	return ec.marshalNMemPoolTx2ᚕᚖgithubᚗcomᚋitzmeanjanᚋharmonyᚋappᚋgraphᚋmodelᚐMemPoolTxᚄ(ctx, field.Selections, res)
 }
 
+func (ec *executionContext) _Query_queuedOlderThan(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_queuedOlderThan_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().QueuedOlderThan(rctx, args["seconds"].(int))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*model.MemPoolTx)
+	fc.Result = res
+	return ec.marshalNMemPoolTx2ᚕᚖgithubᚗcomᚋitzmeanjanᚋharmonyᚋappᚋgraphᚋmodelᚐMemPoolTxᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_freshThan(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_freshThan_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().FreshThan(rctx, args["seconds"].(int))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*model.MemPoolTx)
+	fc.Result = res
+	return ec.marshalNMemPoolTx2ᚕᚖgithubᚗcomᚋitzmeanjanᚋharmonyᚋappᚋgraphᚋmodelᚐMemPoolTxᚄ(ctx, field.Selections, res)
+}
+
 func (ec *executionContext) _Query_pendingFrom(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -2791,9 +2973,16 @@ func (ec *executionContext) _Subscription_newPendingTx(ctx context.Context, fiel
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Subscription_newPendingTx_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return nil
+	}
+	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Subscription().NewPendingTx(rctx)
+		return ec.resolvers.Subscription().NewPendingTx(rctx, args["snapshot"].(*bool))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3115,7 +3304,7 @@ func (ec *executionContext) _Subscription_newPendingTxFrom(ctx context.Context,
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Subscription().NewPendingTxFrom(rctx, args["address"].(string))
+		return ec.resolvers.Subscription().NewPendingTxFrom(rctx, args["address"].(string), args["snapshot"].(*bool))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3479,7 +3668,7 @@ func (ec *executionContext) _Subscription_newPendingTxTo(ctx context.Context, fi
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Subscription().NewPendingTxTo(rctx, args["address"].(string))
+		return ec.resolvers.Subscription().NewPendingTxTo(rctx, args["address"].(string), args["snapshot"].(*bool))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5133,6 +5322,34 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 				}
 				return res
 			})
+		case "queuedOlderThan":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_queuedOlderThan(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
+		case "freshThan":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_freshThan(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
 		case "pendingFrom":
 			field := field
 			out.Concurrently(i, func() (res graphql.Marshaler) {
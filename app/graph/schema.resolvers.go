@@ -6,6 +6,7 @@ package graph
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/itzmeanjan/harmony/app/graph/generated"
@@ -18,7 +19,7 @@ func (r *queryResolver) PendingForMoreThan(ctx context.Context, x string) ([]*mo
 		return nil, err
 	}
 
-	return toGraphQL(memPool.PendingForGTE(dur)), nil
+	return toGraphQL(memPool.PendingForGTE(ctx, dur)), nil
 }
 
 func (r *queryResolver) PendingForLessThan(ctx context.Context, x string) ([]*model.MemPoolTx, error) {
@@ -27,7 +28,7 @@ func (r *queryResolver) PendingForLessThan(ctx context.Context, x string) ([]*mo
 		return nil, err
 	}
 
-	return toGraphQL(memPool.PendingForLTE(dur)), nil
+	return toGraphQL(memPool.PendingForLTE(ctx, dur)), nil
 }
 
 func (r *queryResolver) QueuedForMoreThan(ctx context.Context, x string) ([]*model.MemPoolTx, error) {
@@ -36,7 +37,7 @@ func (r *queryResolver) QueuedForMoreThan(ctx context.Context, x string) ([]*mod
 		return nil, err
 	}
 
-	return toGraphQL(memPool.QueuedForGTE(dur)), nil
+	return toGraphQL(memPool.QueuedForGTE(ctx, dur)), nil
 }
 
 func (r *queryResolver) QueuedForLessThan(ctx context.Context, x string) ([]*model.MemPoolTx, error) {
@@ -45,7 +46,23 @@ func (r *queryResolver) QueuedForLessThan(ctx context.Context, x string) ([]*mod
 		return nil, err
 	}
 
-	return toGraphQL(memPool.QueuedForLTE(dur)), nil
+	return toGraphQL(memPool.QueuedForLTE(ctx, dur)), nil
+}
+
+func (r *queryResolver) QueuedOlderThan(ctx context.Context, seconds int) ([]*model.MemPoolTx, error) {
+	if seconds < 0 {
+		return nil, errors.New("bad argument")
+	}
+
+	return toGraphQL(memPool.QueuedForGTE(ctx, time.Duration(seconds)*time.Second)), nil
+}
+
+func (r *queryResolver) FreshThan(ctx context.Context, seconds int) ([]*model.MemPoolTx, error) {
+	if seconds < 0 {
+		return nil, errors.New("bad argument")
+	}
+
+	return toGraphQL(memPool.QueuedForLTE(ctx, time.Duration(seconds)*time.Second)), nil
 }
 
 func (r *queryResolver) PendingFrom(ctx context.Context, addr string) ([]*model.MemPoolTx, error) {
@@ -53,7 +70,7 @@ func (r *queryResolver) PendingFrom(ctx context.Context, addr string) ([]*model.
 		return nil, errors.New("invalid address")
 	}
 
-	return toGraphQL(memPool.PendingFrom(common.HexToAddress(addr))), nil
+	return toGraphQL(memPool.PendingFrom(ctx, common.HexToAddress(addr))), nil
 }
 
 func (r *queryResolver) PendingTo(ctx context.Context, addr string) ([]*model.MemPoolTx, error) {
@@ -61,7 +78,7 @@ func (r *queryResolver) PendingTo(ctx context.Context, addr string) ([]*model.Me
 		return nil, errors.New("invalid address")
 	}
 
-	return toGraphQL(memPool.PendingTo(common.HexToAddress(addr))), nil
+	return toGraphQL(memPool.PendingTo(ctx, common.HexToAddress(addr))), nil
 }
 
 func (r *queryResolver) QueuedFrom(ctx context.Context, addr string) ([]*model.MemPoolTx, error) {
@@ -69,7 +86,7 @@ func (r *queryResolver) QueuedFrom(ctx context.Context, addr string) ([]*model.M
 		return nil, errors.New("invalid address")
 	}
 
-	return toGraphQL(memPool.QueuedFrom(common.HexToAddress(addr))), nil
+	return toGraphQL(memPool.QueuedFrom(ctx, common.HexToAddress(addr))), nil
 }
 
 func (r *queryResolver) QueuedTo(ctx context.Context, addr string) ([]*model.MemPoolTx, error) {
@@ -77,7 +94,7 @@ func (r *queryResolver) QueuedTo(ctx context.Context, addr string) ([]*model.Mem
 		return nil, errors.New("invalid address")
 	}
 
-	return toGraphQL(memPool.QueuedTo(common.HexToAddress(addr))), nil
+	return toGraphQL(memPool.QueuedTo(ctx, common.HexToAddress(addr))), nil
 }
 
 func (r *queryResolver) TopXPendingWithHighGasPrice(ctx context.Context, x int) ([]*model.MemPoolTx, error) {
@@ -85,7 +102,7 @@ func (r *queryResolver) TopXPendingWithHighGasPrice(ctx context.Context, x int)
 		return nil, errors.New("bad argument")
 	}
 
-	return toGraphQL(memPool.TopXPendingWithHighGasPrice(uint64(x))), nil
+	return toGraphQL(memPool.TopXPendingWithHighGasPrice(ctx, uint64(x))), nil
 }
 
 func (r *queryResolver) TopXQueuedWithHighGasPrice(ctx context.Context, x int) ([]*model.MemPoolTx, error) {
@@ -93,7 +110,7 @@ func (r *queryResolver) TopXQueuedWithHighGasPrice(ctx context.Context, x int) (
 		return nil, errors.New("bad argument")
 	}
 
-	return toGraphQL(memPool.TopXQueuedWithHighGasPrice(uint64(x))), nil
+	return toGraphQL(memPool.TopXQueuedWithHighGasPrice(ctx, uint64(x))), nil
 }
 
 func (r *queryResolver) TopXPendingWithLowGasPrice(ctx context.Context, x int) ([]*model.MemPoolTx, error) {
@@ -101,7 +118,7 @@ func (r *queryResolver) TopXPendingWithLowGasPrice(ctx context.Context, x int) (
 		return nil, errors.New("bad argument")
 	}
 
-	return toGraphQL(memPool.TopXPendingWithLowGasPrice(uint64(x))), nil
+	return toGraphQL(memPool.TopXPendingWithLowGasPrice(ctx, uint64(x))), nil
 }
 
 func (r *queryResolver) TopXQueuedWithLowGasPrice(ctx context.Context, x int) ([]*model.MemPoolTx, error) {
@@ -109,7 +126,7 @@ func (r *queryResolver) TopXQueuedWithLowGasPrice(ctx context.Context, x int) ([
 		return nil, errors.New("bad argument")
 	}
 
-	return toGraphQL(memPool.TopXQueuedWithLowGasPrice(uint64(x))), nil
+	return toGraphQL(memPool.TopXQueuedWithLowGasPrice(ctx, uint64(x))), nil
 }
 
 func (r *queryResolver) PendingDuplicates(ctx context.Context, hash string) ([]*model.MemPoolTx, error) {
@@ -117,7 +134,7 @@ func (r *queryResolver) PendingDuplicates(ctx context.Context, hash string) ([]*
 		return nil, errors.New("invalid txHash")
 	}
 
-	return toGraphQL(memPool.PendingDuplicates(common.HexToHash(hash))), nil
+	return toGraphQL(memPool.PendingDuplicates(ctx, common.HexToHash(hash))), nil
 }
 
 func (r *queryResolver) QueuedDuplicates(ctx context.Context, hash string) ([]*model.MemPoolTx, error) {
@@ -125,7 +142,7 @@ func (r *queryResolver) QueuedDuplicates(ctx context.Context, hash string) ([]*m
 		return nil, errors.New("invalid txHash")
 	}
 
-	return toGraphQL(memPool.QueuedDuplicates(common.HexToHash(hash))), nil
+	return toGraphQL(memPool.QueuedDuplicates(ctx, common.HexToHash(hash))), nil
 }
 
 func (r *queryResolver) PendingWithMoreThan(ctx context.Context, x float64) ([]*model.MemPoolTx, error) {
@@ -133,7 +150,7 @@ func (r *queryResolver) PendingWithMoreThan(ctx context.Context, x float64) ([]*
 		return nil, errors.New("bad gas price ( in Gwei )")
 	}
 
-	return toGraphQL(memPool.PendingWithGTE(x)), nil
+	return toGraphQL(memPool.PendingWithGTE(ctx, x)), nil
 }
 
 func (r *queryResolver) PendingWithLessThan(ctx context.Context, x float64) ([]*model.MemPoolTx, error) {
@@ -141,7 +158,7 @@ func (r *queryResolver) PendingWithLessThan(ctx context.Context, x float64) ([]*
 		return nil, errors.New("bad gas price ( in Gwei )")
 	}
 
-	return toGraphQL(memPool.PendingWithLTE(x)), nil
+	return toGraphQL(memPool.PendingWithLTE(ctx, x)), nil
 }
 
 func (r *queryResolver) QueuedWithMoreThan(ctx context.Context, x float64) ([]*model.MemPoolTx, error) {
@@ -149,7 +166,7 @@ func (r *queryResolver) QueuedWithMoreThan(ctx context.Context, x float64) ([]*m
 		return nil, errors.New("bad gas price ( in Gwei )")
 	}
 
-	return toGraphQL(memPool.QueuedWithGTE(x)), nil
+	return toGraphQL(memPool.QueuedWithGTE(ctx, x)), nil
 }
 
 func (r *queryResolver) QueuedWithLessThan(ctx context.Context, x float64) ([]*model.MemPoolTx, error) {
@@ -157,17 +174,23 @@ func (r *queryResolver) QueuedWithLessThan(ctx context.Context, x float64) ([]*m
 		return nil, errors.New("bad gas price ( in Gwei )")
 	}
 
-	return toGraphQL(memPool.QueuedWithLTE(x)), nil
+	return toGraphQL(memPool.QueuedWithLTE(ctx, x)), nil
 }
 
-func (r *subscriptionResolver) NewPendingTx(ctx context.Context) (<-chan *model.MemPoolTx, error) {
+func (r *subscriptionResolver) NewPendingTx(ctx context.Context, snapshot *bool) (<-chan *model.MemPoolTx, error) {
 	_pubsub, err := SubscribeToPendingTxEntry(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	comm := make(chan *model.MemPoolTx, 1)
-	go ListenToMessages(ctx, _pubsub, comm, NoCriteria)
+
+	listen := ListenToMessages
+	if snapshot != nil && *snapshot {
+		listen = ListenToMessagesAfterSnapshot
+	}
+
+	go listen(ctx, _pubsub, comm, NoCriteria)
 
 	return comm, nil
 }
@@ -244,7 +267,7 @@ func (r *subscriptionResolver) MemPool(ctx context.Context) (<-chan *model.MemPo
 	return comm, nil
 }
 
-func (r *subscriptionResolver) NewPendingTxFrom(ctx context.Context, address string) (<-chan *model.MemPoolTx, error) {
+func (r *subscriptionResolver) NewPendingTxFrom(ctx context.Context, address string, snapshot *bool) (<-chan *model.MemPoolTx, error) {
 	if !checkAddress(address) {
 		return nil, errors.New("invalid address")
 	}
@@ -255,7 +278,13 @@ func (r *subscriptionResolver) NewPendingTxFrom(ctx context.Context, address str
 	}
 
 	comm := make(chan *model.MemPoolTx, 1)
-	go ListenToMessages(ctx, _pubsub, comm, CheckFromAddress, common.HexToAddress(address))
+
+	listen := ListenToMessages
+	if snapshot != nil && *snapshot {
+		listen = ListenToMessagesAfterSnapshot
+	}
+
+	go listen(ctx, _pubsub, comm, CheckFromAddress, common.HexToAddress(address))
 
 	return comm, nil
 }
@@ -360,7 +389,7 @@ func (r *subscriptionResolver) NewTxFromAInMemPool(ctx context.Context, address
 	return comm, nil
 }
 
-func (r *subscriptionResolver) NewPendingTxTo(ctx context.Context, address string) (<-chan *model.MemPoolTx, error) {
+func (r *subscriptionResolver) NewPendingTxTo(ctx context.Context, address string, snapshot *bool) (<-chan *model.MemPoolTx, error) {
 	if !checkAddress(address) {
 		return nil, errors.New("invalid address")
 	}
@@ -371,7 +400,13 @@ func (r *subscriptionResolver) NewPendingTxTo(ctx context.Context, address strin
 	}
 
 	comm := make(chan *model.MemPoolTx, 1)
-	go ListenToMessages(ctx, _pubsub, comm, CheckToAddress, common.HexToAddress(address))
+
+	listen := ListenToMessages
+	if snapshot != nil && *snapshot {
+		listen = ListenToMessagesAfterSnapshot
+	}
+
+	go listen(ctx, _pubsub, comm, CheckToAddress, common.HexToAddress(address))
 
 	return comm, nil
 }
@@ -477,7 +512,7 @@ func (r *subscriptionResolver) WatchTx(ctx context.Context, hash string) (<-chan
 		return nil, errors.New("invalid txHash")
 	}
 
-	tx := memPool.Get(common.HexToHash(hash))
+	tx := memPool.Get(ctx, common.HexToHash(hash))
 	if tx == nil {
 		return nil, errors.New("tx not in mempool")
 	}
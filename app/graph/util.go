@@ -103,12 +103,36 @@ func SubscribeToTopic(ctx context.Context, topic ...string) (*subscriber.Subscri
 	return subscriber, nil
 }
 
+// pendingExitTopic - Topic where full tx leaving pending pool is published,
+// which is opt-in one, when compact removal events are enabled
+func pendingExitTopic() string {
+
+	if config.GetCompactRemovalChoice() {
+		return config.GetPendingTxExitFullPublishTopic()
+	}
+
+	return config.GetPendingTxExitPublishTopic()
+
+}
+
+// queuedExitTopic - Topic where full tx leaving queued pool is published,
+// which is opt-in one, when compact removal events are enabled
+func queuedExitTopic() string {
+
+	if config.GetCompactRemovalChoice() {
+		return config.GetQueuedTxExitFullPublishTopic()
+	}
+
+	return config.GetQueuedTxExitPublishTopic()
+
+}
+
 // SubscribeToPendingPool - Subscribes to both topics, associated with changes
 // happening in pending tx pool
 //
 // When tx joins/ leaves pending pool, subscribers will receive notification
 func SubscribeToPendingPool(ctx context.Context) (*subscriber.Subscriber, error) {
-	return SubscribeToTopic(ctx, config.GetPendingTxEntryPublishTopic(), config.GetPendingTxExitPublishTopic())
+	return SubscribeToTopic(ctx, config.GetPendingTxEntryPublishTopic(), pendingExitTopic())
 }
 
 // SubscribeToQueuedPool - Subscribes to both topics, associated with changes
@@ -119,7 +143,7 @@ func SubscribeToPendingPool(ctx context.Context) (*subscriber.Subscriber, error)
 // @note Tx(s) generally join queued pool, when there's nonce gap & this tx can't be
 // processed until some lower nonce tx(s) get(s) processed
 func SubscribeToQueuedPool(ctx context.Context) (*subscriber.Subscriber, error) {
-	return SubscribeToTopic(ctx, config.GetQueuedTxEntryPublishTopic(), config.GetQueuedTxExitPublishTopic())
+	return SubscribeToTopic(ctx, config.GetQueuedTxEntryPublishTopic(), queuedExitTopic())
 }
 
 // SubscribeToMemPool - Subscribes to any changes happening in mempool
@@ -133,9 +157,9 @@ func SubscribeToQueuedPool(ctx context.Context) (*subscriber.Subscriber, error)
 func SubscribeToMemPool(ctx context.Context) (*subscriber.Subscriber, error) {
 	return SubscribeToTopic(ctx,
 		config.GetQueuedTxEntryPublishTopic(),
-		config.GetQueuedTxExitPublishTopic(),
+		queuedExitTopic(),
 		config.GetPendingTxEntryPublishTopic(),
-		config.GetPendingTxExitPublishTopic())
+		pendingExitTopic())
 }
 
 // SubscribeToPendingTxEntry - Subscribe to topic where new pending tx(s)
@@ -153,13 +177,13 @@ func SubscribeToQueuedTxEntry(ctx context.Context) (*subscriber.Subscriber, erro
 // SubscribeToPendingTxExit - Subscribe to topic where pending tx(s), getting
 // confirmed are published
 func SubscribeToPendingTxExit(ctx context.Context) (*subscriber.Subscriber, error) {
-	return SubscribeToTopic(ctx, config.GetPendingTxExitPublishTopic())
+	return SubscribeToTopic(ctx, pendingExitTopic())
 }
 
 // SubscribeToQueuedTxExit - Subscribe to topic where queued tx(s), getting
 // unstuck are published
 func SubscribeToQueuedTxExit(ctx context.Context) (*subscriber.Subscriber, error) {
-	return SubscribeToTopic(ctx, config.GetQueuedTxExitPublishTopic())
+	return SubscribeToTopic(ctx, queuedExitTopic())
 }
 
 // ListenToMessages - Attempts to listen to messages being published
@@ -253,6 +277,50 @@ func ListenToMessages(ctx context.Context, subscriber *subscriber.Subscriber, co
 
 }
 
+// CriteriaFilter - Publishing criteria, streamed tx(s) are checked against, as
+// filter to be applied on pool snapshot, so that both agree on what's of
+// interest to client
+func CriteriaFilter(pubCriteria PublishingCriteria, params ...interface{}) data.Filter {
+
+	return func(tx *data.MemPoolTx) bool {
+		return pubCriteria(tx, params...)
+	}
+
+}
+
+// ListenToMessagesAfterSnapshot - Sends pending tx(s) satisfying publishing
+// criteria, as of now, to client first, in descending order of gas price, then
+// keeps listening to messages, same as `ListenToMessages`
+//
+// Subscription is already set up before snapshot is taken, so tx joining pool
+// in between isn't missed, though it may be delivered twice.
+//
+// @note Unlike streamed messages, snapshot isn't dropped when client is slow
+func ListenToMessagesAfterSnapshot(ctx context.Context, subscriber *subscriber.Subscriber, comm chan<- *model.MemPoolTx, pubCriteria PublishingCriteria, params ...interface{}) {
+
+	txs := memPool.PendingMatchingSnapshot(ctx, CriteriaFilter(pubCriteria, params...))
+
+SNAPSHOT:
+	for _, tx := range txs {
+
+		sendable := tx.ToGraphQL()
+		if sendable == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			break SNAPSHOT
+		case comm <- sendable:
+		}
+
+	}
+
+	// Cleans up, even if client has already left
+	ListenToMessages(ctx, subscriber, comm, pubCriteria, params...)
+
+}
+
 // UnmarshalPubSubMessage - Attempts to unmarshal message pack serialized
 // pubsub message as structured tx data, which is to be sent to subscriber
 func UnmarshalPubSubMessage(message []byte) *data.MemPoolTx {
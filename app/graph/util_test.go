@@ -0,0 +1,45 @@
+package graph
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/itzmeanjan/harmony/app/data"
+)
+
+func TestCriteriaFilter(t *testing.T) {
+
+	from := common.BigToAddress(big.NewInt(1))
+	to := common.BigToAddress(big.NewInt(2))
+
+	sent := &data.MemPoolTx{From: from, To: &to}
+	deploy := &data.MemPoolTx{From: to}
+
+	for name, c := range map[string]struct {
+		criteria PublishingCriteria
+		params   []interface{}
+	}{
+		"none": {NoCriteria, nil},
+		"from": {CheckFromAddress, []interface{}{from}},
+		"to":   {CheckToAddress, []interface{}{to}},
+	} {
+
+		filter := CriteriaFilter(c.criteria, c.params...)
+
+		// Snapshot must agree with what's streamed
+		for _, tx := range []*data.MemPoolTx{sent, deploy} {
+
+			if filter(tx) != c.criteria(tx, c.params...) {
+				t.Fatalf("%s : filter disagrees with publishing criteria", name)
+			}
+
+		}
+
+	}
+
+	if !CriteriaFilter(CheckFromAddress, from)(sent) || CriteriaFilter(CheckFromAddress, from)(deploy) {
+		t.Fatal("filter doesn't match by sender")
+	}
+
+}
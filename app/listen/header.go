@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 // CaughtTx - Tx caught by block head subscriber, passed to
@@ -22,10 +23,18 @@ type CaughtTx struct {
 // mined block
 type CaughtTxs []*CaughtTx
 
+// Upstream - RPC endpoint(s), mined blocks are fetched from currently
+// active one, so that they're fetched from healthy one after failover
+type Upstream interface {
+	Active() (*rpc.Client, int)
+}
+
 // SubscribeHead - Subscribe to block headers & as soon as new block gets mined
 // its txs are picked up & published on a go channel, which will be listened
 // to by pending pool watcher, so that it can prune its state
-func SubscribeHead(ctx context.Context, client *ethclient.Client, lastSeenBlock uint64, commChan chan<- CaughtTxs, lastSeenBlockChan chan<- uint64, healthChan chan struct{}) {
+//
+// Headers are subscribed to using `client`, blocks are fetched from `upstream`
+func SubscribeHead(ctx context.Context, client *ethclient.Client, upstream Upstream, lastSeenBlock uint64, commChan chan<- CaughtTxs, lastSeenBlockChan chan<- uint64, healthChan chan struct{}) {
 
 	retryTable := make(map[*big.Int]struct{})
 	lastRetried := time.Now()
@@ -67,7 +76,7 @@ func SubscribeHead(ctx context.Context, client *ethclient.Client, lastSeenBlock
 
 			}
 
-			if !ProcessBlock(ctx, client, header.Number, commChan, lastSeenBlockChan) {
+			if !ProcessBlock(ctx, upstream, header.Number, commChan, lastSeenBlockChan) {
 				// Put entry in table that we failed to fetch this block, to be
 				// attempted in some time future
 				retryTable[header.Number] = struct{}{}
@@ -91,7 +100,7 @@ func SubscribeHead(ctx context.Context, client *ethclient.Client, lastSeenBlock
 
 			successC := 0
 			for num := range retryTable {
-				if ProcessBlock(ctx, client, num, commChan, lastSeenBlockChan) {
+				if ProcessBlock(ctx, upstream, num, commChan, lastSeenBlockChan) {
 					delete(retryTable, num)
 					successC++
 				}
@@ -105,9 +114,11 @@ func SubscribeHead(ctx context.Context, client *ethclient.Client, lastSeenBlock
 }
 
 // ProcessBlock - Fetches all txs present in mined block & passes those to pending pool pruning worker
-func ProcessBlock(ctx context.Context, client *ethclient.Client, number *big.Int, commChan chan<- CaughtTxs, lastSeenBlockChan chan<- uint64) bool {
+func ProcessBlock(ctx context.Context, upstream Upstream, number *big.Int, commChan chan<- CaughtTxs, lastSeenBlockChan chan<- uint64) bool {
+
+	client, _ := upstream.Active()
 
-	block, err := client.BlockByNumber(ctx, number)
+	block, err := ethclient.NewClient(client).BlockByNumber(ctx, number)
 	if err != nil {
 
 		log.Printf("❗️ Failed to fetch block : %d\n", number)
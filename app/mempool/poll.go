@@ -2,10 +2,12 @@ package mempool
 
 import (
 	"context"
+	"errors"
 	"log"
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/itzmeanjan/harmony/app/config"
 	"github.com/itzmeanjan/harmony/app/data"
 )
@@ -14,19 +16,34 @@ import (
 // processing with data received back i.e. attempt to keep most fresh view of
 // mempool in `harmony`
 //
-// Emit events on PubSub topics for listening to state changes
-func PollTxPoolContent(ctx context.Context, res *data.Resource, comm chan struct{}) {
+// Emit events on PubSub topics for listening to state changes.
+//
+// If none of healthy nodes support `txpool_content`, as learnt during first poll,
+// it falls back to `newPendingTransactions` subscription, if that can't be set up
+// either, it's reported that no ingestion method is available & supervisor is
+// not asked to restart, `/health` reports unhealthy instead
+//
+// Content is fetched from `source`, which is live RPC node, unless recorded
+// responses are being replayed, in that case pools are left as they are, once
+// replay is done
+func PollTxPoolContent(ctx context.Context, res *data.Resource, source ContentSource, comm chan struct{}) {
 
-	for {
+	for polled := false; ; polled = true {
 
 		// Starting to fetch latest state of mempool
 		start := time.Now().UTC()
 
-		var result map[string]map[string]map[string]*data.MemPoolTx
+		result, err := source.Fetch(ctx)
+		if err != nil {
 
-		if err := res.RPCClient.CallContext(ctx, &result, "txpool_content"); err != nil {
+			if errors.Is(err, ErrReplayDone) {
+
+				log.Printf("[✅] Replayed all recorded mempool content\n")
+
+				<-ctx.Done()
+				break
 
-			log.Printf("[❗️] Failed to fetch mempool content : %s\n", err.Error())
+			}
 
 			// If supervisor is asking to stop operation, just get out
 			// of this infinite loop
@@ -34,6 +51,25 @@ func PollTxPoolContent(ctx context.Context, res *data.Resource, comm chan struct
 				break
 			}
 
+			if !polled && isMethodNotFound(err) {
+
+				log.Printf("[❗️] No RPC node supports `txpool_content`, falling back to `newPendingTransactions` subscription\n")
+
+				if err := SubscribePendingTxs(ctx, res); err != nil && ctx.Err() == nil {
+
+					// Restarting won't make node support any of them, so
+					// process stays up, serving what it has, while `/health`
+					// keeps reporting it's unhealthy, because nothing is
+					// being ingested
+					log.Printf("[❌] No mempool ingestion method available, subscription failed : %s\n", err.Error())
+
+				}
+
+				<-ctx.Done()
+				break
+
+			}
+
 			// Letting supervisor know, pool polling go routine is dying
 			// it must take care of spawning another one to continue functioning
 			close(comm)
@@ -41,9 +77,16 @@ func PollTxPoolContent(ctx context.Context, res *data.Resource, comm chan struct
 
 		}
 
-		// Process current tx pool content
+		// Process current tx pool content i.e. tx(s) found in `pending` &
+		// `queued` section are added into respective pools
+		//
+		// @note Departures aren't reconciled here, pending pool pruner does so
+		// as blocks get mined & queued pool pruner moves unstuck tx(s)
+		// into pending pool
+		res.Pool.MarkPolled()
 		res.Pool.Process(ctx, result["pending"], result["queued"])
-		res.Pool.Stat(start)
+		res.Pool.Stat(ctx, start)
+		res.Pool.ObserveSenderDominance(ctx)
 
 		// Sleep for desired amount of time & get to work again
 		<-time.After(time.Duration(config.GetMemPoolPollingPeriod()) * time.Millisecond)
@@ -51,3 +94,75 @@ func PollTxPoolContent(ctx context.Context, res *data.Resource, comm chan struct
 	}
 
 }
+
+// fetchTxPoolContent - Fetches current content of Ethereum Mempool, retrying
+// with exponential backoff on failure, so that flaky RPC endpoint doesn't
+// kill poller right away
+//
+// Gives up after configured #-of retries, returning last error seen. When
+// node doesn't support `txpool_content`, rest of healthy endpoints are asked
+// once each, without counting as retry, before giving up.
+func fetchTxPoolContent(ctx context.Context, res *data.Resource) (map[string]map[string]map[string]*data.MemPoolTx, error) {
+
+	maxRetries := config.GetPollMaxRetries()
+	delay := time.Duration(config.GetPollRetryBaseDelay()) * time.Millisecond
+
+	unsupported := make(map[*rpc.Client]bool)
+
+	for attempt := uint64(1); ; attempt++ {
+
+		var result map[string]map[string]map[string]*data.MemPoolTx
+
+		client, endpoint := res.Upstreams.Active()
+
+		err := client.CallContext(ctx, &result, "txpool_content")
+		if err == nil {
+			return result, nil
+		}
+
+		// Retrying same node won't help, when it doesn't support it, but
+		// some other healthy one may
+		if isMethodNotFound(err) {
+
+			unsupported[client] = true
+
+			if !res.Upstreams.Failover(client) {
+				return nil, err
+			}
+
+			next, nextEndpoint := res.Upstreams.Active()
+			if unsupported[next] {
+				return nil, err
+			}
+
+			log.Printf("[❃] RPC endpoint #%d doesn't support `txpool_content`, failing over to #%d\n", endpoint, nextEndpoint)
+
+			attempt--
+			continue
+
+		}
+
+		log.Printf("[❗️] Failed to fetch mempool content from endpoint #%d, attempt %d : %s\n", endpoint, attempt, err.Error())
+
+		if attempt > maxRetries || ctx.Err() != nil {
+			return nil, err
+		}
+
+		// Next attempt to be made against next healthy endpoint,
+		// failed one to be health checked periodically
+		if res.Upstreams.Failover(client) {
+			_, endpoint = res.Upstreams.Active()
+			log.Printf("[❃] Failing over to RPC endpoint #%d\n", endpoint)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+
+	}
+
+}
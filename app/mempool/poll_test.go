@@ -0,0 +1,159 @@
+package mempool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/itzmeanjan/harmony/app/data"
+	"github.com/spf13/viper"
+)
+
+// rpcError - JSON-RPC error, as returned by node
+type rpcError struct {
+	code int
+}
+
+func (e *rpcError) Error() string  { return "rpc error" }
+func (e *rpcError) ErrorCode() int { return e.code }
+
+// failingSource - Fails every fetch with given error
+type failingSource struct {
+	err error
+}
+
+func (f *failingSource) Fetch(ctx context.Context) (map[string]map[string]map[string]*data.MemPoolTx, error) {
+	return nil, f.err
+}
+
+func TestIsMethodNotFound(t *testing.T) {
+
+	if !isMethodNotFound(&rpcError{code: methodNotFound}) {
+		t.Fatal("method not found error not recognised")
+	}
+
+	if isMethodNotFound(&rpcError{code: -32000}) {
+		t.Fatal("other rpc error recognised as method not found")
+	}
+
+	if isMethodNotFound(errors.New("connection refused")) {
+		t.Fatal("non rpc error recognised as method not found")
+	}
+
+}
+
+func TestPollWithoutIngestionMethod(t *testing.T) {
+
+	// Subscription can't be set up either
+	prev := viper.Get("WSUrl")
+	viper.Set("WSUrl", "")
+	t.Cleanup(func() { viper.Set("WSUrl", prev) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	comm := make(chan struct{}, 1)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		PollTxPoolContent(ctx, &data.Resource{}, &failingSource{err: &rpcError{code: methodNotFound}}, comm)
+	}()
+
+	// Supervisor must not be asked to restart, it'd end up
+	// in restart loop
+	select {
+	case <-comm:
+		t.Fatal("supervisor asked to restart poller")
+	case <-done:
+		t.Fatal("poller returned without being asked to")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("poller didn't stop once asked to")
+	}
+
+}
+
+func TestPollOtherFailure(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	comm := make(chan struct{}, 1)
+
+	PollTxPoolContent(ctx, &data.Resource{}, &failingSource{err: errors.New("connection refused")}, comm)
+
+	select {
+	case <-comm:
+	default:
+		t.Fatal("supervisor not let known about poller dying")
+	}
+
+}
+
+// rpcEndpoint - JSON-RPC endpoint answering every call with given
+// JSON encoded `result`/ `error` member
+func rpcEndpoint(t *testing.T, member string) string {
+
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+
+		rw.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(rw, `{"jsonrpc":"2.0","id":1,%s}`, member)
+
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv.URL
+
+}
+
+func TestFetchFailsOverOnMethodNotFound(t *testing.T) {
+
+	ctx := context.Background()
+
+	unsupported := fmt.Sprintf(`"error":{"code":%d,"message":"method not found"}`, methodNotFound)
+	supported := `"result":{"pending":{},"queued":{}}`
+
+	upstreams, err := data.NewUpstreams(ctx, []string{rpcEndpoint(t, unsupported), rpcEndpoint(t, supported)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(upstreams.Close)
+
+	result, err := fetchTxPoolContent(ctx, &data.Resource{Upstreams: upstreams})
+	if err != nil {
+		t.Fatalf("expected next endpoint to be asked, got %s", err.Error())
+	}
+
+	if _, ok := result["pending"]; !ok {
+		t.Fatalf("expected content of next endpoint, got %v", result)
+	}
+
+	if _, endpoint := upstreams.Active(); endpoint != 1 {
+		t.Fatalf("expected endpoint #1 to be active, got #%d", endpoint)
+	}
+
+	// None of them supports it, each one is asked only once
+	upstreams, err = data.NewUpstreams(ctx, []string{rpcEndpoint(t, unsupported), rpcEndpoint(t, unsupported)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(upstreams.Close)
+
+	if _, err := fetchTxPoolContent(ctx, &data.Resource{Upstreams: upstreams}); !isMethodNotFound(err) {
+		t.Fatalf("expected method not found, got %v", err)
+	}
+
+}
@@ -0,0 +1,83 @@
+package mempool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/itzmeanjan/harmony/app/data"
+)
+
+// ErrReplayDone - All recorded responses have been replayed
+var ErrReplayDone = errors.New("replay done")
+
+// ReplaySource - Feeds recorded `txpool_content` responses into pools, one
+// on every poll, as if they came from live node, so that past mempool
+// scenario can be reproduced deterministically
+//
+// @note Each JSON file in directory is expected to hold one response, named
+// such that lexical order is timestamp order i.e. unix timestamp/ RFC3339
+type ReplaySource struct {
+	files []string
+	next  int
+}
+
+// NewReplaySource - Lists recorded responses in given directory, in
+// order they're to be replayed
+func NewReplaySource(dir string) (*ReplaySource, error) {
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return nil, errors.New("no recorded response found")
+	}
+
+	sort.Strings(files)
+
+	return &ReplaySource{files: files}, nil
+
+}
+
+// Fetch - Reads next recorded response, returning `ErrReplayDone`
+// once all of them are replayed
+//
+// Unreadable/ corrupt files are skipped with a log line, so that one bad
+// recording doesn't stop rest of them from being replayed
+func (r *ReplaySource) Fetch(ctx context.Context) (map[string]map[string]map[string]*data.MemPoolTx, error) {
+
+	for ; r.next < len(r.files); r.next++ {
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		file := r.files[r.next]
+
+		content, err := os.ReadFile(file)
+		if err != nil {
+			log.Printf("[❗️] Skipping recorded mempool content %s : %s\n", file, err.Error())
+			continue
+		}
+
+		var result map[string]map[string]map[string]*data.MemPoolTx
+
+		if err := json.Unmarshal(content, &result); err != nil {
+			log.Printf("[❗️] Skipping recorded mempool content %s : %s\n", file, err.Error())
+			continue
+		}
+
+		r.next++
+		return result, nil
+
+	}
+
+	return nil, ErrReplayDone
+
+}
@@ -0,0 +1,52 @@
+package mempool
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReplaySkipsCorruptFile(t *testing.T) {
+
+	dir := t.TempDir()
+
+	for name, content := range map[string]string{
+		"1.json": `{"pending":{},"queued":{}}`,
+		"2.json": `{"pending":`,
+		"3.json": `{"pending":{"0x0000000000000000000000000000000000000001":{}},"queued":{}}`,
+	} {
+
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+	}
+
+	source, err := NewReplaySource(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	if _, err := source.Fetch(ctx); err != nil {
+		t.Fatalf("failed to replay first recording : %s", err.Error())
+	}
+
+	// Corrupt one is skipped, third one is replayed
+	result, err := source.Fetch(ctx)
+	if err != nil {
+		t.Fatalf("failed to replay after corrupt recording : %s", err.Error())
+	}
+
+	if len(result["pending"]) != 1 {
+		t.Fatalf("expected third recording to be replayed, got %v", result)
+	}
+
+	if _, err := source.Fetch(ctx); !errors.Is(err, ErrReplayDone) {
+		t.Fatalf("expected replay to be done, got %v", err)
+	}
+
+}
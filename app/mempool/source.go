@@ -0,0 +1,26 @@
+package mempool
+
+import (
+	"context"
+
+	"github.com/itzmeanjan/harmony/app/data"
+)
+
+// ContentSource - Where mempool content i.e. `txpool_content` response is
+// fetched from, on every poll
+type ContentSource interface {
+	Fetch(ctx context.Context) (map[string]map[string]map[string]*data.MemPoolTx, error)
+}
+
+// RPCSource - Fetches mempool content from active RPC endpoint, failing
+// over to next healthy one, when it fails
+type RPCSource struct {
+	Res *data.Resource
+}
+
+// Fetch - Fetches current content of Ethereum Mempool
+func (r *RPCSource) Fetch(ctx context.Context) (map[string]map[string]map[string]*data.MemPoolTx, error) {
+
+	return fetchTxPoolContent(ctx, r.Res)
+
+}
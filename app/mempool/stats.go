@@ -0,0 +1,44 @@
+package mempool
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/itzmeanjan/harmony/app/data"
+	"github.com/itzmeanjan/pub0sub/ops"
+)
+
+// PublishPoolStats - Periodically computes aggregate mempool stats & publishes
+// those ( in messagepack serialized format ) to pubsub topic, so that subscribers
+// can keep track of mempool health, without reading every tx
+func PublishPoolStats(ctx context.Context, res *data.Resource) {
+
+	for {
+
+		select {
+
+		case <-ctx.Done():
+			return
+
+		case <-time.After(time.Duration(config.GetStatsPublishPeriod()) * time.Millisecond):
+
+			_msg, err := res.Pool.Stats(ctx).ToMessagePack()
+			if err != nil {
+				log.Printf("[❗️] Failed to serialize into messagepack : %s\n", err.Error())
+				break
+			}
+
+			if _, err := res.Pool.Pending.PubSub.Publish(&ops.Msg{
+				Topics: []string{config.GetStatsPublishTopic()},
+				Data:   _msg,
+			}); err != nil {
+				log.Printf("[❗️] Failed to publish mempool stats : %s\n", err.Error())
+			}
+
+		}
+
+	}
+
+}
@@ -0,0 +1,149 @@
+package mempool
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/itzmeanjan/harmony/app/data"
+)
+
+// methodNotFound - JSON-RPC error code, returned when node/ provider doesn't
+// support ( or has disabled ) requested method
+const methodNotFound = -32601
+
+// isMethodNotFound - Checks whether RPC call failed because method
+// isn't supported by node
+func isMethodNotFound(err error) bool {
+
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return rpcErr.ErrorCode() == methodNotFound
+	}
+
+	return false
+
+}
+
+// SubscribePendingTxs - Fallback ingestion method, for nodes not supporting
+// `txpool_content`. Hashes of new pending tx(s) are received over websocket
+// subscription & every polling period, those tx(s) are fetched in batch &
+// processed into pending pool
+//
+// Returns error if subscription can't be set up, otherwise runs till
+// subscription fails/ `ctx` is cancelled
+//
+// @note Queued tx(s) can't be learnt about this way, so queued pool
+// stays empty
+func SubscribePendingTxs(ctx context.Context, res *data.Resource) error {
+
+	client, err := rpc.DialContext(ctx, config.Get("WSUrl"))
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	hashes := make(chan common.Hash, 4096)
+
+	sub, err := client.EthSubscribe(ctx, hashes, "newPendingTransactions")
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	log.Printf("[✅] Ingesting pending tx(s) over `newPendingTransactions` subscription\n")
+
+	seen := make([]common.Hash, 0, 1024)
+	ticker := time.NewTicker(time.Duration(config.GetMemPoolPollingPeriod()) * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+
+		select {
+
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case err := <-sub.Err():
+			return err
+
+		case hash := <-hashes:
+			seen = append(seen, hash)
+
+		case <-ticker.C:
+
+			// Subscription is still alive, so ingestion is healthy,
+			// even when nothing new showed up in quiet mempool
+			if len(seen) == 0 {
+				res.Pool.MarkPolled()
+				break
+			}
+
+			start := time.Now().UTC()
+
+			pending, err := fetchTxs(ctx, client, seen)
+			if err != nil {
+				log.Printf("[❗️] Failed to fetch %d subscribed tx(s) : %s\n", len(seen), err.Error())
+			}
+
+			seen = seen[:0]
+
+			if err == nil {
+				res.Pool.MarkPolled()
+			}
+
+			res.Pool.Process(ctx, pending, nil)
+			res.Pool.Stat(ctx, start)
+			res.Pool.ObserveSenderDominance(ctx)
+
+		}
+
+	}
+
+}
+
+// fetchTxs - Fetches given tx(s) in single batch, keeping only ones still
+// pending, grouped same way as `pending` section of `txpool_content`
+func fetchTxs(ctx context.Context, client *rpc.Client, hashes []common.Hash) (map[string]map[string]*data.MemPoolTx, error) {
+
+	txs := make([]*data.MemPoolTx, len(hashes))
+	batch := make([]rpc.BatchElem, len(hashes))
+
+	for i := range hashes {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_getTransactionByHash",
+			Args:   []interface{}{hashes[i].Hex()},
+			Result: &txs[i],
+		}
+	}
+
+	if err := client.BatchCallContext(ctx, batch); err != nil {
+		return nil, err
+	}
+
+	pending := make(map[string]map[string]*data.MemPoolTx)
+
+	for i, tx := range txs {
+
+		// Not found/ already mined by now
+		if batch[i].Error != nil || tx == nil || tx.BlockHash != nil {
+			continue
+		}
+
+		from := tx.From.Hex()
+		if _, ok := pending[from]; !ok {
+			pending[from] = make(map[string]*data.MemPoolTx)
+		}
+
+		pending[from][strconv.FormatUint(uint64(tx.Nonce), 10)] = tx
+
+	}
+
+	return pending, nil
+
+}
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// PendingTxs - #-of tx(s) currently living in pending pool, updated
+	// whenever tx joins/ leaves pool
+	PendingTxs = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "harmony",
+		Name:      "pending_txs",
+		Help:      "Number of tx(s) in pending pool",
+	})
+
+	// QueuedTxs - #-of tx(s) currently living in queued pool, updated
+	// whenever tx joins/ leaves pool
+	QueuedTxs = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "harmony",
+		Name:      "queued_txs",
+		Help:      "Number of tx(s) in queued pool",
+	})
+
+	// PruneDuration - How long it took for pending pool pruner to process
+	// tx(s) found in one mined block
+	PruneDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "harmony",
+		Name:      "prune_duration_seconds",
+		Help:      "Time taken for pruning pending pool, per mined block",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	// SenderTxShare - Largest share of pool, by #-of tx(s), held by single
+	// sender, labelled by pool, updated after each mempool poll
+	SenderTxShare = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "harmony",
+		Name:      "top_sender_tx_share",
+		Help:      "Largest share of pool, by number of tx(s), held by single sender",
+	}, []string{"pool"})
+
+	// SenderGasShare - Largest share of pool, by gas limit, held by single
+	// sender, labelled by pool, updated after each mempool poll
+	SenderGasShare = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "harmony",
+		Name:      "top_sender_gas_share",
+		Help:      "Largest share of pool, by gas limit, held by single sender",
+	}, []string{"pool"})
+
+	// UnstuckTxs - #-of tx(s) moved from queued pool to pending pool,
+	// after they got unstuck
+	UnstuckTxs = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "harmony",
+		Name:      "unstuck_txs_total",
+		Help:      "Number of tx(s) moved from queued pool to pending pool",
+	})
+)
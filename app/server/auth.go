@@ -0,0 +1,92 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/itzmeanjan/harmony/app/data"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"golang.org/x/time/rate"
+)
+
+// APIKeyHeader - Header in which client is expected to send its API key
+const APIKeyHeader = "X-API-Key"
+
+// apiKeyOf - Extracts API key from request header, falling back to query
+// param, because websocket clients can't always set custom headers
+func apiKeyOf(c echo.Context) string {
+
+	if key := c.Request().Header.Get(APIKeyHeader); len(key) != 0 {
+		return key
+	}
+
+	return c.QueryParam("apiKey")
+
+}
+
+// apiKeyAuth - Rejects request with 401, if it doesn't carry any of
+// configured API keys
+func apiKeyAuth(keys []string) echo.MiddlewareFunc {
+
+	allowed := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		allowed[key] = struct{}{}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+
+		return func(c echo.Context) error {
+
+			if _, ok := allowed[apiKeyOf(c)]; !ok {
+
+				return c.JSON(http.StatusUnauthorized, &data.Msg{
+					Message: "Missing/ invalid API key",
+				})
+
+			}
+
+			return next(c)
+
+		}
+
+	}
+
+}
+
+// apiKeyRateLimit - Rejects request with 429, if API key it carries has
+// already exhausted its allowance
+func apiKeyRateLimit() echo.MiddlewareFunc {
+
+	return middleware.RateLimiterWithConfig(middleware.RateLimiterConfig{
+		Skipper: middleware.DefaultSkipper,
+		IdentifierExtractor: func(c echo.Context) (string, error) {
+			return apiKeyOf(c), nil
+		},
+		Store: middleware.NewRateLimiterMemoryStoreWithConfig(middleware.RateLimiterMemoryStoreConfig{
+			Rate:  rate.Limit(config.GetAPIRateLimit()),
+			Burst: config.GetAPIRateBurst(),
+		}),
+		DenyHandler: func(c echo.Context, identifier string, err error) error {
+
+			return c.JSON(http.StatusTooManyRequests, &data.Msg{
+				Message: "Rate limit exceeded",
+			})
+
+		},
+	})
+
+}
+
+// apiAccessControl - Middlewares to be applied on all API endpoints, when
+// API keys are configured, otherwise access is kept open
+func apiAccessControl() []echo.MiddlewareFunc {
+
+	keys := config.GetAPIKeys()
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return []echo.MiddlewareFunc{apiKeyAuth(keys), apiKeyRateLimit()}
+
+}
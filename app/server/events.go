@@ -0,0 +1,139 @@
+package server
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/websocket"
+	"github.com/itzmeanjan/harmony/app/data"
+	"github.com/itzmeanjan/harmony/app/graph"
+	"github.com/itzmeanjan/harmony/app/graph/model"
+	"github.com/labstack/echo/v4"
+)
+
+// EventBufferSize - At max these many events are kept buffered for each
+// websocket client, if it's consuming slowly, newer events are dropped
+const EventBufferSize = 256
+
+// EventFilter - Message to be sent by websocket client, for restricting
+// events only to tx(s) sent from given address
+//
+// @note Empty `From` clears filter
+type EventFilter struct {
+	From string `json:"from"`
+}
+
+// senderFilter - Concurrent safe sender address filter, set by client
+// reader & checked by pubsub listener
+type senderFilter struct {
+	lock sync.RWMutex
+	from *common.Address
+}
+
+func (s *senderFilter) set(from *common.Address) {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.from = from
+
+}
+
+func (s *senderFilter) allows(tx *data.MemPoolTx, _ ...interface{}) bool {
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.from == nil || tx.IsSentFrom(*s.from)
+
+}
+
+var eventsUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+// streamEvents - Bridges tx(s) joining/ leaving pending & queued pool, as published
+// on pubsub topics, to websocket client, as JSON
+//
+// Events are buffered in bounded channel, so slow client never blocks
+// pubsub listener, rather events get dropped
+func streamEvents(c echo.Context) error {
+
+	conn, err := eventsUpgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	subscriber, err := graph.SubscribeToMemPool(ctx)
+	if err != nil {
+
+		log.Printf("[❗️] Failed to subscribe to mempool events : %s\n", err.Error())
+		return nil
+
+	}
+
+	filter := &senderFilter{}
+	comm := make(chan *model.MemPoolTx, EventBufferSize)
+
+	go graph.ListenToMessages(ctx, subscriber, comm, filter.allows)
+
+	// Reading filter messages sent by client, until
+	// it goes away
+	go func() {
+
+		defer cancel()
+
+		for {
+
+			var msg EventFilter
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+
+			if len(msg.From) == 0 {
+				filter.set(nil)
+				continue
+			}
+
+			if !common.IsHexAddress(msg.From) {
+				continue
+			}
+
+			from := common.HexToAddress(msg.From)
+			filter.set(&from)
+
+		}
+
+	}()
+
+	for {
+
+		select {
+
+		case <-ctx.Done():
+			return nil
+
+		case tx, ok := <-comm:
+
+			if !ok {
+				return nil
+			}
+
+			if err := conn.WriteJSON(tx); err != nil {
+				return nil
+			}
+
+		}
+
+	}
+
+}
@@ -2,24 +2,35 @@ package server
 
 import (
 	"context"
+	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"net/http"
+	"regexp"
+	"strconv"
 	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/websocket"
 	"github.com/itzmeanjan/harmony/app/config"
 	"github.com/itzmeanjan/harmony/app/data"
 	"github.com/itzmeanjan/harmony/app/graph"
 	"github.com/itzmeanjan/harmony/app/graph/generated"
+	"github.com/itzmeanjan/harmony/app/graph/model"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+// txHashRegex - Tx hash must be 0x prefixed, 32 bytes hex encoded string
+var txHashRegex = regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+
 // Start - Life cycle definition of http server
 func Start(ctx context.Context, res *data.Resource) {
 
@@ -37,7 +48,39 @@ func Start(ctx context.Context, res *data.Resource) {
 			AllowMethods: []string{http.MethodGet, http.MethodPost},
 		}))
 
-	v1 := router.Group("/v1")
+	// Prometheus metrics, kept out of API key protected group,
+	// so that scrapers can reach it
+	router.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
+
+	// Readiness probe, also kept out of API key protected group,
+	// healthy only if mempool was fetched successfully within
+	// last 2 polling periods
+	router.GET("/health", func(c echo.Context) error {
+
+		lastPolledAt := res.Pool.LastPolledAt()
+		threshold := 2 * time.Duration(config.GetMemPoolPollingPeriod()) * time.Millisecond
+
+		health := &data.Health{
+			Healthy:         !lastPolledAt.IsZero() && time.Now().UTC().Sub(lastPolledAt) <= threshold,
+			PendingPoolSize: res.Pool.PendingPoolLength(c.Request().Context()),
+			QueuedPoolSize:  res.Pool.QueuedPoolLength(c.Request().Context()),
+		}
+
+		if !lastPolledAt.IsZero() {
+			health.LastPolledAt = lastPolledAt.Format(time.RFC3339)
+		}
+
+		if !health.Healthy {
+			return c.JSON(http.StatusServiceUnavailable, health)
+		}
+
+		return c.JSON(http.StatusOK, health)
+
+	})
+
+	// If API keys are configured, only requests carrying one of
+	// them are let through, each key being rate limited
+	v1 := router.Group("/v1", apiAccessControl()...)
 
 	graphql := handler.NewDefaultServer(generated.NewExecutableSchema(
 		generated.Config{
@@ -71,13 +114,13 @@ func Start(ctx context.Context, res *data.Resource) {
 
 		v1.GET("/stat", func(c echo.Context) error {
 
-			latestBlock := res.Pool.LastSeenBlock()
+			latestBlock := res.Pool.LastSeenBlock(c.Request().Context())
 
 			return c.JSON(http.StatusOK, &data.Stat{
-				PendingPoolSize: res.Pool.PendingPoolLength(),
-				QueuedPoolSize:  res.Pool.QueuedPoolLength(),
+				PendingPoolSize: res.Pool.PendingPoolLength(c.Request().Context()),
+				QueuedPoolSize:  res.Pool.QueuedPoolLength(c.Request().Context()),
 				Uptime:          time.Now().UTC().Sub(res.StartedAt).String(),
-				Processed:       res.Pool.DoneTxCount(),
+				Processed:       res.Pool.DoneTxCount(c.Request().Context()),
 				LatestBlock:     latestBlock.Number,
 				SeenAgo:         time.Now().UTC().Sub(latestBlock.At).String(),
 				NetworkID:       res.NetworkID,
@@ -85,6 +128,240 @@ func Start(ctx context.Context, res *data.Resource) {
 
 		})
 
+		v1.GET("/gasprice", func(c echo.Context) error {
+
+			return c.JSON(http.StatusOK, res.Pool.RecommendGasPrice(c.Request().Context()))
+
+		})
+
+		v1.GET("/txpool_content", func(c echo.Context) error {
+
+			return c.JSON(http.StatusOK, res.Pool.TxPoolContent(c.Request().Context()))
+
+		})
+
+		v1.GET("/count", func(c echo.Context) error {
+
+			var filter data.TxFilter
+
+			for param, addr := range map[string]**common.Address{"from": &filter.From, "to": &filter.To} {
+
+				v := c.QueryParam(param)
+				if len(v) == 0 {
+					continue
+				}
+
+				if !common.IsHexAddress(v) {
+
+					return c.JSON(http.StatusBadRequest, &data.Msg{
+						Message: fmt.Sprintf("Bad argument `%s`", param),
+					})
+
+				}
+
+				parsed := common.HexToAddress(v)
+				*addr = &parsed
+
+			}
+
+			if v := c.QueryParam("minGasPrice"); len(v) != 0 {
+
+				gasPrice, err := strconv.ParseFloat(v, 64)
+				if err != nil || gasPrice < 0 {
+
+					return c.JSON(http.StatusBadRequest, &data.Msg{
+						Message: "Bad argument `minGasPrice`",
+					})
+
+				}
+
+				filter.MinGasPrice = gasPrice
+
+			}
+
+			if v := c.QueryParam("minAge"); len(v) != 0 {
+
+				// Must fit in duration, when converted to
+				// nanoseconds
+				age, err := strconv.ParseUint(v, 10, 64)
+				if err != nil || age > uint64(math.MaxInt64/int64(time.Second)) {
+
+					return c.JSON(http.StatusBadRequest, &data.Msg{
+						Message: "Bad argument `minAge`",
+					})
+
+				}
+
+				filter.MinAge = time.Duration(age) * time.Second
+
+			}
+
+			switch pool := c.QueryParam("pool"); pool {
+			case "", "pending":
+
+				return c.JSON(http.StatusOK, &data.Counted{
+					Pool:  "pending",
+					Count: res.Pool.PendingCountMatching(c.Request().Context(), filter),
+				})
+
+			case "queued":
+
+				return c.JSON(http.StatusOK, &data.Counted{
+					Pool:  pool,
+					Count: res.Pool.QueuedCountMatching(c.Request().Context(), filter),
+				})
+
+			default:
+
+				return c.JSON(http.StatusBadRequest, &data.Msg{
+					Message: "Bad pool, expected one of {pending, queued}",
+				})
+
+			}
+
+		})
+
+		v1.GET("/top/:pool", func(c echo.Context) error {
+
+			x, err := strconv.ParseUint(c.QueryParam("x"), 10, 64)
+			if err != nil || x == 0 {
+
+				return c.JSON(http.StatusBadRequest, &data.Msg{
+					Message: "Bad argument `x`",
+				})
+
+			}
+
+			var order int
+
+			switch c.QueryParam("order") {
+			case "", "high":
+				order = data.DESC
+			case "low":
+				order = data.ASC
+			default:
+
+				return c.JSON(http.StatusBadRequest, &data.Msg{
+					Message: "Bad argument `order`, expected one of {high, low}",
+				})
+
+			}
+
+			var txs <-chan *data.MemPoolTx
+
+			switch c.Param("pool") {
+			case "pending":
+				txs = res.Pool.StreamTopXPending(c.Request().Context(), order, x)
+			case "queued":
+				txs = res.Pool.StreamTopXQueued(c.Request().Context(), order, x)
+			default:
+
+				return c.JSON(http.StatusBadRequest, &data.Msg{
+					Message: "Bad pool, expected one of {pending, queued}",
+				})
+
+			}
+
+			return streamJSON(c, txs)
+
+		})
+
+		v1.GET("/pending.csv", func(c echo.Context) error {
+
+			var txs <-chan *data.MemPoolTx
+
+			pool := c.QueryParam("pool")
+			if pool == "" {
+				pool = "pending"
+			}
+
+			// Whole pool is streamed, in descending order of
+			// gas price, one chunk at a time
+			switch pool {
+			case "pending":
+				txs = res.Pool.StreamTopXPending(c.Request().Context(), data.DESC, math.MaxUint64)
+			case "queued":
+				txs = res.Pool.StreamTopXQueued(c.Request().Context(), data.DESC, math.MaxUint64)
+			default:
+
+				return c.JSON(http.StatusBadRequest, &data.Msg{
+					Message: "Bad pool, expected one of {pending, queued}",
+				})
+
+			}
+
+			return streamCSV(c, pool+".csv", txs)
+
+		})
+
+		v1.GET("/pending.parquet", func(c echo.Context) error {
+
+			c.Response().Header().Set(echo.HeaderContentType, "application/vnd.apache.parquet")
+			c.Response().WriteHeader(http.StatusOK)
+
+			// Status is already sent, so failure midway can only
+			// be logged, while client ends up with truncated file
+			if err := res.Pool.WritePendingParquet(c.Request().Context(), c.Response()); err != nil {
+				log.Printf("[❗️] Failed to export pending pool as parquet : %s\n", err.Error())
+			}
+
+			return nil
+
+		})
+
+		v1.GET("/tx/:hash", func(c echo.Context) error {
+
+			hash := c.Param("hash")
+			if !txHashRegex.MatchString(hash) {
+
+				return c.JSON(http.StatusBadRequest, &data.Msg{
+					Message: "Bad tx hash",
+				})
+
+			}
+
+			// Pending pool is looked up first, because that's
+			// where tx(s) are most likely to be found
+			tx := res.Pool.Pending.Get(c.Request().Context(), common.HexToHash(hash))
+			if tx == nil {
+				tx = res.Pool.Queued.Get(c.Request().Context(), common.HexToHash(hash))
+			}
+
+			if tx == nil {
+
+				return c.JSON(http.StatusNotFound, &model.MemPoolTx{
+					Hash: hash,
+					Pool: "absent",
+				})
+
+			}
+
+			return c.JSON(http.StatusOK, tx.ToGraphQL())
+
+		})
+
+		v1.GET("/events", streamEvents)
+
+		v1.GET("/prune/queued", func(c echo.Context) error {
+
+			return c.JSON(http.StatusOK, &data.PruneState{Paused: res.Pool.QueuedPrunePaused()})
+
+		})
+
+		v1.POST("/prune/queued/cancel", func(c echo.Context) error {
+
+			res.Pool.CancelQueuedPrune()
+			return c.JSON(http.StatusOK, &data.PruneState{Paused: res.Pool.QueuedPrunePaused()})
+
+		})
+
+		v1.POST("/prune/queued/resume", func(c echo.Context) error {
+
+			res.Pool.ResumeQueuedPrune()
+			return c.JSON(http.StatusOK, &data.PruneState{Paused: res.Pool.QueuedPrunePaused()})
+
+		})
+
 		v1.GET("/graphql", func(c echo.Context) error {
 
 			if !c.IsWebSocket() {
@@ -133,3 +410,99 @@ func Start(ctx context.Context, res *data.Resource) {
 	}
 
 }
+
+// streamJSON - Writes tx(s) received over channel as JSON array, one
+// element at a time, so that whole response never needs to be buffered
+// in memory
+func streamJSON(c echo.Context, txs <-chan *data.MemPoolTx) error {
+
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	c.Response().WriteHeader(http.StatusOK)
+
+	if _, err := c.Response().Write([]byte("[")); err != nil {
+		return err
+	}
+
+	var written uint64
+
+	for tx := range txs {
+
+		encoded, err := json.Marshal(tx.ToGraphQL())
+		if err != nil {
+			return err
+		}
+
+		if written != 0 {
+			if _, err := c.Response().Write([]byte(",")); err != nil {
+				return err
+			}
+		}
+
+		if _, err := c.Response().Write(encoded); err != nil {
+			return err
+		}
+
+		written++
+		if written%data.StreamChunkSize == 0 {
+			c.Response().Flush()
+		}
+
+	}
+
+	if _, err := c.Response().Write([]byte("]")); err != nil {
+		return err
+	}
+
+	c.Response().Flush()
+	return nil
+
+}
+
+// streamCSV - Writes tx(s) received over channel as CSV, header row first,
+// flushing every chunk, so that whole response never needs to be buffered
+// in memory, suggesting client to save it as `filename`
+//
+// @note Empty pool yields only header row
+func streamCSV(c echo.Context, filename string, txs <-chan *data.MemPoolTx) error {
+
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=UTF-8")
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	c.Response().WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(c.Response())
+
+	if err := w.Write(data.CSVHeader); err != nil {
+		return err
+	}
+
+	var written uint64
+
+	for tx := range txs {
+
+		if err := w.Write(tx.ToCSVRecord()); err != nil {
+			return err
+		}
+
+		written++
+		if written%data.StreamChunkSize == 0 {
+
+			w.Flush()
+			if err := w.Error(); err != nil {
+				return err
+			}
+
+			c.Response().Flush()
+
+		}
+
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+
+	c.Response().Flush()
+	return nil
+
+}
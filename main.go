@@ -40,6 +40,35 @@ func main() {
 
 	}
 
+	// Restoring pool state, as it was during last graceful
+	// shut down, if user has asked for it
+	if path := config.GetSnapshotFile(); len(path) != 0 {
+
+		if err := resources.Pool.LoadSnapshot(ctx, path); err != nil {
+			log.Printf("[❗️] Failed to restore pool snapshot : %s\n", err.Error())
+		}
+
+	}
+
+	// Warming up pool with recently mined tx(s), so that
+	// they're not mistaken as pending, if node reports them
+	//
+	// Live chain has nothing to do with recorded content, so it's
+	// skipped when replaying
+	if depth := config.GetBackfillDepth(); depth != 0 && len(config.GetReplayDir()) != 0 {
+
+		log.Printf("[❃] Skipping backfill, while replaying recorded mempool content\n")
+
+	} else if depth != 0 {
+
+		if n, err := resources.Pool.Backfill(ctx, depth); err != nil {
+			log.Printf("[❗️] Failed to backfill, after marking %d mined tx(s) : %s\n", n, err.Error())
+		} else {
+			log.Printf("[✅] Backfilled %d mined tx(s) from last %d block(s)\n", n, depth)
+		}
+
+	}
+
 	// To be passed to worker go routines, for listening to
 	// their state changes
 	comm := make(chan struct{}, 1)
@@ -88,6 +117,16 @@ func main() {
 
 			case <-interruptChan:
 
+				// Pool workers stop serving requests once asked to
+				// shut down, so snapshot must be taken before that
+				if path := config.GetSnapshotFile(); len(path) != 0 {
+
+					if err := resources.Pool.SaveSnapshot(ctx, path); err != nil {
+						log.Printf("[❗️] Failed to write pool snapshot : %s\n", err.Error())
+					}
+
+				}
+
 				// When interrupt is received, attempting to
 				// let all other go routines know, master go routine
 				// wants all to shut down, they must do a graceful shut down
@@ -117,8 +156,31 @@ func main() {
 
 	}()
 
+	// Mempool content to be fetched from RPC node, unless
+	// recorded responses are asked to be replayed
+	var source mempool.ContentSource = &mempool.RPCSource{Res: resources}
+
+	if dir := config.GetReplayDir(); len(dir) != 0 {
+
+		replay, err := mempool.NewReplaySource(dir)
+		if err != nil {
+
+			log.Printf("[❗️] Failed to set up replay of recorded mempool content : %s\n", err.Error())
+			os.Exit(1)
+
+		}
+
+		source = replay
+		log.Printf("[❃] Replaying recorded mempool content from %s\n", dir)
+
+	}
+
 	// Starting tx pool monitor as a seperate worker
-	go mempool.PollTxPoolContent(ctx, resources, comm)
+	go mempool.PollTxPoolContent(ctx, resources, source, comm)
+	// Failed RPC endpoint(s) health checker
+	go resources.Upstreams.CheckHealth(ctx, time.Duration(config.GetRPCHealthCheckPeriod())*time.Millisecond)
+	// Aggregate mempool stats publisher
+	go mempool.PublishPoolStats(ctx, resources)
 
 	// Main go routine, starts one http server &
 	// interfaces with external world